@@ -12,6 +12,10 @@ import (
 type Cache interface {
 	Set(ctx context.Context, key string, data interface{}, expiration time.Duration) error
 	Get(ctx context.Context, key string, data interface{}) error
+
+	// SetIfNotExists sets the key only when it doesn't exist, it reports
+	// whether the key was set. The check and the write are atomic.
+	SetIfNotExists(ctx context.Context, key string, data interface{}, expiration time.Duration) (bool, error)
 	Delete(ctx context.Context, key string) error
 }
 
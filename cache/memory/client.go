@@ -3,6 +3,7 @@ package mcache
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/go-redis/cache/v8"
@@ -10,6 +11,9 @@ import (
 
 type MemoryCache struct {
 	cache *cache.Cache
+
+	// mu makes SetIfNotExists's check and write atomic.
+	mu sync.Mutex
 }
 
 const cacheSize = 128000
@@ -45,6 +49,27 @@ func (m *MemoryCache) Get(ctx context.Context, key string, data interface{}) err
 	return nil
 }
 
+func (m *MemoryCache) SetIfNotExists(ctx context.Context, key string, data interface{}, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cache.Exists(ctx, key) {
+		return false, nil
+	}
+
+	err := m.cache.Set(&cache.Item{
+		Ctx:   ctx,
+		Key:   key,
+		Value: data,
+		TTL:   ttl,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (m *MemoryCache) Delete(ctx context.Context, key string) error {
 	return m.cache.Delete(ctx, key)
 }
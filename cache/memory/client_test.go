@@ -5,6 +5,8 @@ package mcache
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -51,3 +53,25 @@ func Test_DeleteFromCache(t *testing.T) {
 
 	require.Equal(t, "", item.Name)
 }
+
+func Test_SetIfNotExists(t *testing.T) {
+	cache := NewMemoryCache()
+
+	var wg sync.WaitGroup
+	var reserved int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ok, err := cache.SetIfNotExists(context.TODO(), key, &data{Name: "test_name"}, 10*time.Second)
+			require.NoError(t, err)
+			if ok {
+				atomic.AddInt32(&reserved, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, int32(1), reserved)
+}
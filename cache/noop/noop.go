@@ -19,6 +19,10 @@ func (n *NoopCache) Get(ctx context.Context, key string, data interface{}) error
 	return nil
 }
 
+func (n *NoopCache) SetIfNotExists(ctx context.Context, key string, data interface{}, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
 func (n *NoopCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
@@ -7,10 +7,12 @@ import (
 
 	"github.com/frain-dev/convoy/internal/pkg/rdb"
 	"github.com/go-redis/cache/v8"
+	"github.com/go-redis/redis/v8"
 )
 
 type RedisCache struct {
-	cache *cache.Cache
+	cache  *cache.Cache
+	client *redis.Client
 }
 
 func NewRedisCache(dsn string) (*RedisCache, error) {
@@ -24,7 +26,7 @@ func NewRedisCache(dsn string) (*RedisCache, error) {
 		Redis: rdb.Client(),
 	})
 
-	r := &RedisCache{cache: c}
+	r := &RedisCache{cache: c, client: rdb.Client()}
 
 	return r, nil
 }
@@ -52,6 +54,15 @@ func (r *RedisCache) Get(ctx context.Context, key string, data interface{}) erro
 	return nil
 }
 
+func (r *RedisCache) SetIfNotExists(ctx context.Context, key string, data interface{}, ttl time.Duration) (bool, error) {
+	b, err := r.cache.Marshal(data)
+	if err != nil {
+		return false, err
+	}
+
+	return r.client.SetNX(ctx, key, b, ttl).Result()
+}
+
 func (r *RedisCache) Delete(ctx context.Context, key string) error {
 	return r.cache.Delete(ctx, key)
 }
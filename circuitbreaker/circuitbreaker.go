@@ -0,0 +1,206 @@
+package circuitbreaker
+
+import (
+	"context"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+)
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half_open"
+)
+
+const (
+	defaultConsecutiveFailureThreshold uint64 = 10
+	defaultFailureRateThreshold        uint64 = 50
+	defaultMinimumRequestCount         uint64 = 20
+	defaultObservabilityWindow                = 5 * time.Minute
+	defaultCoolDown                           = time.Minute
+	defaultSuccessThreshold            uint64 = 5
+)
+
+// CircuitBreaker tracks the health of the endpoints events are sent to,
+// it is keyed by the endpoint's uid so it can be shared across workers.
+type CircuitBreaker interface {
+	// Allow reports the state of the breaker before a delivery is made,
+	// deliveries should not be sent while the returned breaker is open.
+	Allow(ctx context.Context, key string) (*Breaker, error)
+
+	// Record stores the outcome of a delivery and returns the updated breaker.
+	Record(ctx context.Context, key string, success bool) (*Breaker, error)
+
+	// Get fetches the breaker without modifying it.
+	Get(ctx context.Context, key string) (*Breaker, error)
+}
+
+func NewCircuitBreaker(cfg config.CircuitBreakerConfiguration) (CircuitBreaker, error) {
+	if cfg.Type == config.RedisCircuitBreakerProvider {
+		cb, err := NewRedisCircuitBreaker(cfg.Redis.Dsn, NewConfig(cfg))
+		if err != nil {
+			return nil, err
+		}
+
+		return cb, nil
+	}
+
+	return NewNoopCircuitBreaker(), nil
+}
+
+// Config holds the thresholds used to transition a breaker between states.
+type Config struct {
+	ConsecutiveFailureThreshold uint64
+	FailureRateThreshold        uint64
+	MinimumRequestCount         uint64
+	ObservabilityWindow         time.Duration
+	CoolDown                    time.Duration
+	SuccessThreshold            uint64
+}
+
+// NewConfig builds a Config from the application configuration,
+// falling back to the defaults for values that aren't set.
+func NewConfig(cfg config.CircuitBreakerConfiguration) Config {
+	c := Config{
+		ConsecutiveFailureThreshold: cfg.ConsecutiveFailureThreshold,
+		FailureRateThreshold:        cfg.FailureRateThreshold,
+		MinimumRequestCount:         cfg.MinimumRequestCount,
+		ObservabilityWindow:         time.Duration(cfg.ObservabilityWindow) * time.Second,
+		CoolDown:                    time.Duration(cfg.CoolDown) * time.Second,
+		SuccessThreshold:            cfg.SuccessThreshold,
+	}
+
+	if c.ConsecutiveFailureThreshold == 0 {
+		c.ConsecutiveFailureThreshold = defaultConsecutiveFailureThreshold
+	}
+
+	if c.FailureRateThreshold == 0 || c.FailureRateThreshold > 100 {
+		c.FailureRateThreshold = defaultFailureRateThreshold
+	}
+
+	if c.MinimumRequestCount == 0 {
+		c.MinimumRequestCount = defaultMinimumRequestCount
+	}
+
+	if c.ObservabilityWindow == 0 {
+		c.ObservabilityWindow = defaultObservabilityWindow
+	}
+
+	if c.CoolDown == 0 {
+		c.CoolDown = defaultCoolDown
+	}
+
+	if c.SuccessThreshold == 0 {
+		c.SuccessThreshold = defaultSuccessThreshold
+	}
+
+	return c
+}
+
+// Breaker is the state of the circuit breaker of a single endpoint.
+type Breaker struct {
+	Key                  string    `json:"key"`
+	State                State     `json:"state"`
+	Requests             uint64    `json:"requests"`
+	Failures             uint64    `json:"failures"`
+	ConsecutiveFailures  uint64    `json:"consecutive_failures"`
+	ConsecutiveSuccesses uint64    `json:"consecutive_successes"`
+	WindowStart          time.Time `json:"window_start"`
+	OpenedAt             time.Time `json:"opened_at,omitempty"`
+	WillResetAt          time.Time `json:"will_reset_at,omitempty"`
+}
+
+func newBreaker(key string, now time.Time) *Breaker {
+	return &Breaker{Key: key, State: StateClosed, WindowStart: now}
+}
+
+// IsOpen reports whether deliveries should be short-circuited.
+func (b *Breaker) IsOpen() bool {
+	return b.State == StateOpen
+}
+
+// RetryAfter returns how long is left before an open breaker is half-opened.
+func (b *Breaker) RetryAfter(now time.Time) time.Duration {
+	if !b.IsOpen() || !now.Before(b.WillResetAt) {
+		return 0
+	}
+
+	return b.WillResetAt.Sub(now)
+}
+
+// FailureRate returns the percentage of failed deliveries in the current window.
+func (b *Breaker) FailureRate() float64 {
+	if b.Requests == 0 {
+		return 0
+	}
+
+	return float64(b.Failures) / float64(b.Requests) * 100
+}
+
+// allow half-opens the breaker once its cool-down has elapsed,
+// it reports whether the breaker was modified.
+func (b *Breaker) allow(now time.Time) bool {
+	if b.State == StateOpen && !now.Before(b.WillResetAt) {
+		b.State = StateHalfOpen
+		b.ConsecutiveSuccesses = 0
+		return true
+	}
+
+	return false
+}
+
+// record applies the outcome of a delivery to the breaker.
+func (b *Breaker) record(success bool, now time.Time, cfg Config) {
+	if b.State == StateClosed && now.Sub(b.WindowStart) >= cfg.ObservabilityWindow {
+		b.resetWindow(now)
+	}
+
+	b.Requests++
+	if success {
+		b.ConsecutiveFailures = 0
+		b.ConsecutiveSuccesses++
+
+		if b.State == StateHalfOpen && b.ConsecutiveSuccesses >= cfg.SuccessThreshold {
+			b.close(now)
+		}
+		return
+	}
+
+	b.Failures++
+	b.ConsecutiveFailures++
+	b.ConsecutiveSuccesses = 0
+
+	switch b.State {
+	case StateHalfOpen:
+		b.open(now, cfg)
+	case StateClosed:
+		if b.ConsecutiveFailures >= cfg.ConsecutiveFailureThreshold ||
+			(b.Requests >= cfg.MinimumRequestCount && b.FailureRate() >= float64(cfg.FailureRateThreshold)) {
+			b.open(now, cfg)
+		}
+	}
+}
+
+func (b *Breaker) open(now time.Time, cfg Config) {
+	b.State = StateOpen
+	b.OpenedAt = now
+	b.WillResetAt = now.Add(cfg.CoolDown)
+}
+
+func (b *Breaker) close(now time.Time) {
+	b.State = StateClosed
+	b.OpenedAt = time.Time{}
+	b.WillResetAt = time.Time{}
+	b.resetWindow(now)
+}
+
+func (b *Breaker) resetWindow(now time.Time) {
+	b.Requests = 0
+	b.Failures = 0
+	b.ConsecutiveFailures = 0
+	b.ConsecutiveSuccesses = 0
+	b.WindowStart = now
+}
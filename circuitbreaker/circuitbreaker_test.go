@@ -0,0 +1,128 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() Config {
+	return Config{
+		ConsecutiveFailureThreshold: 3,
+		FailureRateThreshold:        50,
+		MinimumRequestCount:         6,
+		ObservabilityWindow:         time.Minute,
+		CoolDown:                    30 * time.Second,
+		SuccessThreshold:            2,
+	}
+}
+
+func TestNewConfig(t *testing.T) {
+	c := NewConfig(config.CircuitBreakerConfiguration{CoolDown: 10, FailureRateThreshold: 120})
+
+	require.Equal(t, 10*time.Second, c.CoolDown)
+	require.Equal(t, defaultFailureRateThreshold, c.FailureRateThreshold)
+	require.Equal(t, defaultConsecutiveFailureThreshold, c.ConsecutiveFailureThreshold)
+	require.Equal(t, defaultMinimumRequestCount, c.MinimumRequestCount)
+	require.Equal(t, defaultObservabilityWindow, c.ObservabilityWindow)
+	require.Equal(t, defaultSuccessThreshold, c.SuccessThreshold)
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		outcomes  []bool
+		elapsed   time.Duration
+		wantState State
+	}{
+		{
+			name:      "should_stay_closed_on_success",
+			outcomes:  []bool{true, true, true},
+			wantState: StateClosed,
+		},
+		{
+			name:      "should_open_after_consecutive_failures",
+			outcomes:  []bool{true, false, false, false},
+			wantState: StateOpen,
+		},
+		{
+			name:      "should_not_open_below_consecutive_failures",
+			outcomes:  []bool{false, false, true, false, false},
+			wantState: StateClosed,
+		},
+		{
+			name:      "should_open_when_failure_rate_is_exceeded",
+			outcomes:  []bool{false, true, false, true, false, false},
+			wantState: StateOpen,
+		},
+		{
+			name:      "should_not_open_below_minimum_request_count",
+			outcomes:  []bool{false, false, true, false},
+			wantState: StateClosed,
+		},
+		{
+			name:      "should_reset_window_after_it_elapses",
+			outcomes:  []bool{false, false},
+			elapsed:   2 * time.Minute,
+			wantState: StateClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			b := newBreaker("endpoint-1", now)
+
+			for i, outcome := range tt.outcomes {
+				at := now
+				if i == len(tt.outcomes)-1 {
+					at = now.Add(tt.elapsed)
+				}
+				b.record(outcome, at, cfg)
+			}
+
+			require.Equal(t, tt.wantState, b.State)
+		})
+	}
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	cfg := testConfig()
+	now := time.Now()
+
+	b := newBreaker("endpoint-1", now)
+	for i := 0; i < 3; i++ {
+		b.record(false, now, cfg)
+	}
+
+	require.True(t, b.IsOpen())
+	require.Equal(t, cfg.CoolDown, b.RetryAfter(now))
+
+	// cool-down hasn't elapsed
+	require.False(t, b.allow(now.Add(10*time.Second)))
+	require.True(t, b.IsOpen())
+
+	// a failed probe re-opens the breaker
+	require.True(t, b.allow(now.Add(cfg.CoolDown)))
+	require.Equal(t, StateHalfOpen, b.State)
+
+	b.record(false, now.Add(cfg.CoolDown), cfg)
+	require.True(t, b.IsOpen())
+	require.Equal(t, now.Add(2*cfg.CoolDown), b.WillResetAt)
+
+	// successful probes close the breaker
+	later := now.Add(2 * cfg.CoolDown)
+	require.True(t, b.allow(later))
+
+	b.record(true, later, cfg)
+	require.Equal(t, StateHalfOpen, b.State)
+
+	b.record(true, later, cfg)
+	require.Equal(t, StateClosed, b.State)
+	require.Equal(t, uint64(0), b.Requests)
+	require.Equal(t, time.Duration(0), b.RetryAfter(later))
+}
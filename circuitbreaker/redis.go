@@ -0,0 +1,124 @@
+package circuitbreaker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	keyPrefix = "circuit_breaker"
+
+	// maxTxRetries is the number of times an update is retried when
+	// another worker modifies the breaker concurrently.
+	maxTxRetries = 10
+)
+
+var ErrTooManyConflicts = errors.New("circuit breaker was modified concurrently too many times")
+
+// RedisCircuitBreaker stores breakers in redis so they're shared
+// by every worker delivering to the same endpoint.
+type RedisCircuitBreaker struct {
+	client *redis.Client
+	cfg    Config
+	now    func() time.Time
+}
+
+func NewRedisCircuitBreaker(dsn string, cfg Config) (*RedisCircuitBreaker, error) {
+	opts, err := redis.ParseURL(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(opts)
+
+	return &RedisCircuitBreaker{client: client, cfg: cfg, now: time.Now}, nil
+}
+
+func (r *RedisCircuitBreaker) Allow(ctx context.Context, key string) (*Breaker, error) {
+	return r.update(ctx, key, func(b *Breaker) bool {
+		return b.allow(r.now())
+	})
+}
+
+func (r *RedisCircuitBreaker) Record(ctx context.Context, key string, success bool) (*Breaker, error) {
+	return r.update(ctx, key, func(b *Breaker) bool {
+		now := r.now()
+		b.allow(now)
+		b.record(success, now, r.cfg)
+		return true
+	})
+}
+
+func (r *RedisCircuitBreaker) Get(ctx context.Context, key string) (*Breaker, error) {
+	return r.get(ctx, r.client, key)
+}
+
+// update applies fn to the breaker in an optimistic transaction, fn reports
+// whether the breaker was modified and needs to be written back.
+func (r *RedisCircuitBreaker) update(ctx context.Context, key string, fn func(b *Breaker) bool) (*Breaker, error) {
+	var b *Breaker
+	txFn := func(tx *redis.Tx) error {
+		var err error
+		b, err = r.get(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		if !fn(b) {
+			return nil
+		}
+
+		buf, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, redisKey(key), buf, r.cfg.ObservabilityWindow+r.cfg.CoolDown)
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < maxTxRetries; i++ {
+		err := r.client.Watch(ctx, txFn, redisKey(key))
+		if err == nil {
+			return b, nil
+		}
+
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+
+		return nil, err
+	}
+
+	return nil, ErrTooManyConflicts
+}
+
+func (r *RedisCircuitBreaker) get(ctx context.Context, c redis.Cmdable, key string) (*Breaker, error) {
+	buf, err := c.Get(ctx, redisKey(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return newBreaker(key, r.now()), nil
+		}
+		return nil, err
+	}
+
+	b := &Breaker{}
+	err = json.Unmarshal(buf, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func redisKey(key string) string {
+	return fmt.Sprintf("%s:%s", keyPrefix, key)
+}
@@ -0,0 +1,77 @@
+//go:build integration
+// +build integration
+
+package circuitbreaker
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func getDSN() string {
+	return os.Getenv("TEST_REDIS_DSN")
+}
+
+func flushRedis(dsn string) error {
+	opts, err := redis.ParseURL(dsn)
+	if err != nil {
+		return err
+	}
+
+	client := redis.NewClient(opts)
+
+	_, err = client.FlushAll(context.Background()).Result()
+
+	return err
+}
+
+func Test_RedisCircuitBreaker(t *testing.T) {
+	dsn := getDSN()
+
+	err := flushRedis(dsn)
+	require.NoError(t, err)
+
+	cfg := testConfig()
+	cb, err := NewRedisCircuitBreaker(dsn, cfg)
+	require.NoError(t, err)
+
+	now := time.Now()
+	cb.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	b, err := cb.Allow(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.Equal(t, StateClosed, b.State)
+
+	for i := 0; i < 3; i++ {
+		b, err = cb.Record(ctx, "endpoint-1", false)
+		require.NoError(t, err)
+	}
+	require.True(t, b.IsOpen())
+
+	b, err = cb.Get(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.True(t, b.IsOpen())
+
+	b, err = cb.Get(ctx, "endpoint-2")
+	require.NoError(t, err)
+	require.Equal(t, StateClosed, b.State)
+
+	now = now.Add(cfg.CoolDown)
+
+	b, err = cb.Allow(ctx, "endpoint-1")
+	require.NoError(t, err)
+	require.Equal(t, StateHalfOpen, b.State)
+
+	for i := 0; i < 2; i++ {
+		b, err = cb.Record(ctx, "endpoint-1", true)
+		require.NoError(t, err)
+	}
+	require.Equal(t, StateClosed, b.State)
+}
@@ -11,7 +11,9 @@ import (
 	log "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	convoyNet "github.com/frain-dev/convoy/net"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
 	"github.com/olekukonko/tablewriter"
@@ -57,6 +59,21 @@ func createEndpointCommand(a *app) *cobra.Command {
 				return err
 			}
 
+			cfg, err := config.Get()
+			if err != nil {
+				return err
+			}
+
+			policy, err := convoyNet.NewEgressPolicy(cfg.Egress)
+			if err != nil {
+				return err
+			}
+
+			err = policy.CheckURL(context.Background(), s)
+			if err != nil {
+				return err
+			}
+
 			e.TargetURL = s
 
 			e.UID = uuid.New().String()
@@ -29,13 +29,16 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/circuitbreaker"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/limiter"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/spf13/cobra"
 
+	"github.com/frain-dev/convoy/datastore/memory"
 	cm "github.com/frain-dev/convoy/datastore/mongo"
+	"github.com/frain-dev/convoy/datastore/postgres"
 )
 
 func main() {
@@ -55,9 +58,8 @@ func main() {
 	}
 
 	app := &app{}
-	db := &cm.Client{}
 
-	cli := NewCli(app, db)
+	cli := NewCli(app)
 	if err := cli.Execute(); err != nil {
 		log.Fatal(err)
 	}
@@ -105,6 +107,7 @@ func ensureDefaultUser(ctx context.Context, a *app) error {
 }
 
 type app struct {
+	db                datastore.Database
 	apiKeyRepo        datastore.APIKeyRepository
 	groupRepo         datastore.GroupRepository
 	applicationRepo   datastore.ApplicationRepository
@@ -118,11 +121,13 @@ type app struct {
 	sourceRepo        datastore.SourceRepository
 	userRepo          datastore.UserRepository
 	configRepo        datastore.ConfigurationRepository
+	deadLetterRepo    datastore.DeadLetterRepository
 	queue             queue.Queuer
 	logger            logger.Logger
 	tracer            tracer.Tracer
 	cache             cache.Cache
 	limiter           limiter.RateLimiter
+	circuitBreaker    circuitbreaker.CircuitBreaker
 	searcher          searcher.Searcher
 }
 
@@ -130,7 +135,19 @@ func getCtx() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), time.Second*1)
 }
 
-func preRun(app *app, db *cm.Client) func(cmd *cobra.Command, args []string) error {
+// newDatabase connects to the storage backend selected by the database type.
+func newDatabase(cfg config.Configuration) (datastore.Database, error) {
+	switch cfg.Database.Type {
+	case config.PostgresDatabaseProvider:
+		return postgres.New(cfg)
+	case config.InMemoryDatabaseProvider:
+		return memory.New(cfg)
+	default:
+		return cm.New(cfg)
+	}
+}
+
+func preRun(app *app) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		cfgPath, err := cmd.Flags().GetString("config")
 		if err != nil {
@@ -157,6 +174,11 @@ func preRun(app *app, db *cm.Client) func(cmd *cobra.Command, args []string) err
 			return err
 		}
 
+		cfg, err = config.Get()
+		if err != nil {
+			return err
+		}
+
 		nwCfg := cfg.Tracer.NewRelic
 		nRApp, err := newrelic.NewApplication(
 			newrelic.ConfigAppName(nwCfg.AppName),
@@ -171,15 +193,15 @@ func preRun(app *app, db *cm.Client) func(cmd *cobra.Command, args []string) err
 
 		apm.SetApplication(nRApp)
 
-		database, err := cm.New(cfg)
+		db, err := newDatabase(cfg)
 		if err != nil {
 			return err
 		}
 
-		*db = *database
+		app.db = db
 
 		// Check Pending Migrations
-		if len(cmd.Aliases) > 0 {
+		if _, isMongo := db.(*cm.Client); isMongo && len(cmd.Aliases) > 0 {
 			alias := cmd.Aliases[0]
 			shouldSkip := strings.HasPrefix(alias, "migrate")
 			if !shouldSkip {
@@ -239,6 +261,11 @@ func preRun(app *app, db *cm.Client) func(cmd *cobra.Command, args []string) err
 			return err
 		}
 
+		cb, err := circuitbreaker.NewCircuitBreaker(cfg.CircuitBreaker)
+		if err != nil {
+			return err
+		}
+
 		se, err := searcher.NewSearchClient(cfg)
 		if err != nil {
 			return err
@@ -257,6 +284,7 @@ func preRun(app *app, db *cm.Client) func(cmd *cobra.Command, args []string) err
 		app.orgRepo = db.OrganisationRepo()
 		app.orgMemberRepo = db.OrganisationMemberRepo()
 		app.orgInviteRepo = db.OrganisationInviteRepo()
+		app.deadLetterRepo = db.DeadLetterRepo()
 		app.deviceRepo = db.DeviceRepo()
 
 		app.queue = q
@@ -264,15 +292,16 @@ func preRun(app *app, db *cm.Client) func(cmd *cobra.Command, args []string) err
 		app.tracer = tr
 		app.cache = ca
 		app.limiter = li
+		app.circuitBreaker = cb
 		app.searcher = se
 
 		return ensureDefaultUser(context.Background(), app)
 	}
 }
 
-func postRun(app *app, db *cm.Client) func(cmd *cobra.Command, args []string) error {
+func postRun(app *app) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		err := db.Disconnect(context.Background())
+		err := app.db.Disconnect(context.Background())
 		if err == nil {
 			os.Exit(0)
 		}
@@ -312,15 +341,15 @@ type ConvoyCli struct {
 	cmd *cobra.Command
 }
 
-func NewCli(app *app, db *cm.Client) ConvoyCli {
+func NewCli(app *app) ConvoyCli {
 	cmd := &cobra.Command{
 		Use:     "Convoy",
 		Version: convoy.GetVersion(),
 		Short:   "Fast & reliable webhooks service",
 	}
 
-	cmd.PersistentPreRunE = preRun(app, db)
-	cmd.PersistentPostRunE = postRun(app, db)
+	cmd.PersistentPreRunE = preRun(app)
+	cmd.PersistentPostRunE = postRun(app)
 	parsePersistentArgs(app, cmd)
 
 	return ConvoyCli{cmd: cmd}
@@ -344,8 +373,17 @@ func buildCliConfiguration(cmd *cobra.Command) (*config.Configuration, error) {
 	}
 
 	if !util.IsStringEmpty(dbDsn) {
+		dbType := config.MongodbDatabaseProvider
+		if strings.HasPrefix(dbDsn, "postgres://") || strings.HasPrefix(dbDsn, "postgresql://") {
+			dbType = config.PostgresDatabaseProvider
+		}
+
+		if dbDsn == string(config.InMemoryDatabaseProvider) {
+			dbType = config.InMemoryDatabaseProvider
+		}
+
 		c.Database = config.DatabaseConfiguration{
-			Type: config.MongodbDatabaseProvider,
+			Type: dbType,
 			Dsn:  dbDsn,
 		}
 	}
@@ -372,7 +410,7 @@ func buildCliConfiguration(cmd *cobra.Command) (*config.Configuration, error) {
 	return c, nil
 }
 
-func checkPendingMigrations(dbDsn string, db *cm.Client) error {
+func checkPendingMigrations(dbDsn string, db datastore.Database) error {
 	c := db.Client().(*mongo.Database).Client()
 	u, err := url.Parse(dbDsn)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/frain-dev/convoy/config"
 	cm "github.com/frain-dev/convoy/datastore/mongo"
+	"github.com/frain-dev/convoy/datastore/postgres"
 	"github.com/frain-dev/convoy/internal/pkg/migrate"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -36,6 +37,22 @@ func addUpCommand() *cobra.Command {
 				log.WithError(err).Fatalf("Error fetching the config.")
 			}
 
+			if cfg.Database.Type == config.InMemoryDatabaseProvider {
+				log.Info("The in-memory database has no migrations to run")
+				return
+			}
+
+			if cfg.Database.Type == config.PostgresDatabaseProvider {
+				// postgres.New applies every pending schema migration
+				db, err := postgres.New(cfg)
+				if err != nil {
+					log.WithError(err).Fatalf("Error running migrations")
+				}
+
+				_ = db.Disconnect(context.Background())
+				return
+			}
+
 			db, err := cm.New(cfg)
 			if err != nil {
 				log.WithError(err).Fatalf("Error instantiating a database client")
@@ -78,6 +95,10 @@ func addDownCommand() *cobra.Command {
 				log.WithError(err).Fatalf("Error fetching the config.")
 			}
 
+			if cfg.Database.Type == config.PostgresDatabaseProvider || cfg.Database.Type == config.InMemoryDatabaseProvider {
+				log.Fatalf("Rolling back migrations is not supported by the %s database", cfg.Database.Type)
+			}
+
 			db, err := cm.New(cfg)
 			if err != nil {
 				log.WithError(err).Fatalf("Error instantiating a database client")
@@ -1,19 +1,15 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"time"
 
-	"github.com/frain-dev/convoy"
-	"github.com/frain-dev/convoy/analytics"
 	"github.com/frain-dev/convoy/auth/realm_chain"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/internal/pkg/server"
-	"github.com/frain-dev/convoy/internal/pkg/smtp"
 	route "github.com/frain-dev/convoy/server"
 	"github.com/frain-dev/convoy/util"
-	"github.com/frain-dev/convoy/worker"
-	"github.com/frain-dev/convoy/worker/task"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -24,6 +20,7 @@ func addServerCommand(a *app) *cobra.Command {
 	var host string
 	var sentry string
 	var limiter string
+	var circuitBreaker string
 	var cache string
 	var logger string
 	var searcher string
@@ -102,6 +99,7 @@ func addServerCommand(a *app) *cobra.Command {
 	cmd.Flags().StringVar(&host, "host", "", "Host - The application host name")
 	cmd.Flags().StringVar(&cache, "cache", "redis", `Cache Provider ("redis" or "in-memory")`)
 	cmd.Flags().StringVar(&limiter, "limiter", "redis", `Rate limiter provider ("redis" or "in-memory")`)
+	cmd.Flags().StringVar(&circuitBreaker, "circuit-breaker", "", `Endpoint circuit breaker provider ("redis")`)
 	cmd.Flags().StringVar(&sentry, "sentry", "", "Sentry DSN")
 	cmd.Flags().StringVar(&sslCertFile, "ssl-cert-file", "", "SSL certificate file")
 	cmd.Flags().StringVar(&sslKeyFile, "ssl-key-file", "", "SSL key file")
@@ -170,6 +168,7 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 			UserRepo:          a.userRepo,
 			ConfigRepo:        a.configRepo,
 			DeviceRepo:        a.deviceRepo,
+			DeadLetterRepo:    a.deadLetterRepo,
 		}, route.Services{
 			Queue:    a.queue,
 			Logger:   a.logger,
@@ -177,68 +176,15 @@ func StartConvoyServer(a *app, cfg config.Configuration, withWorkers bool) error
 			Cache:    a.cache,
 			Limiter:  a.limiter,
 			Searcher: a.searcher,
+
+			CircuitBreaker: a.circuitBreaker,
 		})
 
 	if withWorkers {
-		sc, err := smtp.NewClient(&cfg.SMTP)
+		err = startWorkers(context.Background(), a, cfg)
 		if err != nil {
-			log.WithError(err).Error("Failed to create smtp client")
 			return err
 		}
-
-		// register worker.
-		consumer, err := worker.NewConsumer(a.queue)
-		if err != nil {
-			log.WithError(err).Error("failed to create worker")
-		}
-
-		consumer.RegisterHandlers(convoy.EventProcessor, task.ProcessEventDelivery(
-			a.applicationRepo,
-			a.eventDeliveryRepo,
-			a.groupRepo,
-			a.limiter,
-			a.subRepo,
-			a.queue))
-
-		consumer.RegisterHandlers(convoy.CreateEventProcessor, task.ProcessEventCreation(
-			a.applicationRepo,
-			a.eventRepo,
-			a.groupRepo,
-			a.eventDeliveryRepo,
-			a.cache,
-			a.queue,
-			a.subRepo,
-			a.searcher,
-			a.deviceRepo))
-
-		consumer.RegisterHandlers(convoy.RetentionPolicies, task.RententionPolicies(
-			cfg,
-			a.configRepo,
-			a.groupRepo,
-			a.eventRepo,
-			a.eventDeliveryRepo,
-			a.searcher))
-
-		consumer.RegisterHandlers(convoy.MonitorTwitterSources, task.MonitorTwitterSources(
-			a.sourceRepo,
-			a.subRepo,
-			a.applicationRepo,
-			a.queue))
-
-		consumer.RegisterHandlers(convoy.DailyAnalytics, analytics.TrackDailyAnalytics(&analytics.Repo{
-			ConfigRepo: a.configRepo,
-			EventRepo:  a.eventRepo,
-			GroupRepo:  a.groupRepo,
-			OrgRepo:    a.orgRepo,
-			UserRepo:   a.userRepo,
-		}, cfg))
-
-		consumer.RegisterHandlers(convoy.EmailProcessor, task.ProcessEmails(sc))
-		consumer.RegisterHandlers(convoy.NotificationProcessor, task.ProcessNotifications(sc))
-
-		//start worker
-		log.Infof("Starting Convoy workers...")
-		consumer.Start()
 	}
 
 	srv.SetHandler(handler.BuildRoutes())
@@ -310,6 +256,19 @@ func buildServerCliConfiguration(cmd *cobra.Command) (*config.Configuration, err
 		}
 	}
 
+	// CONVOY_CIRCUIT_BREAKER_TYPE
+	circuitBreaker, err := cmd.Flags().GetString("circuit-breaker")
+	if err != nil {
+		return nil, err
+	}
+
+	if !util.IsStringEmpty(circuitBreaker) {
+		c.CircuitBreaker.Type = config.CircuitBreakerProvider(circuitBreaker)
+		if circuitBreaker == "redis" && !util.IsStringEmpty(redis) {
+			c.CircuitBreaker.Redis.Dsn = redis
+		}
+	}
+
 	// CONVOY_CACHE_PROVIDER
 	cache, err := cmd.Flags().GetString("cache")
 	if err != nil {
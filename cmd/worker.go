@@ -9,7 +9,10 @@ import (
 	"github.com/frain-dev/convoy/analytics"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub"
+	"github.com/frain-dev/convoy/internal/pkg/rdb"
 	"github.com/frain-dev/convoy/internal/pkg/smtp"
+	convoyNet "github.com/frain-dev/convoy/net"
 	"github.com/frain-dev/convoy/worker"
 	"github.com/frain-dev/convoy/worker/task"
 	"github.com/go-chi/chi/v5"
@@ -31,69 +34,15 @@ func addWorkerCommand(a *app) *cobra.Command {
 				return err
 			}
 
-			sc, err := smtp.NewClient(&cfg.SMTP)
-			if err != nil {
-				log.WithError(err).Error("Failed to create smtp client")
-				return err
-			}
-
 			ctx := context.Background()
 
-			// register worker.
-			consumer, err := worker.NewConsumer(a.queue)
+			err = startWorkers(ctx, a, cfg)
 			if err != nil {
-				log.WithError(err).Error("failed to create worker")
+				return err
 			}
 
-			consumer.RegisterHandlers(convoy.EventProcessor, task.ProcessEventDelivery(
-				a.applicationRepo,
-				a.eventDeliveryRepo,
-				a.groupRepo,
-				a.limiter,
-				a.subRepo,
-				a.queue))
-
-			consumer.RegisterHandlers(convoy.CreateEventProcessor, task.ProcessEventCreation(
-				a.applicationRepo,
-				a.eventRepo,
-				a.groupRepo,
-				a.eventDeliveryRepo,
-				a.cache,
-				a.queue,
-				a.subRepo,
-				a.searcher,
-				a.deviceRepo))
-
-			consumer.RegisterHandlers(convoy.RetentionPolicies, task.RententionPolicies(
-				cfg,
-				a.configRepo,
-				a.groupRepo,
-				a.eventRepo,
-				a.eventDeliveryRepo,
-				a.searcher))
-
-			consumer.RegisterHandlers(convoy.MonitorTwitterSources, task.MonitorTwitterSources(
-				a.sourceRepo,
-				a.subRepo,
-				a.applicationRepo,
-				a.queue))
-
-			consumer.RegisterHandlers(convoy.DailyAnalytics, analytics.TrackDailyAnalytics(&analytics.Repo{
-				ConfigRepo: a.configRepo,
-				EventRepo:  a.eventRepo,
-				GroupRepo:  a.groupRepo,
-				OrgRepo:    a.orgRepo,
-				UserRepo:   a.userRepo,
-			}, cfg))
-
-			consumer.RegisterHandlers(convoy.EmailProcessor, task.ProcessEmails(sc))
-			consumer.RegisterHandlers(convoy.NotificationProcessor, task.ProcessNotifications(sc))
-
-			//start worker
-			log.Infof("Starting Convoy workers...")
-			consumer.Start()
-
 			metrics.RegisterQueueMetrics(a.queue)
+			metrics.RegisterDispatcherMetrics()
 
 			router := chi.NewRouter()
 			router.Handle("/metrics", promhttp.HandlerFor(metrics.Reg(), promhttp.HandlerOpts{}))
@@ -121,3 +70,100 @@ func addWorkerCommand(a *app) *cobra.Command {
 	cmd.Flags().Uint32Var(&workerPort, "worker-port", 5006, "Worker port")
 	return cmd
 }
+
+// startWorkers registers the handlers of every task, starts consuming them
+// and starts ingesting the events of pub sub sources. Workers and servers
+// started with workers share it, so both process the same tasks.
+func startWorkers(ctx context.Context, a *app, cfg config.Configuration) error {
+	sc, err := smtp.NewClient(&cfg.SMTP)
+	if err != nil {
+		log.WithError(err).Error("Failed to create smtp client")
+		return err
+	}
+
+	// register worker.
+	consumer, err := worker.NewConsumer(a.queue)
+	if err != nil {
+		log.WithError(err).Error("failed to create worker")
+		return err
+	}
+
+	// deliveries share the pool's connections.
+	dispatchers, err := convoyNet.NewDispatcherPool(cfg)
+	if err != nil {
+		log.WithError(err).Error("failed to create dispatcher pool")
+		return err
+	}
+
+	consumer.RegisterHandlers(convoy.EventProcessor, task.ProcessEventDelivery(
+		a.applicationRepo,
+		a.eventDeliveryRepo,
+		a.groupRepo,
+		a.limiter,
+		a.circuitBreaker,
+		a.subRepo,
+		a.queue,
+		a.cache,
+		dispatchers))
+
+	consumer.RegisterHandlers(convoy.DeadLetterProcessor, task.ProcessDeadLetters(
+		a.eventDeliveryRepo,
+		a.deadLetterRepo))
+
+	consumer.RegisterHandlers(convoy.CreateEventProcessor, task.ProcessEventCreation(
+		a.applicationRepo,
+		a.eventRepo,
+		a.groupRepo,
+		a.eventDeliveryRepo,
+		a.cache,
+		a.queue,
+		a.subRepo,
+		a.searcher,
+		a.deviceRepo))
+
+	consumer.RegisterHandlers(convoy.RetentionPolicies, task.RententionPolicies(
+		cfg,
+		a.configRepo,
+		a.groupRepo,
+		a.eventRepo,
+		a.eventDeliveryRepo,
+		a.searcher))
+
+	consumer.RegisterHandlers(convoy.MonitorTwitterSources, task.MonitorTwitterSources(
+		a.sourceRepo,
+		a.subRepo,
+		a.applicationRepo,
+		a.queue))
+
+	consumer.RegisterHandlers(convoy.PollRestApiSources, task.PollRestApiSources(
+		a.sourceRepo,
+		a.queue,
+		dispatchers))
+
+	consumer.RegisterHandlers(convoy.DailyAnalytics, analytics.TrackDailyAnalytics(&analytics.Repo{
+		ConfigRepo: a.configRepo,
+		EventRepo:  a.eventRepo,
+		GroupRepo:  a.groupRepo,
+		OrgRepo:    a.orgRepo,
+		UserRepo:   a.userRepo,
+	}, cfg))
+
+	consumer.RegisterHandlers(convoy.EmailProcessor, task.ProcessEmails(sc))
+	consumer.RegisterHandlers(convoy.NotificationProcessor, task.ProcessNotifications(sc))
+
+	//start worker
+	log.Infof("Starting Convoy workers...")
+	consumer.Start()
+
+	// consume the events of pub sub sources, change streams are
+	// leased so that only one worker consumes each of them.
+	redis, err := rdb.NewClient(cfg.Queue.Redis.Dsn)
+	if err != nil {
+		log.WithError(err).Error("failed to create the source lease client")
+		return err
+	}
+
+	go pubsub.NewIngester(a.sourceRepo, a.queue, pubsub.NewRedisLeaser(redis.Client())).Run(ctx)
+
+	return nil
+}
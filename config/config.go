@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"reflect"
 	"strings"
@@ -142,6 +144,100 @@ type RedisLimiterConfiguration struct {
 	Dsn string `json:"dsn" envconfig:"CONVOY_REDIS_DSN"`
 }
 
+type CircuitBreakerConfiguration struct {
+	Type  CircuitBreakerProvider           `json:"type" envconfig:"CONVOY_CIRCUIT_BREAKER_TYPE"`
+	Redis RedisCircuitBreakerConfiguration `json:"redis"`
+
+	// ConsecutiveFailureThreshold is the number of consecutive failed
+	// deliveries that trips the breaker.
+	ConsecutiveFailureThreshold uint64 `json:"consecutive_failure_threshold" envconfig:"CONVOY_CIRCUIT_BREAKER_CONSECUTIVE_FAILURE_THRESHOLD"`
+
+	// FailureRateThreshold is the percentage of failed deliveries within the
+	// observability window that trips the breaker.
+	FailureRateThreshold uint64 `json:"failure_rate_threshold" envconfig:"CONVOY_CIRCUIT_BREAKER_FAILURE_RATE_THRESHOLD"`
+
+	// MinimumRequestCount is the number of deliveries that must be made within
+	// the observability window before the failure rate is considered.
+	MinimumRequestCount uint64 `json:"minimum_request_count" envconfig:"CONVOY_CIRCUIT_BREAKER_MINIMUM_REQUEST_COUNT"`
+
+	// ObservabilityWindow is the window, in seconds, over which failures are counted.
+	ObservabilityWindow uint64 `json:"observability_window" envconfig:"CONVOY_CIRCUIT_BREAKER_OBSERVABILITY_WINDOW"`
+
+	// CoolDown is the time, in seconds, an open breaker waits before letting
+	// deliveries through again.
+	CoolDown uint64 `json:"cool_down" envconfig:"CONVOY_CIRCUIT_BREAKER_COOL_DOWN"`
+
+	// SuccessThreshold is the number of successful deliveries a half-open
+	// breaker needs to close.
+	SuccessThreshold uint64 `json:"success_threshold" envconfig:"CONVOY_CIRCUIT_BREAKER_SUCCESS_THRESHOLD"`
+}
+
+// EgressConfiguration restricts the destinations deliveries can be made to,
+// so endpoints can't reach into the network convoy runs in.
+type EgressConfiguration struct {
+	Enabled bool `json:"enabled" envconfig:"CONVOY_EGRESS_ENABLED"`
+
+	// BlockedCIDRs are the ip ranges endpoints can't connect to, the
+	// loopback, private, link local and unspecified ranges are blocked
+	// when none are set.
+	BlockedCIDRs []string `json:"blocked_cidrs" envconfig:"CONVOY_EGRESS_BLOCKED_CIDRS"`
+
+	// AllowedHosts are exempt from the blocked ranges, e.g internal services
+	// deliveries are meant to reach. A leading "*." matches subdomains.
+	AllowedHosts []string `json:"allowed_hosts" envconfig:"CONVOY_EGRESS_ALLOWED_HOSTS"`
+}
+
+// DefaultBlockedCIDRs are blocked when egress restrictions are enabled
+// without blocked ranges.
+var DefaultBlockedCIDRs = []string{
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+}
+
+// DispatcherConfiguration tunes the connection pool deliveries share, zero
+// values fall back to the defaults of the net package.
+type DispatcherConfiguration struct {
+	// MaxIdleConns caps the idle connections kept across all endpoints.
+	MaxIdleConns int `json:"max_idle_conns" envconfig:"CONVOY_DISPATCHER_MAX_IDLE_CONNS"`
+
+	// MaxIdleConnsPerHost caps the idle connections kept per endpoint host.
+	MaxIdleConnsPerHost int `json:"max_idle_conns_per_host" envconfig:"CONVOY_DISPATCHER_MAX_IDLE_CONNS_PER_HOST"`
+
+	// MaxConnsPerHost caps the connections per endpoint host, there is no
+	// limit when it isn't set.
+	MaxConnsPerHost int `json:"max_conns_per_host" envconfig:"CONVOY_DISPATCHER_MAX_CONNS_PER_HOST"`
+
+	// IdleConnTimeout is the time, in seconds, an idle connection is kept.
+	IdleConnTimeout uint64 `json:"idle_conn_timeout" envconfig:"CONVOY_DISPATCHER_IDLE_CONN_TIMEOUT"`
+
+	// DisableHTTP2 keeps deliveries on HTTP/1.1 when endpoints support HTTP/2.
+	DisableHTTP2 bool `json:"disable_http2" envconfig:"CONVOY_DISPATCHER_DISABLE_HTTP2"`
+}
+
+// ProxyConfiguration routes deliveries through an http, https or socks5
+// proxy, groups can set their own proxy to override it. When egress is
+// restricted, endpoints of proxied deliveries are checked before they are
+// sent, but the connections the proxy makes can't be checked, so the proxy
+// must enforce the egress policy itself.
+type ProxyConfiguration struct {
+	URL      string `json:"url" envconfig:"CONVOY_PROXY_URL"`
+	Username string `json:"username" envconfig:"CONVOY_PROXY_USERNAME"`
+	Password string `json:"password" envconfig:"CONVOY_PROXY_PASSWORD"`
+}
+
+type RedisCircuitBreakerConfiguration struct {
+	Dsn string `json:"dsn" envconfig:"CONVOY_REDIS_DSN"`
+}
+
 type NewRelicConfiguration struct {
 	AppName                  string `json:"app_name" envconfig:"CONVOY_NEWRELIC_APP_NAME"`
 	LicenseKey               string `json:"license_key" envconfig:"CONVOY_NEWRELIC_LICENSE_KEY"`
@@ -170,12 +266,15 @@ const (
 	DefaultStrategyProvider            StrategyProvider        = "linear"
 	ExponentialBackoffStrategyProvider StrategyProvider        = "exponential"
 	DefaultSignatureHeader             SignatureHeaderProvider = "X-Convoy-Signature"
+	DefaultAsymmetricSignatureHeader   SignatureHeaderProvider = "X-Convoy-Asymmetric-Signature"
 	ConsoleLoggerProvider              LoggerProvider          = "console"
 	NewRelicTracerProvider             TracerProvider          = "new_relic"
 	RedisCacheProvider                 CacheProvider           = "redis"
 	RedisLimiterProvider               LimiterProvider         = "redis"
+	RedisCircuitBreakerProvider        CircuitBreakerProvider  = "redis"
 	MongodbDatabaseProvider            DatabaseProvider        = "mongodb"
 	InMemoryDatabaseProvider           DatabaseProvider        = "in-memory"
+	PostgresDatabaseProvider           DatabaseProvider        = "postgres"
 )
 
 type AuthProvider string
@@ -186,6 +285,7 @@ type LoggerProvider string
 type TracerProvider string
 type CacheProvider string
 type LimiterProvider string
+type CircuitBreakerProvider string
 type DatabaseProvider string
 type SearchProvider string
 
@@ -194,21 +294,25 @@ func (s SignatureHeaderProvider) String() string {
 }
 
 type Configuration struct {
-	Auth            AuthConfiguration       `json:"auth,omitempty"`
-	Database        DatabaseConfiguration   `json:"database"`
-	Queue           QueueConfiguration      `json:"queue"`
-	Prometheus      PrometheusConfiguration `json:"prometheus"`
-	Server          ServerConfiguration     `json:"server"`
-	MaxResponseSize uint64                  `json:"max_response_size" envconfig:"CONVOY_MAX_RESPONSE_SIZE"`
-	SMTP            SMTPConfiguration       `json:"smtp"`
-	Environment     string                  `json:"env" envconfig:"CONVOY_ENV"`
-	MultipleTenants bool                    `json:"multiple_tenants"`
-	Logger          LoggerConfiguration     `json:"logger"`
-	Tracer          TracerConfiguration     `json:"tracer"`
-	Cache           CacheConfiguration      `json:"cache"`
-	Limiter         LimiterConfiguration    `json:"limiter"`
-	Host            string                  `json:"host" envconfig:"CONVOY_HOST"`
-	Search          SearchConfiguration     `json:"search"`
+	Auth            AuthConfiguration           `json:"auth,omitempty"`
+	Database        DatabaseConfiguration       `json:"database"`
+	Queue           QueueConfiguration          `json:"queue"`
+	Prometheus      PrometheusConfiguration     `json:"prometheus"`
+	Server          ServerConfiguration         `json:"server"`
+	MaxResponseSize uint64                      `json:"max_response_size" envconfig:"CONVOY_MAX_RESPONSE_SIZE"`
+	SMTP            SMTPConfiguration           `json:"smtp"`
+	Environment     string                      `json:"env" envconfig:"CONVOY_ENV"`
+	MultipleTenants bool                        `json:"multiple_tenants"`
+	Logger          LoggerConfiguration         `json:"logger"`
+	Tracer          TracerConfiguration         `json:"tracer"`
+	Cache           CacheConfiguration          `json:"cache"`
+	Limiter         LimiterConfiguration        `json:"limiter"`
+	CircuitBreaker  CircuitBreakerConfiguration `json:"circuit_breaker"`
+	Host            string                      `json:"host" envconfig:"CONVOY_HOST"`
+	Search          SearchConfiguration         `json:"search"`
+	Egress          EgressConfiguration         `json:"egress"`
+	Proxy           ProxyConfiguration          `json:"proxy"`
+	Dispatcher      DispatcherConfiguration     `json:"dispatcher"`
 }
 
 // Get fetches the application configuration. LoadConfig must have been called
@@ -336,5 +440,41 @@ func validate(c *Configuration) error {
 		return err
 	}
 
+	if err := ensureEgressConfig(c.Egress); err != nil {
+		return err
+	}
+
+	if err := ensureProxyConfig(c.Proxy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ensureProxyConfig(proxyCfg ProxyConfiguration) error {
+	if IsStringEmpty(proxyCfg.URL) {
+		return nil
+	}
+
+	u, err := url.Parse(proxyCfg.URL)
+	if err != nil || IsStringEmpty(u.Host) {
+		return fmt.Errorf("invalid proxy url: %s", proxyCfg.URL)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+		return nil
+	default:
+		return fmt.Errorf("unsupported proxy scheme: %s", u.Scheme)
+	}
+}
+
+func ensureEgressConfig(egressCfg EgressConfiguration) error {
+	for _, cidr := range egressCfg.BlockedCIDRs {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			return fmt.Errorf("invalid egress blocked cidr: %s", cidr)
+		}
+	}
+
 	return nil
 }
@@ -220,6 +220,22 @@ func TestLoadConfig(t *testing.T) {
 			wantErr:    true,
 			wantErrMsg: "unsupported queue type: abc",
 		},
+		{
+			name: "should_error_for_invalid_egress_blocked_cidr",
+			args: args{
+				path: "./testdata/Config/invalid-egress-blocked-cidr.json",
+			},
+			wantErr:    true,
+			wantErrMsg: "invalid egress blocked cidr: 10.0.0.1",
+		},
+		{
+			name: "should_error_for_unsupported_proxy_scheme",
+			args: args{
+				path: "./testdata/Config/unsupported-proxy-scheme.json",
+			},
+			wantErr:    true,
+			wantErrMsg: "unsupported proxy scheme: ftp",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -9,4 +9,7 @@ const (
 	RATE_LIMIT          = 5000
 	RATE_LIMIT_DURATION = "1m"
 	HTTP_TIMEOUT        = "30s"
+
+	// SECRET_EXPIRATION is how long a rotated endpoint secret stays valid.
+	SECRET_EXPIRATION = "24h"
 )
@@ -15,6 +15,14 @@ type Filter struct {
 	SearchParams SearchParams
 }
 
+type DeadLetterFilter struct {
+	GroupID        string
+	AppID          string
+	EventID        string
+	SubscriptionID string
+	SearchParams   SearchParams
+}
+
 type SourceFilter struct {
 	Type     string
 	Provider string
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_CreateDeadLetter(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	deadLetterRepo := NewDeadLetterRepo(db)
+	deadLetter := generateDeadLetter("group-1")
+
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(context.Background(), deadLetter))
+
+	newDeadLetter, err := deadLetterRepo.FindDeadLetterByID(context.Background(), deadLetter.GroupID, deadLetter.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, deadLetter.UID, newDeadLetter.UID)
+	require.Equal(t, deadLetter.EventDeliveryID, newDeadLetter.EventDeliveryID)
+	require.Equal(t, deadLetter.LastAttempt.UID, newDeadLetter.LastAttempt.UID)
+
+	byDelivery, err := deadLetterRepo.FindDeadLetterByEventDeliveryID(context.Background(), deadLetter.EventDeliveryID)
+	require.NoError(t, err)
+	require.Equal(t, deadLetter.UID, byDelivery.UID)
+}
+
+func Test_FindDeadLetterByID(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	deadLetterRepo := NewDeadLetterRepo(db)
+	deadLetter := generateDeadLetter("group-1")
+
+	_, err := deadLetterRepo.FindDeadLetterByID(context.Background(), deadLetter.GroupID, deadLetter.UID)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, datastore.ErrDeadLetterNotFound))
+
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(context.Background(), deadLetter))
+
+	_, err = deadLetterRepo.FindDeadLetterByID(context.Background(), "group-2", deadLetter.UID)
+	require.True(t, errors.Is(err, datastore.ErrDeadLetterNotFound))
+}
+
+func Test_LoadDeadLettersPaged(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	deadLetterRepo := NewDeadLetterRepo(db)
+
+	for i := 0; i < 5; i++ {
+		deadLetter := generateDeadLetter("group-1")
+		if i%2 == 0 {
+			deadLetter.AppID = "app-2"
+		}
+		require.NoError(t, deadLetterRepo.CreateDeadLetter(context.Background(), deadLetter))
+	}
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(context.Background(), generateDeadLetter("group-2")))
+
+	searchParams := datastore.SearchParams{
+		CreatedAtStart: time.Now().Add(-time.Hour).Unix(),
+		CreatedAtEnd:   time.Now().Add(time.Hour).Unix(),
+	}
+
+	deadLetters, pagination, err := deadLetterRepo.LoadDeadLettersPaged(context.Background(), &datastore.DeadLetterFilter{GroupID: "group-1", SearchParams: searchParams}, datastore.Pageable{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 5, len(deadLetters))
+	require.Equal(t, int64(5), pagination.Total)
+
+	deadLetters, _, err = deadLetterRepo.LoadDeadLettersPaged(context.Background(), &datastore.DeadLetterFilter{GroupID: "group-1", AppID: "app-2", SearchParams: searchParams}, datastore.Pageable{Page: 1, PerPage: 10})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(deadLetters))
+}
+
+func Test_DeleteDeadLetters(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	deadLetterRepo := NewDeadLetterRepo(db)
+
+	first, second := generateDeadLetter("group-1"), generateDeadLetter("group-1")
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(context.Background(), first))
+	require.NoError(t, deadLetterRepo.CreateDeadLetter(context.Background(), second))
+
+	require.NoError(t, deadLetterRepo.DeleteDeadLetters(context.Background(), "group-1", []string{first.UID}))
+
+	deadLetters, err := deadLetterRepo.FindDeadLettersByIDs(context.Background(), "group-1", []string{first.UID, second.UID})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(deadLetters))
+	require.Equal(t, second.UID, deadLetters[0].UID)
+}
+
+func generateDeadLetter(groupID string) *datastore.DeadLetter {
+	return &datastore.DeadLetter{
+		UID:             uuid.NewString(),
+		GroupID:         groupID,
+		AppID:           "app-1",
+		EventID:         uuid.NewString(),
+		EventDeliveryID: uuid.NewString(),
+		SubscriptionID:  uuid.NewString(),
+		Reason:          "Retry limit exceeded",
+		NumTrials:       3,
+		LastAttempt:     &datastore.DeliveryAttempt{UID: uuid.NewString(), HttpResponseCode: "500 Internal Server Error"},
+		CreatedAt:       primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:       primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus:  datastore.ActiveDocumentStatus,
+	}
+}
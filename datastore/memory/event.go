@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventRepo struct {
+	store *store
+}
+
+func NewEventRepository(db *DB) datastore.EventRepository {
+	return &eventRepo{store: newStore(db, EventTable)}
+}
+
+// intervals maps each period to the layout of its interval date and
+// the date component the interval index is computed from.
+var intervals = map[datastore.Period]struct {
+	layout    string
+	component func(t time.Time) int
+}{
+	datastore.Daily:   {"2006-01-02", func(t time.Time) int { return t.YearDay() }},
+	datastore.Weekly:  {"2006-01", week},
+	datastore.Monthly: {"2006-01", func(t time.Time) int { return int(t.Month()) }},
+	datastore.Yearly:  {"2006", func(t time.Time) int { return t.Year() }},
+}
+
+// week returns the week of the year the way mongo's $week does, weeks
+// begin on sundays and the days before the first sunday are in week 0.
+func week(t time.Time) int {
+	return (t.YearDay() + 6 - int(t.Weekday())) / 7
+}
+
+func (db *eventRepo) CreateEvent(ctx context.Context, message *datastore.Event) error {
+	message.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(message.ProviderID) {
+		message.ProviderID = message.AppID
+	}
+	if util.IsStringEmpty(message.UID) {
+		message.UID = uuid.New().String()
+	}
+
+	return db.store.insert(ctx, message.CreatedAt, message)
+}
+
+func (db *eventRepo) CountGroupMessages(ctx context.Context, groupID string) (int64, error) {
+	count, err := db.store.count(ctx, newQuery().eq("group_id", groupID).active())
+	if err != nil {
+		log.WithError(err).Errorf("failed to count events in group %s", groupID)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (db *eventRepo) DeleteGroupEvents(ctx context.Context, filter *datastore.EventFilter, hardDelete bool) error {
+	q := newQuery().
+		eq("group_id", filter.GroupID).
+		active().
+		createdBetween(filter.CreatedAtStart, filter.CreatedAtEnd)
+
+	return db.store.delete(ctx, q, hardDelete)
+}
+
+func (db *eventRepo) LoadEventIntervals(ctx context.Context, groupID string, searchParams datastore.SearchParams, period datastore.Period, interval int) ([]datastore.EventInterval, error) {
+	start := searchParams.CreatedAtStart
+	end := searchParams.CreatedAtEnd
+	if end == 0 || end < searchParams.CreatedAtStart {
+		end = start
+	}
+
+	iv, ok := intervals[period]
+	if !ok {
+		return nil, errors.New("specified data cannot be generated for period")
+	}
+
+	events := make([]datastore.Event, 0)
+	err := db.store.findMany(ctx, newQuery().eq("group_id", groupID).active().createdBetween(start, end), 0, &events)
+	if err != nil {
+		log.WithError(err).Errorln("event intervals query error")
+		return nil, err
+	}
+
+	counts := map[datastore.EventIntervalData]uint64{}
+	for _, event := range events {
+		t := event.CreatedAt.Time().UTC()
+		data := datastore.EventIntervalData{
+			Time:     t.Format(iv.layout),
+			Interval: int64(iv.component(t) / interval),
+		}
+		counts[data]++
+	}
+
+	eventsIntervals := make([]datastore.EventInterval, 0, len(counts))
+	for data, count := range counts {
+		eventsIntervals = append(eventsIntervals, datastore.EventInterval{Data: data, Count: count})
+	}
+
+	sort.Slice(eventsIntervals, func(i, j int) bool {
+		a, b := eventsIntervals[i].Data, eventsIntervals[j].Data
+		if a.Time == b.Time {
+			return a.Interval < b.Interval
+		}
+		return a.Time < b.Time
+	})
+
+	return eventsIntervals, nil
+}
+
+func (db *eventRepo) FindEventByID(ctx context.Context, id string) (*datastore.Event, error) {
+	m := new(datastore.Event)
+
+	err := db.store.findOne(ctx, newQuery().uid(id).active(), 0, m)
+	if errors.Is(err, errNoDocuments) {
+		err = datastore.ErrEventNotFound
+	}
+
+	return m, err
+}
+
+func (db *eventRepo) FindEventsByIDs(ctx context.Context, ids []string) ([]datastore.Event, error) {
+	m := make([]datastore.Event, 0)
+
+	err := db.store.findMany(ctx, newQuery().uids(ids).active(), 0, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (db *eventRepo) LoadEventsPaged(ctx context.Context, groupID string, appId string, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	q := newQuery().active().createdBetween(searchParams.CreatedAtStart, searchParams.CreatedAtEnd)
+
+	if !util.IsStringEmpty(groupID) {
+		q.eq("group_id", groupID)
+	}
+
+	if !util.IsStringEmpty(appId) {
+		q.eq("app_id", appId)
+	}
+
+	var messages []datastore.Event
+	paginationData, err := db.store.paginate(ctx, q, sortOrder(pageable.Sort), pageable, &messages)
+	if err != nil {
+		return messages, datastore.PaginationData{}, err
+	}
+
+	return messages, paginationData, nil
+}
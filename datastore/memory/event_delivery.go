@@ -0,0 +1,271 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventDeliveryRepo struct {
+	store *store
+}
+
+func NewEventDeliveryRepository(db *DB) datastore.EventDeliveryRepository {
+	return &eventDeliveryRepo{store: newStore(db, EventDeliveryTable)}
+}
+
+func (db *eventDeliveryRepo) CreateEventDelivery(ctx context.Context, eventDelivery *datastore.EventDelivery) error {
+	eventDelivery.ID = primitive.NewObjectID()
+	if util.IsStringEmpty(eventDelivery.UID) {
+		eventDelivery.UID = uuid.New().String()
+	}
+
+	return db.store.insert(ctx, eventDelivery.CreatedAt, eventDelivery)
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveryByID(ctx context.Context, id string) (*datastore.EventDelivery, error) {
+	e := new(datastore.EventDelivery)
+
+	err := db.store.findOne(ctx, newQuery().uid(id).active(), 0, e)
+	if errors.Is(err, errNoDocuments) {
+		err = datastore.ErrEventDeliveryNotFound
+	}
+
+	return e, err
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveriesByIDs(ctx context.Context, ids []string) ([]datastore.EventDelivery, error) {
+	deliveries := make([]datastore.EventDelivery, 0)
+	err := db.store.findMany(ctx, newQuery().uids(ids).active(), 0, &deliveries)
+	return deliveries, err
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveriesByEventID(ctx context.Context, eventID string) ([]datastore.EventDelivery, error) {
+	deliveries := make([]datastore.EventDelivery, 0)
+
+	err := db.store.findMany(ctx, newQuery().eq("event_id", eventID).active(), 0, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (db *eventDeliveryRepo) CountDeliveriesByStatus(ctx context.Context, status datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+	q := newQuery().
+		eq("status", status).
+		active().
+		createdBetween(searchParams.CreatedAtStart, searchParams.CreatedAtEnd)
+
+	return db.store.count(ctx, q)
+}
+
+func (db *eventDeliveryRepo) UpdateStatusOfEventDelivery(ctx context.Context, e datastore.EventDelivery, status datastore.EventDeliveryStatus) error {
+	update := bson.M{
+		"status":     status,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err := db.store.update(ctx, newQuery().uid(e.UID), update)
+	if err != nil {
+		log.WithError(err).Error("Failed to update event delivery status")
+		return err
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context, ids []string, status datastore.EventDeliveryStatus) error {
+	update := bson.M{
+		"status":     status,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	return db.store.update(ctx, newQuery().uids(ids).active(), update)
+}
+
+func (db *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context, e datastore.EventDelivery, attempt datastore.DeliveryAttempt) error {
+	set := bson.M{
+		"status":      e.Status,
+		"description": e.Description,
+		"metadata":    e.Metadata,
+		"updated_at":  primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	// the attempt means the delivery is no longer waiting on the head of its queue
+	err := db.store.push(ctx, newQuery().uid(e.UID), "attempts", attempt, set, "blocked_by")
+	if err != nil {
+		log.WithError(err).Errorf("error updating an event delivery %s - %s\n", e.UID, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	q := getFilter(groupID, appID, eventID, status, searchParams)
+
+	var eventDeliveries []datastore.EventDelivery
+	paginationData, err := db.store.paginate(ctx, q, sortOrder(pageable.Sort), pageable, &eventDeliveries)
+	if err != nil {
+		return eventDeliveries, datastore.PaginationData{}, err
+	}
+
+	return eventDeliveries, paginationData, nil
+}
+
+func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+	return db.store.count(ctx, getFilter(groupID, appID, eventID, status, searchParams))
+}
+
+func getFilter(groupID string, appID string, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) *query {
+	q := newQuery().active().createdBetween(searchParams.CreatedAtStart, searchParams.CreatedAtEnd)
+
+	if !util.IsStringEmpty(appID) {
+		q.eq("app_id", appID)
+	}
+
+	if !util.IsStringEmpty(groupID) {
+		q.eq("group_id", groupID)
+	}
+
+	if !util.IsStringEmpty(eventID) {
+		q.eq("event_id", eventID)
+	}
+
+	if len(status) > 0 {
+		q.in("status", statusStrings(status))
+	}
+
+	return q
+}
+
+func statusStrings(status []datastore.EventDeliveryStatus) []string {
+	s := make([]string, 0, len(status))
+	for _, st := range status {
+		s = append(s, string(st))
+	}
+
+	return s
+}
+
+func (db *eventDeliveryRepo) DeleteGroupEventDeliveries(ctx context.Context, filter *datastore.EventDeliveryFilter, hardDelete bool) error {
+	q := newQuery().
+		eq("group_id", filter.GroupID).
+		active().
+		createdBetween(filter.CreatedAtStart, filter.CreatedAtEnd)
+
+	return db.store.delete(ctx, q, hardDelete)
+}
+
+func (db *eventDeliveryRepo) FindDiscardedEventDeliveries(ctx context.Context, appId, deviceId string, searchParams datastore.SearchParams) ([]datastore.EventDelivery, error) {
+	q := newQuery().
+		eq("app_id", appId).
+		eq("device_id", deviceId).
+		eq("status", datastore.DiscardedEventStatus).
+		createdBetween(searchParams.CreatedAtStart, searchParams.CreatedAtEnd).
+		active()
+
+	deliveries := make([]datastore.EventDelivery, 0)
+	err := db.store.findMany(ctx, q, 0, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// pendingEventStatuses are the statuses of deliveries that are still
+// waiting to be sent, the oldest of them is the head of its ordering queue.
+var pendingEventStatuses = []datastore.EventDeliveryStatus{
+	datastore.ScheduledEventStatus,
+	datastore.ProcessingEventStatus,
+	datastore.RetryEventStatus,
+	datastore.CircuitOpenEventStatus,
+	datastore.BlockedEventStatus,
+}
+
+func (db *eventDeliveryRepo) FindOrderingHead(ctx context.Context, orderingKey string) (*datastore.EventDelivery, error) {
+	e := new(datastore.EventDelivery)
+
+	// deliveries left in processing by a crashed worker don't hold back the
+	// rest of the queue.
+	staleAt := primitive.NewDateTimeFromTime(time.Now().Add(-datastore.ProcessingTimeout))
+
+	q := newQuery().
+		eq("ordering_key", orderingKey).
+		in("status", statusStrings(pendingEventStatuses)).
+		where(func(doc bson.M) bool {
+			updatedAt, _ := doc["updated_at"].(primitive.DateTime)
+			return text(doc["status"]) != string(datastore.ProcessingEventStatus) || updatedAt > staleAt
+		}).
+		active()
+
+	err := db.store.findOne(ctx, q, 1, e)
+	if errors.Is(err, errNoDocuments) {
+		err = datastore.ErrEventDeliveryNotFound
+	}
+
+	return e, err
+}
+
+func (db *eventDeliveryRepo) BlockEventDelivery(ctx context.Context, e datastore.EventDelivery, headID string) error {
+	update := bson.M{
+		"status":     datastore.BlockedEventStatus,
+		"blocked_by": headID,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err := db.store.update(ctx, newQuery().uid(e.UID), update)
+	if err != nil {
+		log.WithError(err).Errorf("error blocking event delivery %s", e.UID)
+		return err
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) LoadOrderingQueues(ctx context.Context, subscriptionID string) ([]datastore.OrderingQueue, error) {
+	q := newQuery().
+		eq("subscription_id", subscriptionID).
+		where(func(doc bson.M) bool {
+			key, _ := doc["ordering_key"].(string)
+			return key != ""
+		}).
+		in("status", statusStrings(pendingEventStatuses)).
+		active()
+
+	deliveries := make([]datastore.EventDelivery, 0)
+	err := db.store.findMany(ctx, q, 1, &deliveries)
+	if err != nil {
+		log.WithError(err).Error("failed to load ordering queues")
+		return nil, err
+	}
+
+	// deliveries are sorted oldest first, so the first one seen for a key is its head
+	queues := make([]datastore.OrderingQueue, 0)
+	index := map[string]int{}
+	for _, e := range deliveries {
+		if i, ok := index[e.OrderingKey]; ok {
+			queues[i].Blocked++
+			continue
+		}
+
+		index[e.OrderingKey] = len(queues)
+		queues = append(queues, datastore.OrderingQueue{
+			OrderingKey:   e.OrderingKey,
+			HeadID:        e.UID,
+			HeadStatus:    e.Status,
+			HeadCreatedAt: e.CreatedAt,
+		})
+	}
+
+	return queues, nil
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_LoadOrderingQueues(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventDeliveryRepo := NewEventDeliveryRepository(db)
+	subscriptionID := uuid.NewString()
+	now := time.Now()
+
+	deliveries := []*datastore.EventDelivery{
+		{OrderingKey: "a", Status: datastore.RetryEventStatus, CreatedAt: primitive.NewDateTimeFromTime(now.Add(-3 * time.Minute))},
+		{OrderingKey: "a", Status: datastore.BlockedEventStatus, CreatedAt: primitive.NewDateTimeFromTime(now.Add(-2 * time.Minute))},
+		{OrderingKey: "b", Status: datastore.ScheduledEventStatus, CreatedAt: primitive.NewDateTimeFromTime(now.Add(-time.Minute))},
+		{OrderingKey: "b", Status: datastore.SuccessEventStatus, CreatedAt: primitive.NewDateTimeFromTime(now.Add(-4 * time.Minute))},
+		{Status: datastore.ScheduledEventStatus, CreatedAt: primitive.NewDateTimeFromTime(now)},
+	}
+
+	for _, d := range deliveries {
+		d.UID = uuid.NewString()
+		d.SubscriptionID = subscriptionID
+		d.DocumentStatus = datastore.ActiveDocumentStatus
+		require.NoError(t, eventDeliveryRepo.CreateEventDelivery(context.Background(), d))
+	}
+
+	queues, err := eventDeliveryRepo.LoadOrderingQueues(context.Background(), subscriptionID)
+	require.NoError(t, err)
+	require.Equal(t, []datastore.OrderingQueue{
+		{OrderingKey: "a", HeadID: deliveries[0].UID, HeadStatus: datastore.RetryEventStatus, HeadCreatedAt: deliveries[0].CreatedAt, Blocked: 1},
+		{OrderingKey: "b", HeadID: deliveries[2].UID, HeadStatus: datastore.ScheduledEventStatus, HeadCreatedAt: deliveries[2].CreatedAt},
+	}, queues)
+
+	head, err := eventDeliveryRepo.FindOrderingHead(context.Background(), "a")
+	require.NoError(t, err)
+	require.Equal(t, deliveries[0].UID, head.UID)
+}
+
+func Test_FindOrderingHead_SkipsStaleProcessing(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventDeliveryRepo := NewEventDeliveryRepository(db)
+	now := time.Now()
+
+	deliveries := []*datastore.EventDelivery{
+		{
+			OrderingKey: "a", Status: datastore.ProcessingEventStatus,
+			CreatedAt: primitive.NewDateTimeFromTime(now.Add(-time.Hour)),
+			UpdatedAt: primitive.NewDateTimeFromTime(now.Add(-datastore.ProcessingTimeout - time.Minute)),
+		},
+		{
+			OrderingKey: "a", Status: datastore.BlockedEventStatus,
+			CreatedAt: primitive.NewDateTimeFromTime(now.Add(-time.Minute)),
+			UpdatedAt: primitive.NewDateTimeFromTime(now),
+		},
+		{
+			OrderingKey: "b", Status: datastore.ProcessingEventStatus,
+			CreatedAt: primitive.NewDateTimeFromTime(now.Add(-time.Hour)),
+			UpdatedAt: primitive.NewDateTimeFromTime(now.Add(-time.Minute)),
+		},
+		{
+			OrderingKey: "b", Status: datastore.BlockedEventStatus,
+			CreatedAt: primitive.NewDateTimeFromTime(now.Add(-time.Minute)),
+			UpdatedAt: primitive.NewDateTimeFromTime(now),
+		},
+	}
+
+	for _, d := range deliveries {
+		d.UID = uuid.NewString()
+		d.DocumentStatus = datastore.ActiveDocumentStatus
+		require.NoError(t, eventDeliveryRepo.CreateEventDelivery(context.Background(), d))
+	}
+
+	head, err := eventDeliveryRepo.FindOrderingHead(context.Background(), "a")
+	require.NoError(t, err)
+	require.Equal(t, deliveries[1].UID, head.UID)
+
+	head, err = eventDeliveryRepo.FindOrderingHead(context.Background(), "b")
+	require.NoError(t, err)
+	require.Equal(t, deliveries[2].UID, head.UID)
+}
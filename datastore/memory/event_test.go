@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_LoadEventIntervals(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	eventRepo := NewEventRepository(db)
+	groupID := uuid.NewString()
+
+	// 2022-01-01 is a saturday, so it falls in week 0 and 2022-01-02 in week 1
+	dates := []time.Time{
+		time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2022, 1, 2, 10, 0, 0, 0, time.UTC),
+	}
+
+	for _, d := range dates {
+		event := &datastore.Event{
+			UID:            uuid.NewString(),
+			GroupID:        groupID,
+			CreatedAt:      primitive.NewDateTimeFromTime(d),
+			DocumentStatus: datastore.ActiveDocumentStatus,
+		}
+		require.NoError(t, eventRepo.CreateEvent(context.Background(), event))
+	}
+
+	searchParams := datastore.SearchParams{
+		CreatedAtStart: dates[0].Add(-time.Hour).Unix(),
+		CreatedAtEnd:   dates[2].Add(time.Hour).Unix(),
+	}
+
+	daily, err := eventRepo.LoadEventIntervals(context.Background(), groupID, searchParams, datastore.Daily, 1)
+	require.NoError(t, err)
+	require.Equal(t, []datastore.EventInterval{
+		{Data: datastore.EventIntervalData{Interval: 1, Time: "2022-01-01"}, Count: 2},
+		{Data: datastore.EventIntervalData{Interval: 2, Time: "2022-01-02"}, Count: 1},
+	}, daily)
+
+	weekly, err := eventRepo.LoadEventIntervals(context.Background(), groupID, searchParams, datastore.Weekly, 1)
+	require.NoError(t, err)
+	require.Equal(t, []datastore.EventInterval{
+		{Data: datastore.EventIntervalData{Interval: 0, Time: "2022-01"}, Count: 2},
+		{Data: datastore.EventIntervalData{Interval: 1, Time: "2022-01"}, Count: 1},
+	}, weekly)
+}
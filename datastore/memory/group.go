@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const groupNameIndex = "groups_name_unique"
+
+type groupRepo struct {
+	store  *store
+	apps   *store
+	events *store
+}
+
+func NewGroupRepo(db *DB) datastore.GroupRepository {
+	return &groupRepo{
+		store:  newStore(db, GroupTable),
+		apps:   newStore(db, AppTable),
+		events: newStore(db, EventTable),
+	}
+}
+
+func (db *groupRepo) LoadGroups(ctx context.Context, f *datastore.GroupFilter) ([]*datastore.Group, error) {
+	q := newQuery().active()
+	if f.OrgID != "" {
+		q.eq("organisation_id", f.OrgID)
+	}
+
+	f = f.WithNamesTrimmed()
+	if len(f.Names) > 0 {
+		q.in("name", f.Names)
+	}
+
+	groups := make([]*datastore.Group, 0)
+	err := db.store.findMany(ctx, q, 1, &groups)
+
+	return groups, err
+}
+
+func (db *groupRepo) CreateGroup(ctx context.Context, o *datastore.Group) error {
+	o.ID = primitive.NewObjectID()
+
+	err := db.store.insert(ctx, o.CreatedAt, o)
+	if isDuplicate(err, groupNameIndex) {
+		return datastore.ErrDuplicateGroupName
+	}
+
+	return err
+}
+
+func (db *groupRepo) UpdateGroup(ctx context.Context, o *datastore.Group) error {
+	o.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	update := bson.M{
+		"name":                o.Name,
+		"logo_url":            o.LogoURL,
+		"updated_at":          o.UpdatedAt,
+		"config":              o.Config,
+		"rate_limit":          o.RateLimit,
+		"metadata":            o.Metadata,
+		"rate_limit_duration": o.RateLimitDuration,
+		"signing_keys":        o.SigningKeys,
+	}
+
+	err := db.store.update(ctx, newQuery().uid(o.UID), update)
+	if isDuplicate(err, groupNameIndex) {
+		return datastore.ErrDuplicateGroupName
+	}
+
+	return err
+}
+
+func (db *groupRepo) FetchGroupByID(ctx context.Context, id string) (*datastore.Group, error) {
+	group := new(datastore.Group)
+
+	err := db.store.findOne(ctx, newQuery().uid(id).active(), 0, group)
+	if errors.Is(err, errNoDocuments) {
+		err = datastore.ErrGroupNotFound
+	}
+
+	return group, err
+}
+
+func (db *groupRepo) FillGroupsStatistics(ctx context.Context, groups []*datastore.Group) error {
+	for i := range groups {
+		totalApps, err := db.apps.count(ctx, newQuery().eq("group_id", groups[i].UID))
+		if err != nil {
+			log.WithError(err).Error("failed to load group statistics")
+			return err
+		}
+
+		messagesSent, err := db.events.count(ctx, newQuery().eq("group_id", groups[i].UID))
+		if err != nil {
+			log.WithError(err).Error("failed to load group statistics")
+			return err
+		}
+
+		groups[i].Statistics = &datastore.GroupStatistics{
+			GroupID:      groups[i].UID,
+			TotalApps:    totalApps,
+			MessagesSent: messagesSent,
+		}
+	}
+
+	return nil
+}
+
+func (db *groupRepo) DeleteGroup(ctx context.Context, uid string) error {
+	return db.store.delete(ctx, newQuery().uid(uid), false)
+}
+
+func (db *groupRepo) FetchGroupsByIDs(ctx context.Context, ids []string) ([]datastore.Group, error) {
+	groups := make([]datastore.Group, 0)
+
+	err := db.store.findMany(ctx, newQuery().uids(ids).active(), 1, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+const (
+	ConfigTable              = "configurations"
+	GroupTable               = "groups"
+	OrganisationTable        = "organisations"
+	OrganisationInvitesTable = "organisation_invites"
+	OrganisationMembersTable = "organisation_members"
+	APIKeyTable              = "api_keys"
+	AppTable                 = "applications"
+	DeviceTable              = "devices"
+	EventTable               = "events"
+	EventDeliveryTable       = "event_deliveries"
+	SourceTable              = "sources"
+	UserTable                = "users"
+	SubscriptionTable        = "subscriptions"
+	DeadLetterTable          = "dead_letters"
+)
+
+var tables = []string{
+	ConfigTable, GroupTable, OrganisationTable, OrganisationInvitesTable,
+	OrganisationMembersTable, APIKeyTable, AppTable, DeviceTable, EventTable,
+	EventDeliveryTable, SourceTable, UserTable, SubscriptionTable, DeadLetterTable,
+}
+
+// uniqueIndexes mirrors the unique indexes of the mongo collections.
+var uniqueIndexes = map[string][]uniqueIndex{
+	GroupTable: {
+		{name: "groups_uid", fields: []string{"uid"}},
+		{name: groupNameIndex, fields: []string{"organisation_id", "name"}},
+	},
+	OrganisationTable: {
+		{name: "organisations_uid", fields: []string{"uid"}},
+	},
+	OrganisationMembersTable: {
+		{name: "organisation_members_uid", fields: []string{"uid"}},
+		{name: "organisation_members_user_unique", fields: []string{"organisation_id", "user_id"}},
+	},
+	OrganisationInvitesTable: {
+		{name: "organisation_invites_uid", fields: []string{"uid"}},
+		{name: "organisation_invites_token", fields: []string{"token"}},
+		{name: "organisation_invites_email_unique", fields: []string{"organisation_id", "invitee_email"}},
+	},
+	UserTable: {
+		{name: "users_uid", fields: []string{"uid"}},
+		{name: userEmailIndex, fields: []string{"email"}},
+	},
+	AppTable: {
+		{name: "applications_uid", fields: []string{"uid"}},
+		{name: "applications_title_unique", fields: []string{"group_id", "title"}},
+	},
+	EventTable: {
+		{name: "events_uid", fields: []string{"uid"}},
+	},
+	DeviceTable: {
+		{name: "devices_host_name_unique", fields: []string{"app_id", "group_id", "host_name"}},
+	},
+	SourceTable: {
+		{name: "sources_uid", fields: []string{"uid"}},
+		{name: "sources_mask_id", fields: []string{"mask_id"}},
+	},
+	SubscriptionTable: {
+		{name: "subscriptions_uid", fields: []string{"uid"}},
+		{name: "subscriptions_unique", fields: []string{"app_id", "group_id", "source_id", "device_id", "endpoint_id"}},
+	},
+	DeadLetterTable: {
+		{name: "dead_letters_uid", fields: []string{"uid"}},
+	},
+}
+
+// Client keeps every document in process memory, it is meant for tests
+// and local development, nothing survives a restart.
+type Client struct {
+	db                *DB
+	apiKeyRepo        datastore.APIKeyRepository
+	groupRepo         datastore.GroupRepository
+	eventRepo         datastore.EventRepository
+	applicationRepo   datastore.ApplicationRepository
+	subscriptionRepo  datastore.SubscriptionRepository
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	sourceRepo        datastore.SourceRepository
+	orgRepo           datastore.OrganisationRepository
+	orgMemberRepo     datastore.OrganisationMemberRepository
+	orgInviteRepo     datastore.OrganisationInviteRepository
+	userRepo          datastore.UserRepository
+	deviceRepo        datastore.DeviceRepository
+	configRepo        datastore.ConfigurationRepository
+	deadLetterRepo    datastore.DeadLetterRepository
+}
+
+func New(cfg config.Configuration) (*Client, error) {
+	db := NewDB()
+
+	c := &Client{
+		db:                db,
+		apiKeyRepo:        NewApiKeyRepo(db),
+		groupRepo:         NewGroupRepo(db),
+		applicationRepo:   NewApplicationRepo(db),
+		subscriptionRepo:  NewSubscriptionRepo(db),
+		eventRepo:         NewEventRepository(db),
+		eventDeliveryRepo: NewEventDeliveryRepository(db),
+		sourceRepo:        NewSourceRepo(db),
+		deviceRepo:        NewDeviceRepository(db),
+		orgRepo:           NewOrgRepo(db),
+		orgMemberRepo:     NewOrgMemberRepo(db),
+		orgInviteRepo:     NewOrgInviteRepo(db),
+		userRepo:          NewUserRepo(db),
+		configRepo:        NewConfigRepo(db),
+		deadLetterRepo:    NewDeadLetterRepo(db),
+	}
+
+	return c, nil
+}
+
+func (c *Client) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (c *Client) GetName() string {
+	return "in-memory"
+}
+
+func (c *Client) Client() interface{} {
+	return c.db
+}
+
+func (c *Client) APIRepo() datastore.APIKeyRepository {
+	return c.apiKeyRepo
+}
+
+func (c *Client) GroupRepo() datastore.GroupRepository {
+	return c.groupRepo
+}
+
+func (c *Client) AppRepo() datastore.ApplicationRepository {
+	return c.applicationRepo
+}
+
+func (c *Client) DeviceRepo() datastore.DeviceRepository {
+	return c.deviceRepo
+}
+
+func (c *Client) EventRepo() datastore.EventRepository {
+	return c.eventRepo
+}
+
+func (c *Client) EventDeliveryRepo() datastore.EventDeliveryRepository {
+	return c.eventDeliveryRepo
+}
+
+func (c *Client) SubRepo() datastore.SubscriptionRepository {
+	return c.subscriptionRepo
+}
+
+func (c *Client) SourceRepo() datastore.SourceRepository {
+	return c.sourceRepo
+}
+
+func (c *Client) OrganisationRepo() datastore.OrganisationRepository {
+	return c.orgRepo
+}
+
+func (c *Client) OrganisationMemberRepo() datastore.OrganisationMemberRepository {
+	return c.orgMemberRepo
+}
+
+func (c *Client) OrganisationInviteRepo() datastore.OrganisationInviteRepository {
+	return c.orgInviteRepo
+}
+
+func (c *Client) UserRepo() datastore.UserRepository {
+	return c.userRepo
+}
+
+func (c *Client) ConfigurationRepo() datastore.ConfigurationRepository {
+	return c.configRepo
+}
+
+func (c *Client) DeadLetterRepo() datastore.DeadLetterRepository {
+	return c.deadLetterRepo
+}
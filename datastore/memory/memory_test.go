@@ -0,0 +1,9 @@
+package memory
+
+import (
+	"testing"
+)
+
+func getDB(t *testing.T) (*DB, func()) {
+	return NewDB(), func() {}
+}
@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgMemberRepo struct {
+	store     *store
+	orgStore  *store
+	userStore *store
+}
+
+func NewOrgMemberRepo(db *DB) datastore.OrganisationMemberRepository {
+	return &orgMemberRepo{
+		store:     newStore(db, OrganisationMembersTable),
+		orgStore:  newStore(db, OrganisationTable),
+		userStore: newStore(db, UserTable),
+	}
+}
+
+func (o *orgMemberRepo) LoadOrganisationMembersPaged(ctx context.Context, organisationID string, pageable datastore.Pageable) ([]*datastore.OrganisationMember, datastore.PaginationData, error) {
+	q := newQuery().active()
+	if !util.IsStringEmpty(organisationID) {
+		q.eq("organisation_id", organisationID)
+	}
+
+	members := make([]*datastore.OrganisationMember, 0)
+	paginationData, err := o.store.paginate(ctx, q, sortOrder(pageable.Sort), pageable, &members)
+	if err != nil {
+		return members, datastore.PaginationData{}, err
+	}
+
+	err = o.fillOrgMemberUserMetadata(ctx, members)
+	if err != nil {
+		return members, datastore.PaginationData{}, err
+	}
+
+	return members, paginationData, nil
+}
+
+func (o *orgMemberRepo) LoadUserOrganisationsPaged(ctx context.Context, userID string, pageable datastore.Pageable) ([]datastore.Organisation, datastore.PaginationData, error) {
+	members := make([]datastore.OrganisationMember, 0)
+	err := o.store.findMany(ctx, newQuery().eq("user_id", userID).active(), sortOrder(pageable.Sort), &members)
+	if err != nil {
+		log.WithError(err).Error("failed to load user organisations")
+		return nil, datastore.PaginationData{}, err
+	}
+
+	organisations := make([]datastore.Organisation, 0, len(members))
+	for _, member := range members {
+		org := datastore.Organisation{}
+		err := o.orgStore.findOne(ctx, newQuery().uid(member.OrganisationID).active(), 0, &org)
+		if errors.Is(err, errNoDocuments) {
+			continue
+		}
+
+		if err != nil {
+			log.WithError(err).Error("failed to load user organisations")
+			return nil, datastore.PaginationData{}, err
+		}
+
+		organisations = append(organisations, org)
+	}
+
+	data := paginationData(int64(len(organisations)), pageable)
+	start, end := (data.Page-1)*data.PerPage, data.Page*data.PerPage
+	if start > data.Total {
+		start = data.Total
+	}
+
+	if end > data.Total {
+		end = data.Total
+	}
+
+	return organisations[start:end], data, nil
+}
+
+func (o *orgMemberRepo) CreateOrganisationMember(ctx context.Context, member *datastore.OrganisationMember) error {
+	member.ID = primitive.NewObjectID()
+	return o.store.insert(ctx, member.CreatedAt, member)
+}
+
+func (o *orgMemberRepo) UpdateOrganisationMember(ctx context.Context, member *datastore.OrganisationMember) error {
+	member.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	update := bson.M{
+		"role":       member.Role,
+		"updated_at": member.UpdatedAt,
+	}
+
+	return o.store.update(ctx, newQuery().uid(member.UID), update)
+}
+
+func (o *orgMemberRepo) DeleteOrganisationMember(ctx context.Context, uid, orgID string) error {
+	return o.store.delete(ctx, newQuery().uid(uid).eq("organisation_id", orgID), false)
+}
+
+func (o *orgMemberRepo) FetchOrganisationMemberByID(ctx context.Context, uid, orgID string) (*datastore.OrganisationMember, error) {
+	return o.findMember(ctx, newQuery().uid(uid).eq("organisation_id", orgID).active())
+}
+
+func (o *orgMemberRepo) FetchOrganisationMemberByUserID(ctx context.Context, userID, orgID string) (*datastore.OrganisationMember, error) {
+	return o.findMember(ctx, newQuery().eq("user_id", userID).eq("organisation_id", orgID).active())
+}
+
+func (o *orgMemberRepo) findMember(ctx context.Context, q *query) (*datastore.OrganisationMember, error) {
+	member := new(datastore.OrganisationMember)
+
+	err := o.store.findOne(ctx, q, 0, member)
+	if err != nil {
+		if errors.Is(err, errNoDocuments) {
+			return nil, datastore.ErrOrgMemberNotFound
+		}
+
+		return nil, err
+	}
+
+	err = o.fillOrgMemberUserMetadata(ctx, []*datastore.OrganisationMember{member})
+	return member, err
+}
+
+func (o *orgMemberRepo) fillOrgMemberUserMetadata(ctx context.Context, members []*datastore.OrganisationMember) error {
+	userIDs := make([]string, 0, len(members))
+	for i := range members {
+		userIDs = append(userIDs, members[i].UserID)
+	}
+
+	users := make([]datastore.User, 0, len(userIDs))
+	err := o.userStore.findMany(ctx, newQuery().uids(userIDs), 0, &users)
+	if err != nil {
+		log.WithError(err).Error("failed to load user metadata for organisation members")
+		return err
+	}
+
+	metaMap := map[string]*datastore.UserMetadata{}
+	for _, u := range users {
+		metaMap[u.UID] = &datastore.UserMetadata{
+			UserID:    u.UID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+		}
+	}
+
+	for i := range members {
+		members[i].UserMetadata = metaMap[members[i].UserID]
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type sourceRepo struct {
+	store *store
+}
+
+func NewSourceRepo(db *DB) datastore.SourceRepository {
+	return &sourceRepo{store: newStore(db, SourceTable)}
+}
+
+func (s *sourceRepo) CreateSource(ctx context.Context, source *datastore.Source) error {
+	source.ID = primitive.NewObjectID()
+	return s.store.insert(ctx, source.CreatedAt, source)
+}
+
+func (s *sourceRepo) UpdateSource(ctx context.Context, groupId string, source *datastore.Source) error {
+	update := bson.M{
+		"name":               source.Name,
+		"type":               source.Type,
+		"is_disabled":        source.IsDisabled,
+		"verifier":           source.Verifier,
+		"updated_at":         primitive.NewDateTimeFromTime(time.Now()),
+		"provider_config":    source.ProviderConfig,
+		"idempotency_config": source.IdempotencyConfig,
+		"pub_sub":            source.PubSub,
+		"change_stream":      source.ChangeStream,
+		"resume_token":       source.ResumeToken,
+		"rest_api":           source.RestApi,
+		"rest_api_cursor":    source.RestApiCursor,
+	}
+
+	return s.store.update(ctx, newQuery().uid(source.UID).eq("group_id", groupId).active(), update)
+}
+
+func (s *sourceRepo) FindSourceByID(ctx context.Context, groupId string, id string) (*datastore.Source, error) {
+	source := &datastore.Source{}
+
+	err := s.store.findOne(ctx, newQuery().uid(id).eq("group_id", groupId).active(), 0, source)
+	if errors.Is(err, errNoDocuments) {
+		return source, datastore.ErrSourceNotFound
+	}
+
+	return source, err
+}
+
+func (s *sourceRepo) FindSourceByMaskID(ctx context.Context, maskId string) (*datastore.Source, error) {
+	source := &datastore.Source{}
+
+	err := s.store.findOne(ctx, newQuery().eq("mask_id", maskId).active(), 0, source)
+	if errors.Is(err, errNoDocuments) {
+		return source, datastore.ErrSourceNotFound
+	}
+
+	return source, err
+}
+
+func (s *sourceRepo) DeleteSourceByID(ctx context.Context, groupId string, id string) error {
+	return s.store.delete(ctx, newQuery().uid(id).eq("group_id", groupId), false)
+}
+
+func (s *sourceRepo) UpdateSourceResumeToken(ctx context.Context, groupId string, id string, token string) error {
+	return s.store.update(ctx, newQuery().uid(id).eq("group_id", groupId).active(), bson.M{"resume_token": token})
+}
+
+func (s *sourceRepo) UpdateSourceRestApiCursor(ctx context.Context, groupId string, id string, cursor *datastore.RestApiCursor) error {
+	return s.store.update(ctx, newQuery().uid(id).eq("group_id", groupId).active(), bson.M{"rest_api_cursor": cursor})
+}
+
+func (s *sourceRepo) LoadSourcesPaged(ctx context.Context, groupID string, f *datastore.SourceFilter, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
+	q := newQuery().active()
+
+	if !util.IsStringEmpty(groupID) {
+		q.eq("group_id", groupID)
+	}
+
+	if !util.IsStringEmpty(f.Type) {
+		q.eq("type", f.Type)
+	}
+
+	if !util.IsStringEmpty(f.Provider) {
+		q.eq("provider", f.Provider)
+	}
+
+	var sources []datastore.Source
+	paginationData, err := s.store.paginate(ctx, q, -1, pageable, &sources)
+	if err != nil {
+		return sources, datastore.PaginationData{}, err
+	}
+
+	return sources, paginationData, nil
+}
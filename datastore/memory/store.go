@@ -0,0 +1,524 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errNoDocuments is returned by findOne when nothing matches the query.
+var errNoDocuments = errors.New("no documents in result")
+
+// duplicateError is returned when a write violates a unique index.
+type duplicateError struct {
+	index string
+}
+
+func (e *duplicateError) Error() string {
+	return fmt.Sprintf("duplicate key error, index: %s", e.index)
+}
+
+// isDuplicate reports whether err was caused by the unique index named index.
+func isDuplicate(err error, index string) bool {
+	var dupErr *duplicateError
+	return errors.As(err, &dupErr) && dupErr.index == index
+}
+
+// uniqueIndex is a set of fields no two active documents in a table can share.
+type uniqueIndex struct {
+	name   string
+	fields []string
+}
+
+// record is a stored document, seq keeps the insertion order.
+type record struct {
+	seq       int64
+	createdAt primitive.DateTime
+	doc       bson.M
+}
+
+type table struct {
+	records []*record
+	indexes []uniqueIndex
+}
+
+// DB holds every table of the in-memory datastore.
+type DB struct {
+	mu     sync.RWMutex
+	seq    int64
+	tables map[string]*table
+}
+
+func NewDB() *DB {
+	db := &DB{tables: map[string]*table{}}
+	for _, name := range tables {
+		db.tables[name] = &table{indexes: uniqueIndexes[name]}
+	}
+
+	return db
+}
+
+func (db *DB) table(name string) *table {
+	return db.tables[name]
+}
+
+// toDocument converts a model to the document stored in a table, this way
+// every bson tag on the datastore models behaves the same way it does in mongo.
+func toDocument(v interface{}) (bson.M, error) {
+	b, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := bson.M{}
+	err = bson.Unmarshal(b, &doc)
+	return doc, err
+}
+
+func fromDocument(doc bson.M, out interface{}) error {
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return bson.Unmarshal(b, out)
+}
+
+// lookup returns the value of a document field, nested fields are
+// separated by dots e.g. "role.group".
+func lookup(doc bson.M, name string) (interface{}, bool) {
+	var v interface{} = doc
+	for _, part := range strings.Split(name, ".") {
+		m, ok := v.(bson.M)
+		if !ok {
+			return nil, false
+		}
+
+		v, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+// text returns the string form of a document value, the way it
+// is compared when filtering.
+func text(v interface{}) string {
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	return fmt.Sprint(v)
+}
+
+// query is the list of conditions a document must satisfy to match.
+type query struct {
+	conds []func(doc bson.M) bool
+}
+
+func newQuery() *query {
+	return &query{}
+}
+
+func (q *query) where(cond func(doc bson.M) bool) *query {
+	q.conds = append(q.conds, cond)
+	return q
+}
+
+func (q *query) eq(name string, v interface{}) *query {
+	want := text(v)
+	return q.where(func(doc bson.M) bool {
+		got, ok := lookup(doc, name)
+		return ok && text(got) == want
+	})
+}
+
+func (q *query) in(name string, values []string) *query {
+	return q.where(func(doc bson.M) bool {
+		got, ok := lookup(doc, name)
+		return ok && containsString(values, text(got))
+	})
+}
+
+// hasAny matches documents whose array field contains at least one of values.
+func (q *query) hasAny(name string, values []string) *query {
+	return q.where(func(doc bson.M) bool {
+		got, _ := lookup(doc, name)
+		arr, _ := got.(bson.A)
+		for _, item := range arr {
+			if containsString(values, text(item)) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (q *query) uid(uid string) *query {
+	return q.eq("uid", uid)
+}
+
+func (q *query) uids(uids []string) *query {
+	return q.in("uid", uids)
+}
+
+func (q *query) active() *query {
+	return q.eq("document_status", datastore.ActiveDocumentStatus)
+}
+
+func (q *query) createdBetween(start, end int64) *query {
+	from := primitive.NewDateTimeFromTime(time.Unix(start, 0))
+	to := primitive.NewDateTimeFromTime(time.Unix(end, 0))
+
+	return q.where(func(doc bson.M) bool {
+		createdAt, _ := doc["created_at"].(primitive.DateTime)
+		return createdAt >= from && createdAt <= to
+	})
+}
+
+func (q *query) matches(doc bson.M) bool {
+	for _, cond := range q.conds {
+		if !cond(doc) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// store runs the document operations shared by every table.
+type store struct {
+	db    *DB
+	table string
+}
+
+func newStore(db *DB, table string) *store {
+	return &store{db: db, table: table}
+}
+
+func (s *store) insert(ctx context.Context, createdAt primitive.DateTime, v interface{}) error {
+	doc, err := toDocument(v)
+	if err != nil {
+		return err
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.table(s.table)
+	if err := t.checkUnique(nil, doc); err != nil {
+		return err
+	}
+
+	s.db.seq++
+	t.records = append(t.records, &record{seq: s.db.seq, createdAt: createdAt, doc: doc})
+	return nil
+}
+
+// find returns the records matched by q sorted by their created_at
+// in the sort direction, 0 keeps the insertion order.
+func (s *store) find(q *query, sortDir int) []*record {
+	t := s.db.table(s.table)
+
+	matched := make([]*record, 0)
+	for _, r := range t.records {
+		if q.matches(r.doc) {
+			matched = append(matched, r)
+		}
+	}
+
+	if sortDir != 0 {
+		sort.Slice(matched, func(i, j int) bool {
+			a, b := matched[i], matched[j]
+			if sortDir != 1 {
+				a, b = b, a
+			}
+
+			if a.createdAt == b.createdAt {
+				return a.seq < b.seq
+			}
+			return a.createdAt < b.createdAt
+		})
+	}
+
+	return matched
+}
+
+// findOne decodes the first document matched by q into out, it
+// returns errNoDocuments when nothing matches.
+func (s *store) findOne(ctx context.Context, q *query, sortDir int, out interface{}) error {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	matched := s.find(q, sortDir)
+	if len(matched) == 0 {
+		return errNoDocuments
+	}
+
+	return fromDocument(matched[0].doc, out)
+}
+
+// findMany decodes every document matched by q into out, which must be
+// a pointer to a slice of models or model pointers.
+func (s *store) findMany(ctx context.Context, q *query, sortDir int, out interface{}) error {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	return decodeRecords(s.find(q, sortDir), out)
+}
+
+func (s *store) count(ctx context.Context, q *query) (int64, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	return int64(len(s.find(q, 0))), nil
+}
+
+// update merges the top level fields of payload into every document matched by q.
+func (s *store) update(ctx context.Context, q *query, payload interface{}) error {
+	set, err := toDocument(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.modify(q, func(doc bson.M) {
+		for k, v := range set {
+			doc[k] = v
+		}
+	})
+}
+
+// push appends item to the array field name of every document matched by q,
+// merges set into them and removes the unset fields.
+func (s *store) push(ctx context.Context, q *query, name string, item interface{}, set bson.M, unset ...string) error {
+	it, err := toDocument(item)
+	if err != nil {
+		return err
+	}
+
+	fields, err := toDocument(set)
+	if err != nil {
+		return err
+	}
+
+	return s.modify(q, func(doc bson.M) {
+		arr, _ := doc[name].(bson.A)
+		doc[name] = append(append(bson.A{}, arr...), it)
+
+		for k, v := range fields {
+			doc[k] = v
+		}
+
+		for _, k := range unset {
+			delete(doc, k)
+		}
+	})
+}
+
+// modify applies fn to a copy of every document matched by q, the
+// copies replace the documents once they all satisfy the unique indexes.
+func (s *store) modify(q *query, fn func(doc bson.M)) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.table(s.table)
+
+	matched := s.find(q, 0)
+	docs := make([]bson.M, len(matched))
+	for i, r := range matched {
+		doc := bson.M{}
+		for k, v := range r.doc {
+			doc[k] = v
+		}
+
+		fn(doc)
+		if err := t.checkUnique(r, doc); err != nil {
+			return err
+		}
+		docs[i] = doc
+	}
+
+	for i, r := range matched {
+		r.doc = docs[i]
+	}
+
+	return nil
+}
+
+func (s *store) delete(ctx context.Context, q *query, hardDelete bool) error {
+	if !hardDelete {
+		update := bson.M{
+			"deleted_at":      primitive.NewDateTimeFromTime(time.Now()),
+			"document_status": datastore.DeletedDocumentStatus,
+		}
+
+		return s.update(ctx, q, update)
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	t := s.db.table(s.table)
+
+	kept := t.records[:0]
+	for _, r := range t.records {
+		if !q.matches(r.doc) {
+			kept = append(kept, r)
+		}
+	}
+	t.records = kept
+
+	return nil
+}
+
+// paginate loads a page of the documents matched by q into out.
+func (s *store) paginate(ctx context.Context, q *query, sortDir int, pageable datastore.Pageable, out interface{}) (datastore.PaginationData, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	matched := s.find(q, sortDir)
+	data := paginationData(int64(len(matched)), pageable)
+
+	return data, decodeRecords(page(matched, data), out)
+}
+
+func page(records []*record, data datastore.PaginationData) []*record {
+	start := (data.Page - 1) * data.PerPage
+	if start >= int64(len(records)) {
+		return nil
+	}
+
+	end := start + data.PerPage
+	if end > int64(len(records)) {
+		end = int64(len(records))
+	}
+
+	return records[start:end]
+}
+
+// paginationData computes the page stats the same way the mongo pager does.
+func paginationData(total int64, pageable datastore.Pageable) datastore.PaginationData {
+	page, perPage := int64(pageable.Page), int64(pageable.PerPage)
+	if page < 1 {
+		page = 1
+	}
+
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	data := datastore.PaginationData{
+		Total:     total,
+		Page:      page,
+		PerPage:   perPage,
+		TotalPage: (total + perPage - 1) / perPage,
+	}
+
+	if total > 0 && page > 1 {
+		data.Prev = page - 1
+	}
+
+	if total > 0 && page < data.TotalPage {
+		data.Next = page + 1
+	}
+
+	return data
+}
+
+// checkUnique returns a duplicateError when doc shares the fields of a unique
+// index with another active document, self is the record being replaced.
+func (t *table) checkUnique(self *record, doc bson.M) error {
+	if text(doc["document_status"]) != string(datastore.ActiveDocumentStatus) {
+		return nil
+	}
+
+	for _, r := range t.records {
+		if r == self || text(r.doc["document_status"]) != string(datastore.ActiveDocumentStatus) {
+			continue
+		}
+
+		for _, idx := range t.indexes {
+			if sameFields(r.doc, doc, idx.fields) {
+				return &duplicateError{index: idx.name}
+			}
+		}
+	}
+
+	return nil
+}
+
+func sameFields(a, b bson.M, fields []string) bool {
+	for _, f := range fields {
+		x, _ := lookup(a, f)
+		y, _ := lookup(b, f)
+		if !reflect.DeepEqual(x, y) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodeRecords(records []*record, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return datastore.ErrInvalidPtr
+	}
+
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	if slice.IsNil() {
+		slice.Set(reflect.MakeSlice(slice.Type(), 0, len(records)))
+	}
+
+	for _, r := range records {
+		elem := reflect.New(elemType)
+		if err := fromDocument(r.doc, elem.Interface()); err != nil {
+			return err
+		}
+
+		if !isPtr {
+			elem = elem.Elem()
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+
+	return nil
+}
+
+// sortOrder maps a pageable sort to a sort direction, 1 sorts in
+// ascending order and anything else in descending order.
+func sortOrder(sort int) int {
+	if sort == 1 {
+		return 1
+	}
+
+	return -1
+}
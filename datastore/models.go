@@ -7,11 +7,17 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/auth"
+	"github.com/frain-dev/convoy/circuitbreaker"
 	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/pkg/filter"
 	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/pkg/transform"
+	"github.com/frain-dev/convoy/pkg/verifier"
+	"github.com/robfig/cron/v3"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -79,6 +85,12 @@ type EncodingType string
 
 type StorageType string
 
+type TransformType string
+
+type PubSubType string
+
+type ChangeStreamType string
+
 const (
 	HTTPSource     SourceType = "http"
 	RestApiSource  SourceType = "rest_api"
@@ -87,9 +99,23 @@ const (
 )
 
 const (
-	GithubSourceProvider  SourceProvider = "github"
-	TwitterSourceProvider SourceProvider = "twitter"
-	ShopifySourceProvider SourceProvider = "shopify"
+	RedisStreamsPubSub PubSubType = "redis_streams"
+	AMQPPubSub         PubSubType = "amqp"
+)
+
+const (
+	MongoChangeStream ChangeStreamType = "mongo"
+)
+
+const (
+	GithubSourceProvider   SourceProvider = "github"
+	TwitterSourceProvider  SourceProvider = "twitter"
+	ShopifySourceProvider  SourceProvider = "shopify"
+	StripeSourceProvider   SourceProvider = "stripe"
+	SlackSourceProvider    SourceProvider = "slack"
+	PaystackSourceProvider SourceProvider = "paystack"
+	TwilioSourceProvider   SourceProvider = "twilio"
+	GitlabSourceProvider   SourceProvider = "gitlab"
 )
 
 const (
@@ -109,6 +135,11 @@ const (
 	IncomingGroup GroupType = "incoming"
 )
 
+const (
+	MappingTransform  TransformType = "mapping"
+	TemplateTransform TransformType = "template"
+)
+
 const (
 	S3     StorageType = "s3"
 	OnPrem StorageType = "on_prem"
@@ -135,6 +166,41 @@ const (
 	ExponentialStrategyProvider = "exponential"
 )
 
+type JitterMode string
+type RetryDecision string
+type DeliveryMode string
+
+const (
+	FullJitter         JitterMode = "full"
+	EqualJitter        JitterMode = "equal"
+	DecorrelatedJitter JitterMode = "decorrelated"
+)
+
+const (
+	// RetryScheduledDecision : the delivery is retried on the configured schedule
+	RetryScheduledDecision RetryDecision = "retry"
+	// RetryAfterDecision : the delivery is retried when the endpoint's Retry-After elapses
+	RetryAfterDecision RetryDecision = "retry_after"
+	// RetryLimitExceededDecision : the delivery failed after exhausting its retries
+	RetryLimitExceededDecision RetryDecision = "retry_limit_exceeded"
+	// NonRetryableDecision : the endpoint responded with a non-retryable status code
+	NonRetryableDecision RetryDecision = "non_retryable"
+	// GoneDecision : the endpoint responded with 410 Gone and its subscription was deactivated
+	GoneDecision RetryDecision = "gone"
+)
+
+const (
+	// UnorderedDeliveryMode : deliveries are sent independently of each other
+	UnorderedDeliveryMode DeliveryMode = "unordered"
+	// OrderedDeliveryMode : deliveries with the same ordering key are sent one at a time, in order
+	OrderedDeliveryMode DeliveryMode = "ordered"
+)
+
+// IsTerminal reports whether the delivery should fail without being retried.
+func (r RetryDecision) IsTerminal() bool {
+	return r == NonRetryableDecision || r == GoneDecision
+}
+
 var (
 	DefaultStrategyConfig = StrategyConfiguration{
 		Type:       "linear",
@@ -158,6 +224,10 @@ var (
 		RetryCount: 3,
 	}
 
+	DefaultIdempotencyConfig = IdempotencyConfiguration{
+		Window: "24h",
+	}
+
 	DefaultAlertConfig = AlertConfiguration{
 		Count:     4,
 		Threshold: "1h",
@@ -185,6 +255,11 @@ type Application struct {
 	SlackWebhookURL string             `json:"slack_webhook_url,omitempty" bson:"slack_webhook_url"`
 	IsDisabled      bool               `json:"is_disabled,omitempty" bson:"is_disabled"`
 
+	// OwnerID and Tags group applications, an event addressed
+	// to an owner or tags is sent to every matching application
+	OwnerID string   `json:"owner_id,omitempty" bson:"owner_id,omitempty"`
+	Tags    []string `json:"tags,omitempty" bson:"tags,omitempty"`
+
 	Endpoints []Endpoint         `json:"endpoints,omitempty" bson:"endpoints"`
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
@@ -203,10 +278,20 @@ type Endpoint struct {
 	Description string `json:"description" bson:"description"`
 	Secret      string `json:"secret" bson:"secret"`
 
+	// RotatedSecrets are previous secrets that are still valid, deliveries
+	// are signed with them as well until they expire.
+	RotatedSecrets []RotatedSecret `json:"rotated_secrets,omitempty" bson:"rotated_secrets,omitempty"`
+
 	HttpTimeout       string `json:"http_timeout" bson:"http_timeout"`
 	RateLimit         int    `json:"rate_limit" bson:"rate_limit"`
 	RateLimitDuration string `json:"rate_limit_duration" bson:"rate_limit_duration"`
 
+	TLS            *EndpointTLSConfig      `json:"tls,omitempty" bson:"tls,omitempty"`
+	Authentication *EndpointAuthentication `json:"authentication,omitempty" bson:"authentication,omitempty"`
+
+	// CircuitBreaker is loaded from the circuit breaker store, it isn't persisted.
+	CircuitBreaker *circuitbreaker.Breaker `json:"circuit_breaker,omitempty" bson:"-"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
@@ -214,6 +299,102 @@ type Endpoint struct {
 	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
 
+// MaskedSecret replaces secrets in API responses, sending it back in
+// an update keeps the stored secret.
+const MaskedSecret = "********"
+
+// EndpointTLSConfig configures the tls connections of deliveries to an
+// endpoint. Certificates and keys are PEM encoded, CACert replaces the
+// system's root CAs and MinVersion is one of 1.0, 1.1, 1.2 or 1.3.
+type EndpointTLSConfig struct {
+	ClientCert string `json:"client_cert,omitempty" bson:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty" bson:"client_key,omitempty"`
+	CACert     string `json:"ca_cert,omitempty" bson:"ca_cert,omitempty"`
+	MinVersion string `json:"min_version,omitempty" bson:"min_version,omitempty"`
+	ServerName string `json:"server_name,omitempty" bson:"server_name,omitempty"`
+}
+
+// MarshalJSON masks the client key, responses only show that one is set.
+func (t EndpointTLSConfig) MarshalJSON() ([]byte, error) {
+	type tlsConfig EndpointTLSConfig
+
+	c := tlsConfig(t)
+	if len(c.ClientKey) > 0 {
+		c.ClientKey = MaskedSecret
+	}
+
+	return json.Marshal(c)
+}
+
+type EndpointAuthenticationType string
+
+const (
+	BearerEndpointAuthentication EndpointAuthenticationType = "bearer"
+	BasicEndpointAuthentication  EndpointAuthenticationType = "basic"
+	OAuth2EndpointAuthentication EndpointAuthenticationType = "oauth2"
+)
+
+// EndpointAuthentication authenticates deliveries to endpoints behind
+// gateways, with a static bearer token, basic auth or an OAuth2 token
+// fetched with the client credentials grant.
+type EndpointAuthentication struct {
+	Type      EndpointAuthenticationType `json:"type" bson:"type" valid:"required~please provide an authentication type,supported_endpoint_authentication~unsupported authentication type"`
+	Token     string                     `json:"token,omitempty" bson:"token,omitempty"`
+	BasicAuth *BasicAuth                 `json:"basic_auth,omitempty" bson:"basic_auth,omitempty"`
+	OAuth2    *OAuth2Config              `json:"oauth2,omitempty" bson:"oauth2,omitempty"`
+}
+
+type OAuth2Config struct {
+	TokenURL     string   `json:"token_url" bson:"token_url" valid:"required~please provide a token url,url~please provide a valid token url"`
+	ClientID     string   `json:"client_id" bson:"client_id" valid:"required~please provide a client id"`
+	ClientSecret string   `json:"client_secret" bson:"client_secret" valid:"required~please provide a client secret"`
+	Scopes       []string `json:"scopes,omitempty" bson:"scopes,omitempty"`
+	Audience     string   `json:"audience,omitempty" bson:"audience,omitempty"`
+}
+
+// MarshalJSON masks the token, password and client secret, responses
+// only show that they are set.
+func (a EndpointAuthentication) MarshalJSON() ([]byte, error) {
+	type endpointAuthentication EndpointAuthentication
+
+	c := endpointAuthentication(a)
+	if len(c.Token) > 0 {
+		c.Token = MaskedSecret
+	}
+
+	if c.BasicAuth != nil {
+		basicAuth := *c.BasicAuth
+		basicAuth.Password = MaskedSecret
+		c.BasicAuth = &basicAuth
+	}
+
+	if c.OAuth2 != nil {
+		oauth2 := *c.OAuth2
+		oauth2.ClientSecret = MaskedSecret
+		c.OAuth2 = &oauth2
+	}
+
+	return json.Marshal(c)
+}
+
+type RotatedSecret struct {
+	Secret    string             `json:"secret" bson:"secret"`
+	ExpiresAt primitive.DateTime `json:"expires_at" bson:"expires_at" swaggertype:"string"`
+}
+
+// Secrets returns the secrets deliveries to the endpoint are signed with,
+// the current secret followed by the rotated secrets that haven't expired.
+func (e *Endpoint) Secrets(now time.Time) []string {
+	secrets := []string{e.Secret}
+	for _, s := range e.RotatedSecrets {
+		if s.ExpiresAt.Time().After(now) {
+			secrets = append(secrets, s.Secret)
+		}
+	}
+
+	return secrets
+}
+
 var ErrOrgNotFound = errors.New("organisation not found")
 var ErrDeviceNotFound = errors.New("device not found")
 var ErrOrgInviteNotFound = errors.New("organisation invite not found")
@@ -234,6 +415,10 @@ type Group struct {
 	RateLimitDuration string         `json:"rate_limit_duration" bson:"rate_limit_duration"`
 	Metadata          *GroupMetadata `json:"metadata" bson:"metadata"`
 
+	// SigningKeys are the group's key pairs for asymmetric signatures, only
+	// their public keys are exposed through the group's jwks.
+	SigningKeys []SigningKey `json:"-" bson:"signing_keys,omitempty"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
@@ -253,6 +438,88 @@ type GroupConfig struct {
 	DisableEndpoint          bool                          `json:"disable_endpoint" bson:"disable_endpoint"`
 	ReplayAttacks            bool                          `json:"replay_attacks" bson:"replay_attacks"`
 	IsRetentionPolicyEnabled bool                          `json:"is_retention_policy_enabled" bson:"is_retention_policy_enabled"`
+	Idempotency              *IdempotencyConfiguration     `json:"idempotency,omitempty" bson:"idempotency,omitempty"`
+	Proxy                    *ProxyConfiguration           `json:"proxy,omitempty" bson:"proxy,omitempty"`
+}
+
+// ProxyConfiguration routes the group's deliveries through an http, https
+// or socks5 proxy instead of the global proxy.
+type ProxyConfiguration struct {
+	URL      string `json:"url" bson:"url" valid:"required~please provide a proxy url,supported_proxy_url~unsupported proxy url scheme"`
+	Username string `json:"username,omitempty" bson:"username,omitempty"`
+	Password string `json:"password,omitempty" bson:"password,omitempty"`
+}
+
+// MarshalJSON masks the password, responses only show that one is set.
+func (p ProxyConfiguration) MarshalJSON() ([]byte, error) {
+	type proxyConfiguration ProxyConfiguration
+
+	c := proxyConfiguration(p)
+	if len(c.Password) > 0 {
+		c.Password = MaskedSecret
+	}
+
+	return json.Marshal(c)
+}
+
+// IdempotencyConfiguration controls how long an event's idempotency key is
+// remembered, a duplicate received within the window returns the original event.
+type IdempotencyConfiguration struct {
+	Window string `json:"window,omitempty" bson:"window,omitempty" valid:"duration~please provide a valid idempotency window"`
+
+	// ProviderID is a header name or a payload path (e.g. $.id) holding the
+	// provider's event id, it is used as the idempotency key of events
+	// ingested by a source when the Idempotency-Key header isn't set.
+	ProviderID string `json:"provider_id,omitempty" bson:"provider_id,omitempty"`
+}
+
+// WindowDuration returns the idempotency window, it falls back to the
+// default window when the configuration is missing or invalid.
+func (i *IdempotencyConfiguration) WindowDuration() time.Duration {
+	window, _ := time.ParseDuration(DefaultIdempotencyConfig.Window)
+	if i == nil || strings.TrimSpace(i.Window) == "" {
+		return window
+	}
+
+	d, err := time.ParseDuration(i.Window)
+	if err != nil || d <= 0 {
+		return window
+	}
+
+	return d
+}
+
+// ProviderIDFrom returns the provider's event id from the request
+// header or payload, it is empty when no provider id is configured.
+func (i *IdempotencyConfiguration) ProviderIDFrom(header http.Header, payload []byte) string {
+	if i == nil || strings.TrimSpace(i.ProviderID) == "" {
+		return ""
+	}
+
+	if !strings.HasPrefix(i.ProviderID, "$.") {
+		return header.Get(i.ProviderID)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return ""
+	}
+
+	v, ok := transform.Lookup(data, i.ProviderID)
+	if !ok || v == nil {
+		return ""
+	}
+
+	if str, ok := v.(string); ok {
+		return str
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
 }
 
 type RateLimitConfiguration struct {
@@ -264,11 +531,76 @@ type StrategyConfiguration struct {
 	Type       StrategyProvider `json:"type" valid:"required~please provide a valid strategy type, in(linear|exponential)~unsupported strategy type"`
 	Duration   uint64           `json:"duration" valid:"required~please provide a valid duration in seconds,int"`
 	RetryCount uint64           `json:"retry_count" valid:"required~please provide a valid retry count,int"`
+
+	// Multiplier, MaxInterval (in seconds) and Jitter only apply to the
+	// exponential strategy, Duration is used as its base interval.
+	Multiplier  float64    `json:"multiplier,omitempty"`
+	MaxInterval uint64     `json:"max_interval,omitempty" valid:"int~please provide a valid max interval in seconds"`
+	Jitter      JitterMode `json:"jitter,omitempty" valid:"supported_jitter~unsupported jitter mode"`
 }
 
 type SignatureConfiguration struct {
-	Header config.SignatureHeaderProvider `json:"header,omitempty" valid:"required~please provide a valid signature header"`
-	Hash   string                         `json:"hash,omitempty" valid:"required~please provide a valid hash,supported_hash~unsupported hash type"`
+	Header     config.SignatureHeaderProvider    `json:"header,omitempty" valid:"required~please provide a valid signature header"`
+	Hash       string                            `json:"hash,omitempty" valid:"required~please provide a valid hash,supported_hash~unsupported hash type"`
+	Scheme     SignatureScheme                   `json:"scheme,omitempty" bson:"scheme,omitempty" valid:"supported_signature_scheme~unsupported signature scheme"`
+	Asymmetric *AsymmetricSignatureConfiguration `json:"asymmetric,omitempty" bson:"asymmetric,omitempty"`
+}
+
+// SignatureScheme is the format of webhook signatures, Header and Hash only
+// apply to the convoy scheme as Standard Webhooks fixes both.
+type SignatureScheme string
+
+const (
+	ConvoySignatureScheme           SignatureScheme = "convoy"
+	StandardWebhooksSignatureScheme SignatureScheme = "standard_webhooks"
+)
+
+// AsymmetricSignatureConfiguration enables signing webhooks with the group's
+// private key in addition to the endpoint secret's hmac, endpoints verify
+// the signature with the public key published in the group's jwks.
+type AsymmetricSignatureConfiguration struct {
+	Algorithm SigningAlgorithm               `json:"algorithm" valid:"required~please provide a signing algorithm,supported_signing_algorithm~unsupported signing algorithm"`
+	Header    config.SignatureHeaderProvider `json:"header,omitempty"`
+}
+
+func (a *AsymmetricSignatureConfiguration) HeaderName() string {
+	if len(strings.TrimSpace(a.Header.String())) == 0 {
+		return config.DefaultAsymmetricSignatureHeader.String()
+	}
+
+	return a.Header.String()
+}
+
+type SigningAlgorithm string
+
+const (
+	Ed25519SigningAlgorithm SigningAlgorithm = "EdDSA"
+	RSASigningAlgorithm     SigningAlgorithm = "RS256"
+)
+
+// SigningKey is a key pair of a group, PrivateKey is PEM encoded PKCS #8.
+type SigningKey struct {
+	UID        string             `json:"uid" bson:"uid"`
+	Algorithm  SigningAlgorithm   `json:"algorithm" bson:"algorithm"`
+	PrivateKey string             `json:"-" bson:"private_key"`
+	CreatedAt  primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
+}
+
+// SigningKey returns the group's newest key of the algorithm.
+func (g *Group) SigningKey(alg SigningAlgorithm) *SigningKey {
+	var key *SigningKey
+	for i := range g.SigningKeys {
+		k := &g.SigningKeys[i]
+		if k.Algorithm != alg {
+			continue
+		}
+
+		if key == nil || k.CreatedAt >= key.CreatedAt {
+			key = k
+		}
+	}
+
+	return key
 }
 
 type SignatureValues struct {
@@ -338,11 +670,13 @@ var (
 	ErrSubscriptionNotFound          = errors.New("subscription not found")
 	ErrEventDeliveryNotFound         = errors.New("event delivery not found")
 	ErrEventDeliveryAttemptNotFound  = errors.New("event delivery attempt not found")
+	ErrDeadLetterNotFound            = errors.New("dead letter not found")
 	ErrDuplicateAppName              = errors.New("an application with this name exists")
 	ErrNotAuthorisedToAccessDocument = errors.New("your credentials cannot access or modify this resource")
 	ErrConfigNotFound                = errors.New("config not found")
 	ErrDuplicateGroupName            = errors.New("a group with this name already exists")
 	ErrDuplicateEmail                = errors.New("a user with this email already exists")
+	ErrInvalidNonRetryableStatusCode = errors.New("please provide valid non-retryable status codes")
 )
 
 type AppMetadata struct {
@@ -377,6 +711,15 @@ type Event struct {
 	App        *Application          `json:"app_metadata,omitempty" bson:"-"`
 	Source     *Source               `json:"source_metadata,omitempty" bson:"-"`
 
+	// OwnerID and AppTags address the Event to every application with the
+	// owner id or with any of the tags, they are empty when AppID is set.
+	OwnerID string   `json:"owner_id,omitempty" bson:"owner_id,omitempty"`
+	AppTags []string `json:"app_tags,omitempty" bson:"app_tags,omitempty"`
+
+	// IdempotencyKey is the key this Event was created with, a request
+	// with the same key within the idempotency window returns this Event.
+	IdempotencyKey string `json:"idempotency_key,omitempty" bson:"idempotency_key,omitempty"`
+
 	// Data is an arbitrary JSON value that gets sent as the body of the
 	// webhook to the endpoints
 	Data json.RawMessage `json:"data,omitempty" bson:"data"`
@@ -406,8 +749,20 @@ const (
 	FailureEventStatus    EventDeliveryStatus = "Failure"
 	SuccessEventStatus    EventDeliveryStatus = "Success"
 	RetryEventStatus      EventDeliveryStatus = "Retry"
+
+	// CircuitOpenEventStatus : when a delivery is held back because the
+	// endpoint's circuit breaker is open, it doesn't consume a retry.
+	CircuitOpenEventStatus EventDeliveryStatus = "CircuitOpen"
+
+	// BlockedEventStatus : when an ordered delivery is waiting for the
+	// head of its ordering queue to succeed or be dead-lettered.
+	BlockedEventStatus EventDeliveryStatus = "Blocked"
 )
 
+// ProcessingTimeout is how long a delivery can stay in processing before
+// it is considered abandoned by a worker that crashed while sending it.
+const ProcessingTimeout = 10 * time.Minute
+
 func (e EventDeliveryStatus) IsValid() bool {
 	switch e {
 	case ScheduledEventStatus,
@@ -415,7 +770,9 @@ func (e EventDeliveryStatus) IsValid() bool {
 		DiscardedEventStatus,
 		FailureEventStatus,
 		SuccessEventStatus,
-		RetryEventStatus:
+		RetryEventStatus,
+		CircuitOpenEventStatus,
+		BlockedEventStatus:
 		return true
 	default:
 		return false
@@ -441,6 +798,12 @@ type Metadata struct {
 
 	IntervalSeconds uint64 `json:"interval_seconds" bson:"interval_seconds"`
 
+	// Multiplier, MaxIntervalSeconds and Jitter parameterise the
+	// exponential strategy, IntervalSeconds is used as its base interval.
+	Multiplier         float64    `json:"multiplier,omitempty" bson:"multiplier,omitempty"`
+	MaxIntervalSeconds uint64     `json:"max_interval_seconds,omitempty" bson:"max_interval_seconds,omitempty"`
+	Jitter             JitterMode `json:"jitter,omitempty" bson:"jitter,omitempty"`
+
 	RetryLimit uint64 `json:"retry_limit" bson:"retry_limit"`
 }
 
@@ -474,6 +837,7 @@ type DeliveryAttempt struct {
 	APIVersion string             `json:"api_version" bson:"api_version"`
 
 	IPAddress        string     `json:"ip_address,omitempty" bson:"ip_address,omitempty"`
+	Proxy            string     `json:"proxy,omitempty" bson:"proxy,omitempty"`
 	RequestHeader    HttpHeader `json:"request_http_header,omitempty" bson:"request_http_header,omitempty"`
 	ResponseHeader   HttpHeader `json:"response_http_header,omitempty" bson:"response_http_header,omitempty"`
 	HttpResponseCode string     `json:"http_status,omitempty" bson:"http_status,omitempty"`
@@ -481,6 +845,9 @@ type DeliveryAttempt struct {
 	Error            string     `json:"error,omitempty" bson:"error,omitempty"`
 	Status           bool       `json:"status,omitempty" bson:"status,omitempty"`
 
+	// RetryDecision records how the delivery was handled after a failed attempt.
+	RetryDecision RetryDecision `json:"retry_decision,omitempty" bson:"retry_decision,omitempty"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
@@ -497,6 +864,8 @@ type EventDelivery struct {
 	DeviceID       string                `json:"device_id" bson:"device_id"`
 	SubscriptionID string                `json:"subscription_id,omitempty" bson:"subscription_id"`
 	Headers        httpheader.HTTPHeader `json:"headers" bson:"headers"`
+	OrderingKey    string                `json:"ordering_key,omitempty" bson:"ordering_key,omitempty"`
+	BlockedBy      string                `json:"blocked_by,omitempty" bson:"blocked_by,omitempty"`
 
 	Endpoint *Endpoint    `json:"endpoint_metadata,omitempty" bson:"-"`
 	Event    *Event       `json:"event_metadata,omitempty" bson:"-"`
@@ -514,6 +883,34 @@ type EventDelivery struct {
 	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
 
+// IsStaleProcessing reports whether the delivery has been in processing
+// for longer than ProcessingTimeout.
+func (e *EventDelivery) IsStaleProcessing(now time.Time) bool {
+	return e.Status == ProcessingEventStatus && now.Sub(e.UpdatedAt.Time()) > ProcessingTimeout
+}
+
+// DeadLetter is an event delivery that exhausted its retries, kept
+// with its final attempt until it is replayed or purged.
+type DeadLetter struct {
+	ID              primitive.ObjectID `json:"-" bson:"_id"`
+	UID             string             `json:"uid" bson:"uid"`
+	GroupID         string             `json:"group_id" bson:"group_id"`
+	AppID           string             `json:"app_id" bson:"app_id"`
+	EventID         string             `json:"event_id" bson:"event_id"`
+	EventDeliveryID string             `json:"event_delivery_id" bson:"event_delivery_id"`
+	SubscriptionID  string             `json:"subscription_id" bson:"subscription_id"`
+	EndpointID      string             `json:"endpoint_id" bson:"endpoint_id"`
+	Reason          string             `json:"reason" bson:"reason"`
+	NumTrials       uint64             `json:"num_trials" bson:"num_trials"`
+	LastAttempt     *DeliveryAttempt   `json:"last_attempt,omitempty" bson:"last_attempt,omitempty"`
+
+	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at,omitempty" swaggertype:"string"`
+	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at,omitempty" swaggertype:"string"`
+	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swaggertype:"string"`
+
+	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
+}
+
 type CLIMetadata struct {
 	EventType string `json:"event_type" bson:"event_type"`
 	HostName  string `json:"host_name,omitempty" bson:"-"`
@@ -550,14 +947,20 @@ type Subscription struct {
 	EndpointID string             `json:"-" bson:"endpoint_id"`
 	DeviceID   string             `json:"device_id" bson:"device_id"`
 
+	// DeliveryMode is either ordered or unordered, OrderingKey is an optional
+	// payload path used to split ordered deliveries into independent queues
+	DeliveryMode DeliveryMode `json:"delivery_mode,omitempty" bson:"delivery_mode,omitempty"`
+	OrderingKey  string       `json:"ordering_key,omitempty" bson:"ordering_key,omitempty"`
+
 	Source   *Source      `json:"source_metadata,omitempty" bson:"-"`
 	Endpoint *Endpoint    `json:"endpoint_metadata,omitempty" bson:"-"`
 	App      *Application `json:"app_metadata,omitempty" bson:"-"`
 
 	// subscription config
-	AlertConfig  *AlertConfiguration  `json:"alert_config,omitempty" bson:"alert_config,omitempty"`
-	RetryConfig  *RetryConfiguration  `json:"retry_config,omitempty" bson:"retry_config,omitempty"`
-	FilterConfig *FilterConfiguration `json:"filter_config,omitempty" bson:"filter_config,omitempty"`
+	AlertConfig     *AlertConfiguration     `json:"alert_config,omitempty" bson:"alert_config,omitempty"`
+	RetryConfig     *RetryConfiguration     `json:"retry_config,omitempty" bson:"retry_config,omitempty"`
+	FilterConfig    *FilterConfiguration    `json:"filter_config,omitempty" bson:"filter_config,omitempty"`
+	TransformConfig *TransformConfiguration `json:"transform_config,omitempty" bson:"transform_config,omitempty"`
 
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at" swaggertype:"string"`
@@ -566,6 +969,50 @@ type Subscription struct {
 	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
 
+// DeliveryOrderingKey returns the key deliveries of data are ordered by, an
+// empty key means the delivery isn't ordered. When the ordering key path is
+// missing from the payload the delivery falls back to the subscription's queue.
+func (s *Subscription) DeliveryOrderingKey(data json.RawMessage) string {
+	if s.DeliveryMode != OrderedDeliveryMode {
+		return ""
+	}
+
+	if strings.TrimSpace(s.OrderingKey) == "" {
+		return s.UID
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return s.UID
+	}
+
+	v, ok := transform.Lookup(payload, s.OrderingKey)
+	if !ok || v == nil {
+		return s.UID
+	}
+
+	if str, ok := v.(string); ok {
+		return s.UID + ":" + str
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return s.UID
+	}
+
+	return s.UID + ":" + string(b)
+}
+
+// OrderingQueue describes the pending deliveries of an ordering key,
+// the head is the delivery every other one in the queue is waiting on.
+type OrderingQueue struct {
+	OrderingKey   string              `json:"ordering_key" bson:"_id"`
+	HeadID        string              `json:"head_id" bson:"head_id"`
+	HeadStatus    EventDeliveryStatus `json:"head_status" bson:"head_status"`
+	HeadCreatedAt primitive.DateTime  `json:"head_created_at" bson:"head_created_at" swaggertype:"string"`
+	Blocked       int64               `json:"blocked" bson:"blocked"`
+}
+
 type Source struct {
 	ID             primitive.ObjectID `json:"-" bson:"_id"`
 	UID            string             `json:"uid" bson:"uid"`
@@ -579,6 +1026,17 @@ type Source struct {
 	ProviderConfig *ProviderConfig    `json:"provider_config" bson:"provider_config"`
 	ForwardHeaders []string           `json:"forward_headers" bson:"forward_headers"`
 
+	IdempotencyConfig *IdempotencyConfiguration `json:"idempotency_config,omitempty" bson:"idempotency_config,omitempty"`
+	PubSub            *PubSubConfig             `json:"pub_sub,omitempty" bson:"pub_sub,omitempty"`
+	ChangeStream      *ChangeStreamConfig       `json:"change_stream,omitempty" bson:"change_stream,omitempty"`
+
+	// ResumeToken is the position of the last change of a db_change_stream
+	// source written to the event queue, the stream resumes after it.
+	ResumeToken string `json:"-" bson:"resume_token,omitempty"`
+
+	RestApi       *RestApiConfig `json:"rest_api,omitempty" bson:"rest_api,omitempty"`
+	RestApiCursor *RestApiCursor `json:"rest_api_cursor,omitempty" bson:"rest_api_cursor,omitempty"`
+
 	CreatedAt primitive.DateTime `json:"created_at,omitempty" bson:"created_at" swaggertype:"string"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty" bson:"updated_at" swaggertype:"string"`
 	DeletedAt primitive.DateTime `json:"deleted_at,omitempty" bson:"deleted_at" swaggertype:"string"`
@@ -586,6 +1044,154 @@ type Source struct {
 	DocumentStatus DocumentStatus `json:"-" bson:"document_status"`
 }
 
+// PubSubConfig is the message broker a pub_sub source consumes events from.
+type PubSubConfig struct {
+	Type PubSubType `json:"type" bson:"type" valid:"required~please provide a pub sub type,supported_pub_sub~unsupported pub sub type"`
+
+	// Workers is the number of messages of the source processed
+	// concurrently, it defaults to 1.
+	Workers int `json:"workers" bson:"workers" valid:"range(0|100)~workers must be between 1 and 100"`
+
+	RedisStreams *RedisStreamsPubSubConfig `json:"redis_streams,omitempty" bson:"redis_streams,omitempty"`
+	AMQP         *AMQPPubSubConfig         `json:"amqp,omitempty" bson:"amqp,omitempty"`
+}
+
+// WorkerCount returns the number of concurrent workers of the source.
+func (p *PubSubConfig) WorkerCount() int {
+	if p == nil || p.Workers <= 0 {
+		return 1
+	}
+
+	return p.Workers
+}
+
+type RedisStreamsPubSubConfig struct {
+	DSN           string `json:"dsn" bson:"dsn" valid:"required~please provide the redis dsn"`
+	Stream        string `json:"stream" bson:"stream" valid:"required~please provide the stream name"`
+	ConsumerGroup string `json:"consumer_group" bson:"consumer_group" valid:"required~please provide the consumer group"`
+}
+
+// MarshalJSON masks the dsn, it carries the redis credentials.
+func (r RedisStreamsPubSubConfig) MarshalJSON() ([]byte, error) {
+	type redisStreamsPubSubConfig RedisStreamsPubSubConfig
+
+	c := redisStreamsPubSubConfig(r)
+	if len(c.DSN) > 0 {
+		c.DSN = MaskedSecret
+	}
+
+	return json.Marshal(c)
+}
+
+type AMQPPubSubConfig struct {
+	DSN   string `json:"dsn" bson:"dsn" valid:"required~please provide the amqp dsn"`
+	Queue string `json:"queue" bson:"queue" valid:"required~please provide the queue name"`
+
+	// Exchange and RoutingKey bind the queue to an exchange when set.
+	Exchange   string `json:"exchange,omitempty" bson:"exchange,omitempty"`
+	RoutingKey string `json:"routing_key,omitempty" bson:"routing_key,omitempty"`
+}
+
+// MarshalJSON masks the dsn, it carries the broker credentials.
+func (a AMQPPubSubConfig) MarshalJSON() ([]byte, error) {
+	type amqpPubSubConfig AMQPPubSubConfig
+
+	c := amqpPubSubConfig(a)
+	if len(c.DSN) > 0 {
+		c.DSN = MaskedSecret
+	}
+
+	return json.Marshal(c)
+}
+
+// ChangeStreamConfig is the collection a db_change_stream source tails, the
+// inserts, updates and deletes of its documents become events with types
+// like users.created, users.updated and users.deleted.
+type ChangeStreamConfig struct {
+	Type       ChangeStreamType `json:"type" bson:"type" valid:"required~please provide a change stream type,supported_change_stream~unsupported change stream type"`
+	DSN        string           `json:"dsn" bson:"dsn" valid:"required~please provide the database dsn"`
+	Database   string           `json:"database" bson:"database" valid:"required~please provide the database name"`
+	Collection string           `json:"collection" bson:"collection" valid:"required~please provide the collection name"`
+}
+
+// MarshalJSON masks the dsn, it carries the database credentials.
+func (c ChangeStreamConfig) MarshalJSON() ([]byte, error) {
+	type changeStreamConfig ChangeStreamConfig
+
+	cs := changeStreamConfig(c)
+	if len(cs.DSN) > 0 {
+		cs.DSN = MaskedSecret
+	}
+
+	return json.Marshal(cs)
+}
+
+// RestApiConfig is the http api a rest_api source polls, every new record
+// of the response becomes an event.
+type RestApiConfig struct {
+	URL     string            `json:"url" bson:"url" valid:"required~please provide the url,url~please provide a valid url"`
+	Headers map[string]string `json:"headers,omitempty" bson:"headers,omitempty"`
+
+	// Schedule is a cron spec such as */5 * * * * or @every 10m.
+	Schedule string `json:"schedule" bson:"schedule" valid:"required~please provide a schedule"`
+
+	// RecordsPath is the path of the records array in the
+	// response e.g. $.data, $ when the response is the array.
+	RecordsPath string `json:"records_path" bson:"records_path" valid:"required~please provide the records path"`
+
+	// IDField is the path of a record's id, records
+	// already emitted by the source are skipped.
+	IDField string `json:"id_field" bson:"id_field" valid:"required~please provide the id field"`
+
+	// EventType is the type of the events, it defaults to the source's mask id.
+	EventType string `json:"event_type,omitempty" bson:"event_type,omitempty"`
+
+	Pagination *RestApiPagination `json:"pagination,omitempty" bson:"pagination,omitempty"`
+}
+
+// MarshalJSON masks the header values, they usually carry the api's
+// credentials.
+func (r RestApiConfig) MarshalJSON() ([]byte, error) {
+	type restApiConfig RestApiConfig
+
+	c := restApiConfig(r)
+	if len(c.Headers) > 0 {
+		c.Headers = make(map[string]string, len(r.Headers))
+		for k := range r.Headers {
+			c.Headers[k] = MaskedSecret
+		}
+	}
+
+	return json.Marshal(c)
+}
+
+// NextPollAt returns when the source should be polled after last.
+func (r *RestApiConfig) NextPollAt(last time.Time) (time.Time, error) {
+	schedule, err := cron.ParseStandard(r.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return schedule.Next(last), nil
+}
+
+// RestApiPagination follows the pages of the response, the cursor found at
+// NextPath is sent as the CursorParam query parameter or, when CursorParam
+// is empty, it is the url of the next page.
+type RestApiPagination struct {
+	NextPath    string `json:"next_path" bson:"next_path" valid:"required~please provide the next page path"`
+	CursorParam string `json:"cursor_param,omitempty" bson:"cursor_param,omitempty"`
+	MaxPages    int    `json:"max_pages,omitempty" bson:"max_pages,omitempty"`
+}
+
+// RestApiCursor is the polling state of a rest_api source.
+type RestApiCursor struct {
+	// Cursor is the page the next poll starts from.
+	Cursor   string             `json:"cursor,omitempty" bson:"cursor,omitempty"`
+	SeenIDs  []string           `json:"-" bson:"seen_ids,omitempty"`
+	PolledAt primitive.DateTime `json:"polled_at,omitempty" bson:"polled_at,omitempty" swaggertype:"string"`
+}
+
 type User struct {
 	ID                     primitive.ObjectID `json:"-" bson:"_id"`
 	UID                    string             `json:"uid" bson:"uid"`
@@ -607,6 +1213,40 @@ type RetryConfiguration struct {
 	Type       config.StrategyProvider `json:"type,omitempty" bson:"type,omitempty" valid:"supported_retry_strategy~please provide a valid retry strategy type"`
 	Duration   string                  `json:"duration,omitempty" bson:"duration,omitempty" valid:"duration~please provide a valid time duration"`
 	RetryCount int                     `json:"retry_count" bson:"retry_count" valid:"int~please provide a valid retry count"`
+
+	Multiplier  float64    `json:"multiplier,omitempty" bson:"multiplier,omitempty"`
+	MaxInterval string     `json:"max_interval,omitempty" bson:"max_interval,omitempty" valid:"duration~please provide a valid max interval"`
+	Jitter      JitterMode `json:"jitter,omitempty" bson:"jitter,omitempty" valid:"supported_jitter~unsupported jitter mode"`
+
+	// NonRetryableStatusCodes are response status codes that fail
+	// a delivery right away instead of retrying it.
+	NonRetryableStatusCodes []int `json:"non_retryable_status_codes,omitempty" bson:"non_retryable_status_codes,omitempty"`
+}
+
+// ValidateStatusCodes checks that the non-retryable status codes are non 2xx http status codes.
+func (r *RetryConfiguration) ValidateStatusCodes() error {
+	for _, code := range r.NonRetryableStatusCodes {
+		if code < 300 || code > 599 {
+			return ErrInvalidNonRetryableStatusCode
+		}
+	}
+
+	return nil
+}
+
+// IsNonRetryable reports whether a delivery that got statusCode should fail without being retried.
+func (r *RetryConfiguration) IsNonRetryable(statusCode int) bool {
+	if r == nil {
+		return false
+	}
+
+	for _, code := range r.NonRetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
 }
 
 type AlertConfiguration struct {
@@ -615,7 +1255,60 @@ type AlertConfiguration struct {
 }
 
 type FilterConfiguration struct {
-	EventTypes []string `json:"event_types" bson:"event_types,omitempty"`
+	EventTypes []string     `json:"event_types" bson:"event_types,omitempty"`
+	Filter     FilterSchema `json:"filter" bson:"filter"`
+}
+
+// FilterSchema holds the payload filters of a subscription, see
+// pkg/filter for the filter syntax.
+type FilterSchema struct {
+	Headers map[string]interface{} `json:"headers" bson:"headers"`
+	Body    map[string]interface{} `json:"body" bson:"body"`
+}
+
+// Filters builds the header and body filters described by this schema.
+// Header names are canonicalized so they match regardless of case, also
+// when nested in $and or $or.
+func (f *FilterSchema) Filters() (headers *filter.Filter, body *filter.Filter, err error) {
+	headers, err = filter.NewWithKeys(f.Headers, http.CanonicalHeaderKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err = filter.New(f.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return headers, body, nil
+}
+
+type TransformConfiguration struct {
+	Type     TransformType  `json:"type" bson:"type" valid:"required~please provide a transform type,supported_transform~unsupported transform type"`
+	Mapping  []FieldMapping `json:"mapping,omitempty" bson:"mapping,omitempty"`
+	Template string         `json:"template,omitempty" bson:"template,omitempty"`
+}
+
+type FieldMapping struct {
+	From string `json:"from" bson:"from"`
+	To   string `json:"to" bson:"to"`
+}
+
+// Transformer builds the payload transformer described by this configuration.
+func (t *TransformConfiguration) Transformer() (transform.Transformer, error) {
+	fields := make([]transform.Field, 0, len(t.Mapping))
+	for _, m := range t.Mapping {
+		fields = append(fields, transform.Field{From: m.From, To: m.To})
+	}
+
+	switch t.Type {
+	case MappingTransform:
+		return transform.NewMapper(fields)
+	case TemplateTransform:
+		return transform.NewTemplate(t.Template)
+	default:
+		return nil, transform.ErrUnsupportedTransform
+	}
 }
 
 type ProviderConfig struct {
@@ -638,6 +1331,28 @@ type HMac struct {
 	Hash     string       `json:"hash" bson:"hash" valid:"supported_hash,required"`
 	Secret   string       `json:"secret" bson:"secret" valid:"required"`
 	Encoding EncodingType `json:"encoding" bson:"encoding" valid:"supported_encoding~please provide a valid encoding type,required"`
+
+	// TimestampHeader is the header holding the unix timestamp of the request,
+	// when it is set the signed content is "timestamp,payload" like convoy's
+	// own signatures, and requests outside the tolerance window or with a
+	// signature seen before are rejected.
+	TimestampHeader string `json:"timestamp_header,omitempty" bson:"timestamp_header,omitempty"`
+	Tolerance       string `json:"tolerance,omitempty" bson:"tolerance,omitempty" valid:"duration~please provide a valid tolerance"`
+}
+
+// ToleranceDuration returns how old a request's timestamp can be, it falls
+// back to the verifier's default tolerance when it is missing or invalid.
+func (h *HMac) ToleranceDuration() time.Duration {
+	if h == nil || strings.TrimSpace(h.Tolerance) == "" {
+		return verifier.DefaultTolerance
+	}
+
+	d, err := time.ParseDuration(h.Tolerance)
+	if err != nil || d <= 0 {
+		return verifier.DefaultTolerance
+	}
+
+	return d
 }
 
 type BasicAuth struct {
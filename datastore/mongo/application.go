@@ -242,11 +242,45 @@ func (db *appRepo) UpdateApplication(ctx context.Context, app *datastore.Applica
 		"title":         app.Title,
 		"support_email": app.SupportEmail,
 		"is_disabled":   app.IsDisabled,
+		"owner_id":      app.OwnerID,
+		"tags":          app.Tags,
 	}
 
 	return db.store.UpdateByID(ctx, app.UID, update)
 }
 
+func (db *appRepo) FindApplicationsByOwnerID(ctx context.Context, groupID string, ownerID string) ([]datastore.Application, error) {
+	filter := bson.M{
+		"group_id":        groupID,
+		"owner_id":        ownerID,
+		"document_status": datastore.ActiveDocumentStatus,
+	}
+
+	apps := make([]datastore.Application, 0)
+	err := db.store.FindMany(ctx, filter, nil, nil, 0, 0, &apps)
+	if err != nil {
+		return apps, err
+	}
+
+	return apps, nil
+}
+
+func (db *appRepo) FindApplicationsByTags(ctx context.Context, groupID string, tags []string) ([]datastore.Application, error) {
+	filter := bson.M{
+		"group_id":        groupID,
+		"tags":            bson.M{"$in": tags},
+		"document_status": datastore.ActiveDocumentStatus,
+	}
+
+	apps := make([]datastore.Application, 0)
+	err := db.store.FindMany(ctx, filter, nil, nil, 0, 0, &apps)
+	if err != nil {
+		return apps, err
+	}
+
+	return apps, nil
+}
+
 func (db *appRepo) CreateApplicationEndpoint(ctx context.Context, groupID string, appID string, endpoint *datastore.Endpoint) error {
 	filter := bson.M{"uid": appID, "document_status": datastore.ActiveDocumentStatus}
 	update := bson.M{
@@ -0,0 +1,116 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	pager "github.com/gobeam/mongo-go-pagination"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type deadLetterRepo struct {
+	inner *mongo.Collection
+	store datastore.Store
+}
+
+func NewDeadLetterRepo(db *mongo.Database, store datastore.Store) datastore.DeadLetterRepository {
+	return &deadLetterRepo{
+		inner: db.Collection(DeadLetterCollection),
+		store: store,
+	}
+}
+
+func (d *deadLetterRepo) CreateDeadLetter(ctx context.Context, deadLetter *datastore.DeadLetter) error {
+	deadLetter.ID = primitive.NewObjectID()
+	if util.IsStringEmpty(deadLetter.UID) {
+		deadLetter.UID = uuid.NewString()
+	}
+
+	return d.store.Save(ctx, deadLetter, nil)
+}
+
+func (d *deadLetterRepo) FindDeadLetterByID(ctx context.Context, groupID string, id string) (*datastore.DeadLetter, error) {
+	deadLetter := &datastore.DeadLetter{}
+
+	filter := bson.M{"uid": id, "group_id": groupID}
+	err := d.store.FindOne(ctx, filter, nil, deadLetter)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrDeadLetterNotFound
+	}
+
+	return deadLetter, err
+}
+
+func (d *deadLetterRepo) FindDeadLettersByIDs(ctx context.Context, groupID string, ids []string) ([]datastore.DeadLetter, error) {
+	filter := bson.M{"uid": bson.M{"$in": ids}, "group_id": groupID}
+
+	deadLetters := make([]datastore.DeadLetter, 0)
+	err := d.store.FindMany(ctx, filter, nil, nil, 0, 0, &deadLetters)
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+func (d *deadLetterRepo) FindDeadLetterByEventDeliveryID(ctx context.Context, eventDeliveryID string) (*datastore.DeadLetter, error) {
+	deadLetter := &datastore.DeadLetter{}
+
+	filter := bson.M{"event_delivery_id": eventDeliveryID}
+	err := d.store.FindOne(ctx, filter, nil, deadLetter)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrDeadLetterNotFound
+	}
+
+	return deadLetter, err
+}
+
+func (d *deadLetterRepo) LoadDeadLettersPaged(ctx context.Context, f *datastore.DeadLetterFilter, pageable datastore.Pageable) ([]datastore.DeadLetter, datastore.PaginationData, error) {
+	filter := bson.M{
+		"group_id":        f.GroupID,
+		"document_status": datastore.ActiveDocumentStatus,
+		"created_at":      getCreatedDateFilter(f.SearchParams),
+	}
+
+	if !util.IsStringEmpty(f.AppID) {
+		filter["app_id"] = f.AppID
+	}
+
+	if !util.IsStringEmpty(f.EventID) {
+		filter["event_id"] = f.EventID
+	}
+
+	if !util.IsStringEmpty(f.SubscriptionID) {
+		filter["subscription_id"] = f.SubscriptionID
+	}
+
+	var deadLetters []datastore.DeadLetter
+	paginatedData, err := pager.
+		New(d.inner).
+		Context(ctx).
+		Limit(int64(pageable.PerPage)).
+		Page(int64(pageable.Page)).
+		Sort("created_at", -1).
+		Filter(filter).
+		Decode(&deadLetters).
+		Find()
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	if deadLetters == nil {
+		deadLetters = make([]datastore.DeadLetter, 0)
+	}
+
+	return deadLetters, datastore.PaginationData(paginatedData.Pagination), nil
+}
+
+func (d *deadLetterRepo) DeleteDeadLetters(ctx context.Context, groupID string, ids []string) error {
+	filter := bson.M{"uid": bson.M{"$in": ids}, "group_id": groupID}
+	return d.store.DeleteMany(ctx, filter, nil, true)
+}
@@ -13,6 +13,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type eventDeliveryRepo struct {
@@ -193,6 +194,9 @@ func (db *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context,
 		"$push": bson.M{
 			"attempts": attempt,
 		},
+		"$unset": bson.M{
+			"blocked_by": "",
+		},
 	}
 
 	_, err := db.inner.UpdateOne(ctx, filter, update)
@@ -320,3 +324,100 @@ func (db *eventDeliveryRepo) FindDiscardedEventDeliveries(ctx context.Context, a
 
 	return deliveries, nil
 }
+
+// pendingEventStatuses are the statuses of deliveries that are still
+// waiting to be sent, the oldest of them is the head of its ordering queue.
+var pendingEventStatuses = []datastore.EventDeliveryStatus{
+	datastore.ScheduledEventStatus,
+	datastore.ProcessingEventStatus,
+	datastore.RetryEventStatus,
+	datastore.CircuitOpenEventStatus,
+	datastore.BlockedEventStatus,
+}
+
+func (db *eventDeliveryRepo) FindOrderingHead(ctx context.Context, orderingKey string) (*datastore.EventDelivery, error) {
+	e := new(datastore.EventDelivery)
+
+	// deliveries left in processing by a crashed worker don't hold back the
+	// rest of the queue.
+	staleAt := primitive.NewDateTimeFromTime(time.Now().Add(-datastore.ProcessingTimeout))
+
+	filter := bson.M{
+		"ordering_key":    orderingKey,
+		"status":          bson.M{"$in": pendingEventStatuses},
+		"document_status": datastore.ActiveDocumentStatus,
+		"$or": bson.A{
+			bson.M{"status": bson.M{"$ne": datastore.ProcessingEventStatus}},
+			bson.M{"updated_at": bson.M{"$gt": staleAt}},
+		},
+	}
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	err := db.inner.FindOne(ctx, filter, opts).Decode(&e)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		err = datastore.ErrEventDeliveryNotFound
+	}
+
+	return e, err
+}
+
+func (db *eventDeliveryRepo) BlockEventDelivery(ctx context.Context, e datastore.EventDelivery, headID string) error {
+	filter := bson.M{"uid": e.UID}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     datastore.BlockedEventStatus,
+			"blocked_by": headID,
+			"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+		},
+	}
+
+	_, err := db.inner.UpdateOne(ctx, filter, update)
+	if err != nil {
+		log.WithError(err).Errorf("error blocking event delivery %s", e.UID)
+		return err
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) LoadOrderingQueues(ctx context.Context, subscriptionID string) ([]datastore.OrderingQueue, error) {
+	matchStage := bson.D{{Key: "$match", Value: bson.D{
+		{Key: "subscription_id", Value: subscriptionID},
+		{Key: "ordering_key", Value: bson.M{"$exists": true, "$ne": ""}},
+		{Key: "status", Value: bson.M{"$in": pendingEventStatuses}},
+		{Key: "document_status", Value: datastore.ActiveDocumentStatus},
+	}}}
+
+	sortStage := bson.D{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}}}
+
+	groupStage := bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: "$ordering_key"},
+		{Key: "head_id", Value: bson.M{"$first": "$uid"}},
+		{Key: "head_status", Value: bson.M{"$first": "$status"}},
+		{Key: "head_created_at", Value: bson.M{"$first": "$created_at"}},
+		{Key: "count", Value: bson.M{"$sum": 1}},
+	}}}
+
+	projectStage := bson.D{{Key: "$project", Value: bson.D{
+		{Key: "head_id", Value: 1},
+		{Key: "head_status", Value: 1},
+		{Key: "head_created_at", Value: 1},
+		{Key: "blocked", Value: bson.M{"$subtract": bson.A{"$count", 1}}},
+	}}}
+
+	orderSortStage := bson.D{{Key: "$sort", Value: bson.D{{Key: "head_created_at", Value: 1}}}}
+
+	var queues []datastore.OrderingQueue
+	err := db.store.Aggregate(ctx, mongo.Pipeline{matchStage, sortStage, groupStage, projectStage, orderSortStage}, &queues, false)
+	if err != nil {
+		log.WithError(err).Error("failed to load ordering queues")
+		return nil, err
+	}
+
+	if queues == nil {
+		queues = make([]datastore.OrderingQueue, 0)
+	}
+
+	return queues, nil
+}
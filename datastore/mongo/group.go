@@ -77,6 +77,7 @@ func (db *groupRepo) UpdateGroup(ctx context.Context, o *datastore.Group) error
 		primitive.E{Key: "rate_limit", Value: o.RateLimit},
 		primitive.E{Key: "metadata", Value: o.Metadata},
 		primitive.E{Key: "rate_limit_duration", Value: o.RateLimitDuration},
+		primitive.E{Key: "signing_keys", Value: o.SigningKeys},
 	}
 
 	err := db.store.UpdateByID(ctx, o.UID, update)
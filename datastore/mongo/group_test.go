@@ -35,6 +35,33 @@ func Test_FetchGroupByID(t *testing.T) {
 	require.Equal(t, org.UID, newOrg.UID)
 }
 
+func Test_UpdateGroup(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	store := getStore(db, GroupCollection)
+
+	groupRepo := NewGroupRepo(db, store)
+
+	group := &datastore.Group{
+		Name:           "Yet another group",
+		UID:            uuid.NewString(),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	require.NoError(t, groupRepo.CreateGroup(context.Background(), group))
+
+	group.Name = "Updated group"
+	group.SigningKeys = []datastore.SigningKey{{UID: uuid.NewString(), Algorithm: datastore.Ed25519SigningAlgorithm, PrivateKey: "private-key"}}
+	require.NoError(t, groupRepo.UpdateGroup(context.Background(), group))
+
+	g, err := groupRepo.FetchGroupByID(context.Background(), group.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, "Updated group", g.Name)
+	require.Equal(t, group.SigningKeys, g.SigningKeys)
+}
+
 func Test_CreateGroup(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
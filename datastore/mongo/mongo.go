@@ -28,6 +28,7 @@ const (
 	SourceCollection              = "sources"
 	UserCollection                = "users"
 	SubscriptionCollection        = "subscriptions"
+	DeadLetterCollection          = "deadletters"
 )
 
 type Client struct {
@@ -45,6 +46,7 @@ type Client struct {
 	userRepo          datastore.UserRepository
 	deviceRepo        datastore.DeviceRepository
 	configRepo        datastore.ConfigurationRepository
+	deadLetterRepo    datastore.DeadLetterRepository
 }
 
 func New(cfg config.Configuration) (*Client, error) {
@@ -86,6 +88,7 @@ func New(cfg config.Configuration) (*Client, error) {
 	config := datastore.New(conn, ConfigCollection)
 	devices := datastore.New(conn, DeviceCollection)
 	event_delivery := datastore.New(conn, EventDeliveryCollection)
+	dead_letters := datastore.New(conn, DeadLetterCollection)
 
 	c := &Client{
 		db:                conn,
@@ -102,6 +105,7 @@ func New(cfg config.Configuration) (*Client, error) {
 		orgInviteRepo:     NewOrgInviteRepo(conn, org_invite),
 		userRepo:          NewUserRepo(conn, users),
 		configRepo:        NewConfigRepo(conn, config),
+		deadLetterRepo:    NewDeadLetterRepo(conn, dead_letters),
 	}
 
 	c.ensureMongoIndices()
@@ -173,6 +177,10 @@ func (c *Client) ConfigurationRepo() datastore.ConfigurationRepository {
 	return c.configRepo
 }
 
+func (c *Client) DeadLetterRepo() datastore.DeadLetterRepository {
+	return c.deadLetterRepo
+}
+
 func (c *Client) ensureMongoIndices() {
 	c.ensureIndex(GroupCollection, "uid", true, nil)
 
@@ -188,16 +196,21 @@ func (c *Client) ensureMongoIndices() {
 	c.ensureIndex(AppCollection, "group_id", false, nil)
 	c.ensureIndex(UserCollection, "uid", true, nil)
 	c.ensureIndex(AppCollection, "uid", true, nil)
+	c.ensureIndex(AppCollection, "owner_id", false, nil)
+	c.ensureIndex(AppCollection, "tags", false, nil)
 
 	c.ensureIndex(EventCollection, "uid", true, nil)
 	c.ensureIndex(EventCollection, "app_id", false, nil)
 	c.ensureIndex(EventCollection, "group_id", false, nil)
 	c.ensureIndex(AppCollection, "group_id", false, nil)
 	c.ensureIndex(EventDeliveryCollection, "status", false, nil)
+	c.ensureIndex(EventDeliveryCollection, "ordering_key", false, nil)
 	c.ensureIndex(SourceCollection, "uid", true, nil)
 	c.ensureIndex(SourceCollection, "mask_id", true, nil)
 	c.ensureIndex(SubscriptionCollection, "uid", true, nil)
 	c.ensureIndex(SubscriptionCollection, "filter_config.event_type", false, nil)
+	c.ensureIndex(DeadLetterCollection, "uid", true, nil)
+	c.ensureIndex(DeadLetterCollection, "event_delivery_id", false, nil)
 	c.ensureCompoundIndex(AppCollection)
 	c.ensureCompoundIndex(EventCollection)
 	c.ensureCompoundIndex(UserCollection)
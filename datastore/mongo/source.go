@@ -44,6 +44,12 @@ func (s *sourceRepo) UpdateSource(ctx context.Context, groupId string, source *d
 		primitive.E{Key: "verifier", Value: source.Verifier},
 		primitive.E{Key: "updated_at", Value: primitive.NewDateTimeFromTime(time.Now())},
 		primitive.E{Key: "provider_config", Value: source.ProviderConfig},
+		primitive.E{Key: "idempotency_config", Value: source.IdempotencyConfig},
+		primitive.E{Key: "pub_sub", Value: source.PubSub},
+		primitive.E{Key: "change_stream", Value: source.ChangeStream},
+		primitive.E{Key: "resume_token", Value: source.ResumeToken},
+		primitive.E{Key: "rest_api", Value: source.RestApi},
+		primitive.E{Key: "rest_api_cursor", Value: source.RestApiCursor},
 	}
 
 	err := s.store.UpdateOne(ctx, filter, update)
@@ -88,6 +94,24 @@ func (s *sourceRepo) DeleteSourceByID(ctx context.Context, groupId string, id st
 	return err
 }
 
+func (s *sourceRepo) UpdateSourceResumeToken(ctx context.Context, groupId string, id string, token string) error {
+	filter := bson.M{"uid": id, "group_id": groupId, "document_status": datastore.ActiveDocumentStatus}
+
+	update := bson.M{"resume_token": token}
+
+	err := s.store.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (s *sourceRepo) UpdateSourceRestApiCursor(ctx context.Context, groupId string, id string, cursor *datastore.RestApiCursor) error {
+	filter := bson.M{"uid": id, "group_id": groupId, "document_status": datastore.ActiveDocumentStatus}
+
+	update := bson.M{"rest_api_cursor": cursor}
+
+	err := s.store.UpdateOne(ctx, filter, update)
+	return err
+}
+
 func (s *sourceRepo) LoadSourcesPaged(ctx context.Context, groupID string, f *datastore.SourceFilter, pageable datastore.Pageable) ([]datastore.Source, datastore.PaginationData, error) {
 	var sources []datastore.Source
 
@@ -96,6 +96,44 @@ func Test_UpdateSource(t *testing.T) {
 	require.Equal(t, name, newSource.Name)
 }
 
+func Test_UpdateSourceResumeToken(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	store := getStore(db, SourceCollection)
+	sourceRepo := NewSourceRepo(db, store)
+	source := generateSource(t)
+
+	require.NoError(t, sourceRepo.CreateSource(context.Background(), source))
+	require.NoError(t, sourceRepo.UpdateSourceResumeToken(context.Background(), source.GroupID, source.UID, "token"))
+
+	newSource, err := sourceRepo.FindSourceByID(context.Background(), source.GroupID, source.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, "token", newSource.ResumeToken)
+	require.Equal(t, source.Name, newSource.Name)
+}
+
+func Test_UpdateSourceRestApiCursor(t *testing.T) {
+	db, closeFn := getDB(t)
+	defer closeFn()
+
+	store := getStore(db, SourceCollection)
+	sourceRepo := NewSourceRepo(db, store)
+	source := generateSource(t)
+
+	require.NoError(t, sourceRepo.CreateSource(context.Background(), source))
+
+	cursor := &datastore.RestApiCursor{Cursor: "page-2", SeenIDs: []string{"1", "2"}}
+	require.NoError(t, sourceRepo.UpdateSourceRestApiCursor(context.Background(), source.GroupID, source.UID, cursor))
+
+	newSource, err := sourceRepo.FindSourceByID(context.Background(), source.GroupID, source.UID)
+	require.NoError(t, err)
+
+	require.Equal(t, "page-2", newSource.RestApiCursor.Cursor)
+	require.Equal(t, []string{"1", "2"}, newSource.RestApiCursor.SeenIDs)
+}
+
 func Test_DeleteSource(t *testing.T) {
 	db, closeFn := getDB(t)
 	defer closeFn()
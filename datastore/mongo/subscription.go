@@ -52,13 +52,19 @@ func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, groupId strin
 		"source_id":   subscription.SourceID,
 		"endpoint_id": subscription.EndpointID,
 
+		"delivery_mode": subscription.DeliveryMode,
+		"ordering_key":  subscription.OrderingKey,
+
 		"filter_config.event_types": subscription.FilterConfig.EventTypes,
 		"alert_config.count":        subscription.AlertConfig.Count,
 		"alert_config.threshold":    subscription.AlertConfig.Threshold,
 
-		"retry_config.type":        string(subscription.RetryConfig.Type),
-		"retry_config.duration":    subscription.RetryConfig.Duration,
-		"retry_config.retry_count": subscription.RetryConfig.RetryCount,
+		"retry_config": subscription.RetryConfig,
+
+		"filter_config.filter.headers": subscription.FilterConfig.Filter.Headers,
+		"filter_config.filter.body":    subscription.FilterConfig.Filter.Body,
+
+		"transform_config": subscription.TransformConfig,
 	}
 
 	err := s.store.UpdateOne(ctx, filter, update)
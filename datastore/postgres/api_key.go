@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type apiKeyRepo struct {
+	store *store
+}
+
+func NewApiKeyRepo(db *sql.DB) datastore.APIKeyRepository {
+	return &apiKeyRepo{store: newStore(db, APIKeyTable)}
+}
+
+func (db *apiKeyRepo) CreateAPIKey(ctx context.Context, apiKey *datastore.APIKey) error {
+	apiKey.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(apiKey.UID) {
+		apiKey.UID = uuid.New().String()
+	}
+
+	return db.store.insert(ctx, apiKey.UID, apiKey.CreatedAt, apiKey)
+}
+
+func (db *apiKeyRepo) UpdateAPIKey(ctx context.Context, apiKey *datastore.APIKey) error {
+	return db.store.update(ctx, newQuery().uid(apiKey.UID), apiKey)
+}
+
+func (db *apiKeyRepo) FindAPIKeyByID(ctx context.Context, uid string) (*datastore.APIKey, error) {
+	return db.findAPIKey(ctx, newQuery().uid(uid).active())
+}
+
+func (db *apiKeyRepo) FindAPIKeyByMaskID(ctx context.Context, maskID string) (*datastore.APIKey, error) {
+	return db.findAPIKey(ctx, newQuery().eq("mask_id", maskID).active())
+}
+
+func (db *apiKeyRepo) FindAPIKeyByHash(ctx context.Context, hash string) (*datastore.APIKey, error) {
+	return db.findAPIKey(ctx, newQuery().eq("hash", hash).active())
+}
+
+func (db *apiKeyRepo) findAPIKey(ctx context.Context, q *query) (*datastore.APIKey, error) {
+	apiKey := &datastore.APIKey{}
+
+	err := db.store.findOne(ctx, q, "", apiKey)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrAPIKeyNotFound
+	}
+
+	return apiKey, err
+}
+
+func (db *apiKeyRepo) RevokeAPIKeys(ctx context.Context, uids []string) error {
+	update := bson.M{
+		"deleted_at":      primitive.NewDateTimeFromTime(time.Now()),
+		"document_status": datastore.DeletedDocumentStatus,
+	}
+
+	return db.store.update(ctx, newQuery().uids(uids), update)
+}
+
+func (db *apiKeyRepo) LoadAPIKeysPaged(ctx context.Context, f *datastore.ApiKeyFilter, pageable *datastore.Pageable) ([]datastore.APIKey, datastore.PaginationData, error) {
+	q := newQuery().active()
+
+	if !util.IsStringEmpty(f.GroupID) {
+		q.eq("role.group", f.GroupID)
+	}
+
+	if !util.IsStringEmpty(f.AppID) {
+		q.eq("role.app", f.AppID)
+	}
+
+	if !util.IsStringEmpty(string(f.KeyType)) {
+		q.eq("key_type", f.KeyType)
+	}
+
+	var apiKeys []datastore.APIKey
+	paginationData, err := db.store.paginate(ctx, q, sortOrder(pageable.Sort), *pageable, &apiKeys)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return apiKeys, paginationData, nil
+}
@@ -0,0 +1,276 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type appRepo struct {
+	db     *sql.DB
+	store  *store
+	events *store
+}
+
+func NewApplicationRepo(db *sql.DB) datastore.ApplicationRepository {
+	return &appRepo{
+		db:     db,
+		store:  newStore(db, AppTable),
+		events: newStore(db, EventTable),
+	}
+}
+
+func (db *appRepo) CreateApplication(ctx context.Context, app *datastore.Application, groupID string) error {
+	err := db.assertUniqueAppTitle(ctx, app, groupID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateAppName) {
+			return err
+		}
+
+		return fmt.Errorf("failed to check if application name is unique: %v", err)
+	}
+
+	app.ID = primitive.NewObjectID()
+	return db.store.insert(ctx, app.UID, app.CreatedAt, app)
+}
+
+func (db *appRepo) LoadApplicationsPaged(ctx context.Context, groupID, q string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+	query := newQuery().active()
+	if !util.IsStringEmpty(groupID) || !util.IsStringEmpty(q) {
+		query.eq("group_id", groupID)
+	}
+
+	if !util.IsStringEmpty(q) {
+		query.cond(field("title")+" ~* %s", q)
+	}
+
+	var apps []datastore.Application
+	paginationData, err := db.store.paginate(ctx, query, "created_at DESC, id DESC", pageable, &apps)
+	if err != nil {
+		return apps, datastore.PaginationData{}, err
+	}
+
+	err = db.fillEventCounts(ctx, apps)
+	if err != nil {
+		return apps, datastore.PaginationData{}, err
+	}
+
+	return apps, paginationData, nil
+}
+
+func (db *appRepo) assertUniqueAppTitle(ctx context.Context, app *datastore.Application, groupID string) error {
+	q := newQuery().
+		cond("uid <> %s", app.UID).
+		eq("title", app.Title).
+		eq("group_id", groupID).
+		active()
+
+	count, err := db.store.count(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	if count != 0 {
+		return datastore.ErrDuplicateAppName
+	}
+
+	return nil
+}
+
+func (db *appRepo) LoadApplicationsPagedByGroupId(ctx context.Context, groupID string, pageable datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
+	var applications []datastore.Application
+	paginationData, err := db.store.paginate(ctx, newQuery().eq("group_id", groupID).active(), "created_at DESC, id DESC", pageable, &applications)
+	if err != nil {
+		return applications, datastore.PaginationData{}, err
+	}
+
+	err = db.fillEventCounts(ctx, applications)
+	if err != nil {
+		return applications, datastore.PaginationData{}, err
+	}
+
+	return applications, paginationData, nil
+}
+
+func (db *appRepo) CountGroupApplications(ctx context.Context, groupID string) (int64, error) {
+	count, err := db.store.count(ctx, newQuery().eq("group_id", groupID).active())
+	if err != nil {
+		log.WithError(err).Errorf("failed to count apps in group %s", groupID)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (db *appRepo) SearchApplicationsByGroupId(ctx context.Context, groupId string, searchParams datastore.SearchParams) ([]datastore.Application, error) {
+	start := searchParams.CreatedAtStart
+	end := searchParams.CreatedAtEnd
+	if end == 0 || end < searchParams.CreatedAtStart {
+		end = searchParams.CreatedAtStart
+	}
+
+	q := newQuery().eq("group_id", groupId).active().createdBetween(start, end)
+
+	apps := make([]datastore.Application, 0)
+	err := db.store.findMany(ctx, q, "", &apps)
+	if err != nil {
+		return apps, err
+	}
+
+	err = db.fillEventCounts(ctx, apps)
+	return apps, err
+}
+
+func (db *appRepo) FindApplicationByID(ctx context.Context, id string) (*datastore.Application, error) {
+	app := new(datastore.Application)
+
+	err := db.store.findOne(ctx, newQuery().uid(id).active(), "", app)
+	if errors.Is(err, sql.ErrNoRows) {
+		return app, datastore.ErrApplicationNotFound
+	}
+
+	if err != nil {
+		return app, err
+	}
+
+	count, err := db.events.count(ctx, newQuery().eq("app_id", app.UID).active())
+	if err != nil {
+		log.WithError(err).Errorf("failed to count events in %s", app.UID)
+		return app, err
+	}
+	app.Events = count
+
+	return app, nil
+}
+
+func (db *appRepo) FindApplicationEndpointByID(ctx context.Context, appID string, endpointID string) (*datastore.Endpoint, error) {
+	app, err := db.FindApplicationByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, endpoint := range app.Endpoints {
+		if endpoint.UID == endpointID && endpoint.DeletedAt == 0 {
+			return &endpoint, nil
+		}
+	}
+
+	return nil, datastore.ErrEndpointNotFound
+}
+
+func (db *appRepo) UpdateApplication(ctx context.Context, app *datastore.Application, groupID string) error {
+	err := db.assertUniqueAppTitle(ctx, app, groupID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDuplicateAppName) {
+			return err
+		}
+
+		return fmt.Errorf("failed to check if application name is unique: %v", err)
+	}
+
+	app.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	update := bson.M{
+		"endpoints":     app.Endpoints,
+		"updated_at":    app.UpdatedAt,
+		"title":         app.Title,
+		"support_email": app.SupportEmail,
+		"is_disabled":   app.IsDisabled,
+		"owner_id":      app.OwnerID,
+		"tags":          app.Tags,
+	}
+
+	return db.store.update(ctx, newQuery().uid(app.UID), update)
+}
+
+func (db *appRepo) FindApplicationsByOwnerID(ctx context.Context, groupID string, ownerID string) ([]datastore.Application, error) {
+	q := newQuery().eq("group_id", groupID).eq("owner_id", ownerID).active()
+
+	apps := make([]datastore.Application, 0)
+	err := db.store.findMany(ctx, q, "", &apps)
+	if err != nil {
+		return apps, err
+	}
+
+	return apps, nil
+}
+
+func (db *appRepo) FindApplicationsByTags(ctx context.Context, groupID string, tags []string) ([]datastore.Application, error) {
+	q := newQuery().
+		eq("group_id", groupID).
+		cond("jsonb_exists_any(document->'tags', %s)", pq.Array(tags)).
+		active()
+
+	apps := make([]datastore.Application, 0)
+	err := db.store.findMany(ctx, q, "", &apps)
+	if err != nil {
+		return apps, err
+	}
+
+	return apps, nil
+}
+
+func (db *appRepo) CreateApplicationEndpoint(ctx context.Context, groupID string, appID string, endpoint *datastore.Endpoint) error {
+	set := bson.M{"updated_at": primitive.NewDateTimeFromTime(time.Now())}
+	return db.store.push(ctx, newQuery().uid(appID).active(), "endpoints", endpoint, set)
+}
+
+func (db *appRepo) DeleteGroupApps(ctx context.Context, groupID string) error {
+	return db.store.delete(ctx, newQuery().eq("group_id", groupID), false)
+}
+
+// DeleteApplication marks the app and its events as deleted in a single transaction.
+func (db *appRepo) DeleteApplication(ctx context.Context, app *datastore.Application) error {
+	update, err := encode(bson.M{
+		"deleted_at":      primitive.NewDateTimeFromTime(time.Now()),
+		"document_status": datastore.DeletedDocumentStatus,
+	})
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET document = document || $1::jsonb WHERE %s = $2", EventTable, field("app_id")),
+		update, app.UID)
+	if err != nil {
+		log.Errorf("failed to delete messages in %s. Reason: %s", app.UID, err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET document = document || $1::jsonb WHERE uid = $2", AppTable),
+		update, app.UID)
+	if err != nil {
+		log.Errorf("%s an error has occurred while deleting app - %s", app.UID, err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *appRepo) fillEventCounts(ctx context.Context, apps []datastore.Application) error {
+	for i, app := range apps {
+		count, err := db.events.count(ctx, newQuery().eq("app_id", app.UID).active())
+		if err != nil {
+			log.Errorf("failed to count events in %s. Reason: %s", app.UID, err)
+			return err
+		}
+		apps[i].Events = count
+	}
+
+	return nil
+}
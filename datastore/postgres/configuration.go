@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type configRepo struct {
+	store *store
+}
+
+func NewConfigRepo(db *sql.DB) datastore.ConfigurationRepository {
+	return &configRepo{store: newStore(db, ConfigTable)}
+}
+
+func (c *configRepo) CreateConfiguration(ctx context.Context, config *datastore.Configuration) error {
+	config.ID = primitive.NewObjectID()
+	return c.store.insert(ctx, config.UID, config.CreatedAt, config)
+}
+
+func (c *configRepo) LoadConfiguration(ctx context.Context) (*datastore.Configuration, error) {
+	config := &datastore.Configuration{}
+
+	err := c.store.findOne(ctx, newQuery().active(), "id ASC", config)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, datastore.ErrConfigNotFound
+	}
+
+	return config, err
+}
+
+func (c *configRepo) UpdateConfiguration(ctx context.Context, config *datastore.Configuration) error {
+	update := bson.M{
+		"is_analytics_enabled": config.IsAnalyticsEnabled,
+		"is_signup_enabled":    config.IsSignupEnabled,
+		"storage_policy":       config.StoragePolicy,
+		"updated_at":           primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	return c.store.update(ctx, newQuery().uid(config.UID), update)
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type deadLetterRepo struct {
+	store *store
+}
+
+func NewDeadLetterRepo(db *sql.DB) datastore.DeadLetterRepository {
+	return &deadLetterRepo{store: newStore(db, DeadLetterTable)}
+}
+
+func (d *deadLetterRepo) CreateDeadLetter(ctx context.Context, deadLetter *datastore.DeadLetter) error {
+	deadLetter.ID = primitive.NewObjectID()
+	if util.IsStringEmpty(deadLetter.UID) {
+		deadLetter.UID = uuid.NewString()
+	}
+
+	return d.store.insert(ctx, deadLetter.UID, deadLetter.CreatedAt, deadLetter)
+}
+
+func (d *deadLetterRepo) FindDeadLetterByID(ctx context.Context, groupID string, id string) (*datastore.DeadLetter, error) {
+	return d.findDeadLetter(ctx, newQuery().uid(id).eq("group_id", groupID).active())
+}
+
+func (d *deadLetterRepo) FindDeadLettersByIDs(ctx context.Context, groupID string, ids []string) ([]datastore.DeadLetter, error) {
+	deadLetters := make([]datastore.DeadLetter, 0)
+
+	err := d.store.findMany(ctx, newQuery().uids(ids).eq("group_id", groupID).active(), "", &deadLetters)
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+func (d *deadLetterRepo) FindDeadLetterByEventDeliveryID(ctx context.Context, eventDeliveryID string) (*datastore.DeadLetter, error) {
+	return d.findDeadLetter(ctx, newQuery().eq("event_delivery_id", eventDeliveryID).active())
+}
+
+func (d *deadLetterRepo) findDeadLetter(ctx context.Context, q *query) (*datastore.DeadLetter, error) {
+	deadLetter := &datastore.DeadLetter{}
+
+	err := d.store.findOne(ctx, q, "", deadLetter)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrDeadLetterNotFound
+	}
+
+	return deadLetter, err
+}
+
+func (d *deadLetterRepo) LoadDeadLettersPaged(ctx context.Context, f *datastore.DeadLetterFilter, pageable datastore.Pageable) ([]datastore.DeadLetter, datastore.PaginationData, error) {
+	q := newQuery().
+		eq("group_id", f.GroupID).
+		active().
+		createdBetween(f.SearchParams.CreatedAtStart, f.SearchParams.CreatedAtEnd)
+
+	if !util.IsStringEmpty(f.AppID) {
+		q.eq("app_id", f.AppID)
+	}
+
+	if !util.IsStringEmpty(f.EventID) {
+		q.eq("event_id", f.EventID)
+	}
+
+	if !util.IsStringEmpty(f.SubscriptionID) {
+		q.eq("subscription_id", f.SubscriptionID)
+	}
+
+	var deadLetters []datastore.DeadLetter
+	paginationData, err := d.store.paginate(ctx, q, "created_at DESC, id DESC", pageable, &deadLetters)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return deadLetters, paginationData, nil
+}
+
+func (d *deadLetterRepo) DeleteDeadLetters(ctx context.Context, groupID string, ids []string) error {
+	return d.store.delete(ctx, newQuery().uids(ids).eq("group_id", groupID), true)
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type deviceRepo struct {
+	store *store
+}
+
+func NewDeviceRepository(db *sql.DB) datastore.DeviceRepository {
+	return &deviceRepo{store: newStore(db, DeviceTable)}
+}
+
+func (d *deviceRepo) CreateDevice(ctx context.Context, device *datastore.Device) error {
+	device.ID = primitive.NewObjectID()
+	return d.store.insert(ctx, device.UID, device.CreatedAt, device)
+}
+
+func (d *deviceRepo) UpdateDevice(ctx context.Context, device *datastore.Device, appID, groupID string) error {
+	device.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	update := bson.M{
+		"status":       device.Status,
+		"host_name":    device.HostName,
+		"updated_at":   device.UpdatedAt,
+		"last_seen_at": device.LastSeenAt,
+	}
+
+	return d.store.update(ctx, deviceQuery(device.UID, appID, groupID), update)
+}
+
+func (d *deviceRepo) UpdateDeviceLastSeen(ctx context.Context, device *datastore.Device, appID, groupID string, status datastore.DeviceStatus) error {
+	device.Status = status
+	device.LastSeenAt = primitive.NewDateTimeFromTime(time.Now())
+	device.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	return d.store.update(ctx, deviceQuery(device.UID, appID, groupID), device)
+}
+
+func (d *deviceRepo) DeleteDevice(ctx context.Context, uid string, appID, groupID string) error {
+	return d.store.delete(ctx, deviceQuery(uid, appID, groupID), false)
+}
+
+func (d *deviceRepo) FetchDeviceByID(ctx context.Context, uid string, appID, groupID string) (*datastore.Device, error) {
+	return d.findDevice(ctx, deviceQuery(uid, appID, groupID))
+}
+
+func (d *deviceRepo) FetchDeviceByHostName(ctx context.Context, hostName string, appID, groupID string) (*datastore.Device, error) {
+	q := newQuery().eq("group_id", groupID).eq("host_name", hostName).active()
+	if !util.IsStringEmpty(appID) {
+		q.eq("app_id", appID)
+	}
+
+	return d.findDevice(ctx, q)
+}
+
+func (d *deviceRepo) findDevice(ctx context.Context, q *query) (*datastore.Device, error) {
+	device := &datastore.Device{}
+
+	err := d.store.findOne(ctx, q, "", device)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrDeviceNotFound
+		}
+
+		return nil, err
+	}
+
+	return device, nil
+}
+
+func (d *deviceRepo) LoadDevicesPaged(ctx context.Context, groupID string, f *datastore.ApiKeyFilter, pageable datastore.Pageable) ([]datastore.Device, datastore.PaginationData, error) {
+	q := newQuery().active().eq("group_id", groupID)
+	if !util.IsStringEmpty(f.AppID) {
+		q.eq("app_id", f.AppID)
+	}
+
+	var devices []datastore.Device
+	paginationData, err := d.store.paginate(ctx, q, sortOrder(pageable.Sort), pageable, &devices)
+	if err != nil {
+		return devices, datastore.PaginationData{}, err
+	}
+
+	return devices, paginationData, nil
+}
+
+// deviceQuery matches the active device uid in the group, and in the app when one is given.
+func deviceQuery(uid, appID, groupID string) *query {
+	q := newQuery().uid(uid).eq("group_id", groupID).active()
+	if !util.IsStringEmpty(appID) {
+		q.eq("app_id", appID)
+	}
+
+	return q
+}
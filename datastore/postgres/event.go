@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventRepo struct {
+	db    *sql.DB
+	store *store
+}
+
+func NewEventRepository(db *sql.DB) datastore.EventRepository {
+	return &eventRepo{db: db, store: newStore(db, EventTable)}
+}
+
+// intervalFormats maps each period to the to_char format of its
+// interval and the date field the interval index is computed from.
+var intervalFormats = map[datastore.Period][2]string{
+	datastore.Daily:   {"YYYY-MM-DD", "DOY"},
+	datastore.Weekly:  {"YYYY-MM", "WEEK"},
+	datastore.Monthly: {"YYYY-MM", "MONTH"},
+	datastore.Yearly:  {"YYYY", "YEAR"},
+}
+
+func (db *eventRepo) CreateEvent(ctx context.Context, message *datastore.Event) error {
+	message.ID = primitive.NewObjectID()
+
+	if util.IsStringEmpty(message.ProviderID) {
+		message.ProviderID = message.AppID
+	}
+	if util.IsStringEmpty(message.UID) {
+		message.UID = uuid.New().String()
+	}
+
+	return db.store.insert(ctx, message.UID, message.CreatedAt, message)
+}
+
+func (db *eventRepo) CountGroupMessages(ctx context.Context, groupID string) (int64, error) {
+	count, err := db.store.count(ctx, newQuery().eq("group_id", groupID).active())
+	if err != nil {
+		log.WithError(err).Errorf("failed to count events in group %s", groupID)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (db *eventRepo) DeleteGroupEvents(ctx context.Context, filter *datastore.EventFilter, hardDelete bool) error {
+	q := newQuery().
+		eq("group_id", filter.GroupID).
+		active().
+		createdBetween(filter.CreatedAtStart, filter.CreatedAtEnd)
+
+	return db.store.delete(ctx, q, hardDelete)
+}
+
+func (db *eventRepo) LoadEventIntervals(ctx context.Context, groupID string, searchParams datastore.SearchParams, period datastore.Period, interval int) ([]datastore.EventInterval, error) {
+	start := searchParams.CreatedAtStart
+	end := searchParams.CreatedAtEnd
+	if end == 0 || end < searchParams.CreatedAtStart {
+		end = start
+	}
+
+	format, ok := intervalFormats[period]
+	if !ok {
+		return nil, errors.New("specified data cannot be generated for period")
+	}
+
+	q := newQuery().eq("group_id", groupID).active().createdBetween(start, end)
+	stmt := fmt.Sprintf(`SELECT to_char(created_at AT TIME ZONE 'UTC', '%s') AS total_time,
+		trunc(EXTRACT(%s FROM created_at AT TIME ZONE 'UTC') / %s)::BIGINT AS idx,
+		count(*)
+		FROM %s%s GROUP BY total_time, idx ORDER BY total_time, idx`,
+		format[0], format[1], q.arg(interval), EventTable, q)
+
+	rows, err := db.db.QueryContext(ctx, stmt, q.args...)
+	if err != nil {
+		log.WithError(err).Errorln("event intervals query error")
+		return nil, err
+	}
+	defer rows.Close()
+
+	eventsIntervals := make([]datastore.EventInterval, 0)
+	for rows.Next() {
+		var i datastore.EventInterval
+		if err := rows.Scan(&i.Data.Time, &i.Data.Interval, &i.Count); err != nil {
+			return nil, err
+		}
+		eventsIntervals = append(eventsIntervals, i)
+	}
+
+	return eventsIntervals, rows.Err()
+}
+
+func (db *eventRepo) FindEventByID(ctx context.Context, id string) (*datastore.Event, error) {
+	m := new(datastore.Event)
+
+	err := db.store.findOne(ctx, newQuery().uid(id).active(), "", m)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrEventNotFound
+	}
+
+	return m, err
+}
+
+func (db *eventRepo) FindEventsByIDs(ctx context.Context, ids []string) ([]datastore.Event, error) {
+	m := make([]datastore.Event, 0)
+
+	err := db.store.findMany(ctx, newQuery().uids(ids).active(), "", &m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (db *eventRepo) LoadEventsPaged(ctx context.Context, groupID string, appId string, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.Event, datastore.PaginationData, error) {
+	q := newQuery().active().createdBetween(searchParams.CreatedAtStart, searchParams.CreatedAtEnd)
+
+	if !util.IsStringEmpty(groupID) {
+		q.eq("group_id", groupID)
+	}
+
+	if !util.IsStringEmpty(appId) {
+		q.eq("app_id", appId)
+	}
+
+	var messages []datastore.Event
+	paginationData, err := db.store.paginate(ctx, q, sortOrder(pageable.Sort), pageable, &messages)
+	if err != nil {
+		return messages, datastore.PaginationData{}, err
+	}
+
+	return messages, paginationData, nil
+}
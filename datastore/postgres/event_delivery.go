@@ -0,0 +1,271 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventDeliveryRepo struct {
+	db    *sql.DB
+	store *store
+}
+
+func NewEventDeliveryRepository(db *sql.DB) datastore.EventDeliveryRepository {
+	return &eventDeliveryRepo{db: db, store: newStore(db, EventDeliveryTable)}
+}
+
+func (db *eventDeliveryRepo) CreateEventDelivery(ctx context.Context, eventDelivery *datastore.EventDelivery) error {
+	eventDelivery.ID = primitive.NewObjectID()
+	if util.IsStringEmpty(eventDelivery.UID) {
+		eventDelivery.UID = uuid.New().String()
+	}
+
+	return db.store.insert(ctx, eventDelivery.UID, eventDelivery.CreatedAt, eventDelivery)
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveryByID(ctx context.Context, id string) (*datastore.EventDelivery, error) {
+	e := new(datastore.EventDelivery)
+
+	err := db.store.findOne(ctx, newQuery().uid(id).active(), "", e)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrEventDeliveryNotFound
+	}
+
+	return e, err
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveriesByIDs(ctx context.Context, ids []string) ([]datastore.EventDelivery, error) {
+	deliveries := make([]datastore.EventDelivery, 0)
+	err := db.store.findMany(ctx, newQuery().uids(ids).active(), "", &deliveries)
+	return deliveries, err
+}
+
+func (db *eventDeliveryRepo) FindEventDeliveriesByEventID(ctx context.Context, eventID string) ([]datastore.EventDelivery, error) {
+	deliveries := make([]datastore.EventDelivery, 0)
+
+	err := db.store.findMany(ctx, newQuery().eq("event_id", eventID).active(), "", &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (db *eventDeliveryRepo) CountDeliveriesByStatus(ctx context.Context, status datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+	q := newQuery().
+		eq("status", status).
+		active().
+		createdBetween(searchParams.CreatedAtStart, searchParams.CreatedAtEnd)
+
+	return db.store.count(ctx, q)
+}
+
+func (db *eventDeliveryRepo) UpdateStatusOfEventDelivery(ctx context.Context, e datastore.EventDelivery, status datastore.EventDeliveryStatus) error {
+	update := bson.M{
+		"status":     status,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err := db.store.update(ctx, newQuery().uid(e.UID), update)
+	if err != nil {
+		log.WithError(err).Error("Failed to update event delivery status")
+		return err
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) UpdateStatusOfEventDeliveries(ctx context.Context, ids []string, status datastore.EventDeliveryStatus) error {
+	update := bson.M{
+		"status":     status,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	return db.store.update(ctx, newQuery().uids(ids).active(), update)
+}
+
+func (db *eventDeliveryRepo) UpdateEventDeliveryWithAttempt(ctx context.Context, e datastore.EventDelivery, attempt datastore.DeliveryAttempt) error {
+	set := bson.M{
+		"status":      e.Status,
+		"description": e.Description,
+		"metadata":    e.Metadata,
+		"updated_at":  primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	// the attempt means the delivery is no longer waiting on the head of its queue
+	err := db.store.push(ctx, newQuery().uid(e.UID), "attempts", attempt, set, "blocked_by")
+	if err != nil {
+		log.WithError(err).Errorf("error updating an event delivery %s - %s\n", e.UID, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) LoadEventDeliveriesPaged(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams, pageable datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
+	q := getFilter(groupID, appID, eventID, status, searchParams)
+
+	var eventDeliveries []datastore.EventDelivery
+	paginationData, err := db.store.paginate(ctx, q, sortOrder(pageable.Sort), pageable, &eventDeliveries)
+	if err != nil {
+		return eventDeliveries, datastore.PaginationData{}, err
+	}
+
+	return eventDeliveries, paginationData, nil
+}
+
+func (db *eventDeliveryRepo) CountEventDeliveries(ctx context.Context, groupID, appID, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) (int64, error) {
+	return db.store.count(ctx, getFilter(groupID, appID, eventID, status, searchParams))
+}
+
+func getFilter(groupID string, appID string, eventID string, status []datastore.EventDeliveryStatus, searchParams datastore.SearchParams) *query {
+	q := newQuery().active().createdBetween(searchParams.CreatedAtStart, searchParams.CreatedAtEnd)
+
+	if !util.IsStringEmpty(appID) {
+		q.eq("app_id", appID)
+	}
+
+	if !util.IsStringEmpty(groupID) {
+		q.eq("group_id", groupID)
+	}
+
+	if !util.IsStringEmpty(eventID) {
+		q.eq("event_id", eventID)
+	}
+
+	if len(status) > 0 {
+		q.in("status", statusStrings(status))
+	}
+
+	return q
+}
+
+func statusStrings(status []datastore.EventDeliveryStatus) []string {
+	s := make([]string, 0, len(status))
+	for _, st := range status {
+		s = append(s, string(st))
+	}
+
+	return s
+}
+
+func (db *eventDeliveryRepo) DeleteGroupEventDeliveries(ctx context.Context, filter *datastore.EventDeliveryFilter, hardDelete bool) error {
+	q := newQuery().
+		eq("group_id", filter.GroupID).
+		active().
+		createdBetween(filter.CreatedAtStart, filter.CreatedAtEnd)
+
+	return db.store.delete(ctx, q, hardDelete)
+}
+
+func (db *eventDeliveryRepo) FindDiscardedEventDeliveries(ctx context.Context, appId, deviceId string, searchParams datastore.SearchParams) ([]datastore.EventDelivery, error) {
+	q := newQuery().
+		eq("app_id", appId).
+		eq("device_id", deviceId).
+		eq("status", datastore.DiscardedEventStatus).
+		createdBetween(searchParams.CreatedAtStart, searchParams.CreatedAtEnd).
+		active()
+
+	deliveries := make([]datastore.EventDelivery, 0)
+	err := db.store.findMany(ctx, q, "", &deliveries)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// pendingEventStatuses are the statuses of deliveries that are still
+// waiting to be sent, the oldest of them is the head of its ordering queue.
+var pendingEventStatuses = []datastore.EventDeliveryStatus{
+	datastore.ScheduledEventStatus,
+	datastore.ProcessingEventStatus,
+	datastore.RetryEventStatus,
+	datastore.CircuitOpenEventStatus,
+	datastore.BlockedEventStatus,
+}
+
+func (db *eventDeliveryRepo) FindOrderingHead(ctx context.Context, orderingKey string) (*datastore.EventDelivery, error) {
+	e := new(datastore.EventDelivery)
+
+	// deliveries left in processing by a crashed worker don't hold back the
+	// rest of the queue, updated_at is stored as an extended json date.
+	staleAt := primitive.NewDateTimeFromTime(time.Now().Add(-datastore.ProcessingTimeout))
+
+	q := newQuery().
+		eq("ordering_key", orderingKey).
+		in("status", statusStrings(pendingEventStatuses)).
+		cond("("+field("status")+" <> %s OR (document->'updated_at'->'$date'->>'$numberLong')::bigint > %s)",
+			datastore.ProcessingEventStatus, int64(staleAt)).
+		active()
+
+	err := db.store.findOne(ctx, q, "created_at ASC, id ASC", e)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrEventDeliveryNotFound
+	}
+
+	return e, err
+}
+
+func (db *eventDeliveryRepo) BlockEventDelivery(ctx context.Context, e datastore.EventDelivery, headID string) error {
+	update := bson.M{
+		"status":     datastore.BlockedEventStatus,
+		"blocked_by": headID,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err := db.store.update(ctx, newQuery().uid(e.UID), update)
+	if err != nil {
+		log.WithError(err).Errorf("error blocking event delivery %s", e.UID)
+		return err
+	}
+
+	return nil
+}
+
+func (db *eventDeliveryRepo) LoadOrderingQueues(ctx context.Context, subscriptionID string) ([]datastore.OrderingQueue, error) {
+	q := newQuery().
+		eq("subscription_id", subscriptionID).
+		cond(field("ordering_key")+" <> ''").
+		in("status", statusStrings(pendingEventStatuses)).
+		active()
+
+	stmt := fmt.Sprintf(`SELECT %s AS ordering_key,
+		(array_agg(uid ORDER BY created_at, id))[1],
+		(array_agg(%s ORDER BY created_at, id))[1],
+		min(created_at) AS head_created_at,
+		count(*) - 1
+		FROM %s%s GROUP BY ordering_key ORDER BY head_created_at`,
+		field("ordering_key"), field("status"), EventDeliveryTable, q)
+
+	rows, err := db.db.QueryContext(ctx, stmt, q.args...)
+	if err != nil {
+		log.WithError(err).Error("failed to load ordering queues")
+		return nil, err
+	}
+	defer rows.Close()
+
+	queues := make([]datastore.OrderingQueue, 0)
+	for rows.Next() {
+		var oq datastore.OrderingQueue
+		var headCreatedAt time.Time
+		if err := rows.Scan(&oq.OrderingKey, &oq.HeadID, &oq.HeadStatus, &headCreatedAt, &oq.Blocked); err != nil {
+			return nil, err
+		}
+
+		oq.HeadCreatedAt = primitive.NewDateTimeFromTime(headCreatedAt)
+		queues = append(queues, oq)
+	}
+
+	return queues, rows.Err()
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const groupNameIndex = "groups_name_unique"
+
+type groupRepo struct {
+	db    *sql.DB
+	store *store
+}
+
+func NewGroupRepo(db *sql.DB) datastore.GroupRepository {
+	return &groupRepo{db: db, store: newStore(db, GroupTable)}
+}
+
+func (db *groupRepo) LoadGroups(ctx context.Context, f *datastore.GroupFilter) ([]*datastore.Group, error) {
+	q := newQuery().active()
+	if f.OrgID != "" {
+		q.eq("organisation_id", f.OrgID)
+	}
+
+	f = f.WithNamesTrimmed()
+	if len(f.Names) > 0 {
+		q.in("name", f.Names)
+	}
+
+	groups := make([]*datastore.Group, 0)
+	err := db.store.findMany(ctx, q, "created_at ASC, id ASC", &groups)
+
+	return groups, err
+}
+
+func (db *groupRepo) CreateGroup(ctx context.Context, o *datastore.Group) error {
+	o.ID = primitive.NewObjectID()
+
+	err := db.store.insert(ctx, o.UID, o.CreatedAt, o)
+	if isDuplicate(err, groupNameIndex) {
+		return datastore.ErrDuplicateGroupName
+	}
+
+	return err
+}
+
+func (db *groupRepo) UpdateGroup(ctx context.Context, o *datastore.Group) error {
+	o.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	update := bson.M{
+		"name":                o.Name,
+		"logo_url":            o.LogoURL,
+		"updated_at":          o.UpdatedAt,
+		"config":              o.Config,
+		"rate_limit":          o.RateLimit,
+		"metadata":            o.Metadata,
+		"rate_limit_duration": o.RateLimitDuration,
+		"signing_keys":        o.SigningKeys,
+	}
+
+	err := db.store.update(ctx, newQuery().uid(o.UID), update)
+	if isDuplicate(err, groupNameIndex) {
+		return datastore.ErrDuplicateGroupName
+	}
+
+	return err
+}
+
+func (db *groupRepo) FetchGroupByID(ctx context.Context, id string) (*datastore.Group, error) {
+	group := new(datastore.Group)
+
+	err := db.store.findOne(ctx, newQuery().uid(id).active(), "", group)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrGroupNotFound
+	}
+
+	return group, err
+}
+
+func (db *groupRepo) FillGroupsStatistics(ctx context.Context, groups []*datastore.Group) error {
+	ids := make([]string, 0, len(groups))
+	for _, group := range groups {
+		ids = append(ids, group.UID)
+	}
+
+	stmt := fmt.Sprintf(`SELECT g.uid,
+		(SELECT count(*) FROM %s a WHERE a.document->>'group_id' = g.uid),
+		(SELECT count(*) FROM %s e WHERE e.document->>'group_id' = g.uid)
+		FROM %s g WHERE g.uid = ANY($1)`, AppTable, EventTable, GroupTable)
+
+	rows, err := db.db.QueryContext(ctx, stmt, pq.Array(ids))
+	if err != nil {
+		log.WithError(err).Error("failed to load group statistics")
+		return err
+	}
+	defer rows.Close()
+
+	statsMap := map[string]*datastore.GroupStatistics{}
+	for rows.Next() {
+		s := &datastore.GroupStatistics{}
+		if err := rows.Scan(&s.GroupID, &s.TotalApps, &s.MessagesSent); err != nil {
+			return err
+		}
+		statsMap[s.GroupID] = s
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range groups {
+		groups[i].Statistics = statsMap[groups[i].UID]
+	}
+
+	return nil
+}
+
+func (db *groupRepo) DeleteGroup(ctx context.Context, uid string) error {
+	return db.store.delete(ctx, newQuery().uid(uid), false)
+}
+
+func (db *groupRepo) FetchGroupsByIDs(ctx context.Context, ids []string) ([]datastore.Group, error) {
+	groups := make([]datastore.Group, 0)
+
+	err := db.store.findMany(ctx, newQuery().uids(ids).active(), "created_at ASC, id ASC", &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
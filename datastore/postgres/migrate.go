@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the advisory lock held while migrations run, so
+// servers and workers starting together don't apply them twice.
+const migrationLockID = 4947213
+
+// Migrate applies every migration in the migrations directory that has
+// not been recorded in the schema_migrations table, in file name order.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID)
+	if err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return err
+	}
+
+	names, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := strings.TrimSuffix(strings.TrimPrefix(name, "migrations/"), ".sql")
+
+		var applied bool
+		err = conn.QueryRowContext(ctx,
+			"SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied)
+		if err != nil {
+			return err
+		}
+
+		if applied {
+			continue
+		}
+
+		stmts, err := migrationFiles.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		err = applyMigration(ctx, conn, version, string(stmts))
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %s: %v", version, err)
+		}
+
+		log.Infof("applied postgres migration %s", version)
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, conn *sql.Conn, version, stmts string) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, stmts); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
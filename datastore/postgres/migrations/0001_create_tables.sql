@@ -0,0 +1,152 @@
+-- Every table stores the full model in document, uid and created_at are
+-- kept in their own columns for lookups, date ranges and sorting.
+
+CREATE TABLE IF NOT EXISTS configurations (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS organisations (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS organisation_members (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS organisation_members_organisation_id ON organisation_members ((document->>'organisation_id'));
+CREATE INDEX IF NOT EXISTS organisation_members_user_id ON organisation_members ((document->>'user_id'));
+CREATE UNIQUE INDEX IF NOT EXISTS organisation_members_user_unique ON organisation_members ((document->>'organisation_id'), (document->>'user_id'))
+	WHERE document->>'document_status' = 'Active';
+
+CREATE TABLE IF NOT EXISTS organisation_invites (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS organisation_invites_token ON organisation_invites ((document->>'token'));
+CREATE UNIQUE INDEX IF NOT EXISTS organisation_invites_email_unique ON organisation_invites ((document->>'organisation_id'), (document->>'invitee_email'))
+	WHERE document->>'document_status' = 'Active';
+
+CREATE TABLE IF NOT EXISTS users (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS users_reset_password_token ON users ((document->>'reset_password_token'));
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_unique ON users ((document->>'email'))
+	WHERE document->>'document_status' = 'Active';
+
+CREATE TABLE IF NOT EXISTS groups (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS groups_name_unique ON groups ((document->>'organisation_id'), (document->>'name'))
+	WHERE document->>'document_status' = 'Active';
+
+CREATE TABLE IF NOT EXISTS api_keys (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_mask_id ON api_keys ((document->>'mask_id'));
+CREATE INDEX IF NOT EXISTS api_keys_hash ON api_keys ((document->>'hash'));
+
+CREATE TABLE IF NOT EXISTS applications (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS applications_group_id ON applications ((document->>'group_id'), (document->>'document_status'), created_at);
+CREATE INDEX IF NOT EXISTS applications_owner_id ON applications ((document->>'owner_id'));
+CREATE INDEX IF NOT EXISTS applications_tags ON applications USING GIN ((document->'tags'));
+CREATE UNIQUE INDEX IF NOT EXISTS applications_title_unique ON applications ((document->>'group_id'), (document->>'title'))
+	WHERE document->>'document_status' = 'Active';
+
+CREATE TABLE IF NOT EXISTS devices (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS devices_host_name_unique ON devices ((document->>'app_id'), (document->>'group_id'), (document->>'host_name'))
+	WHERE document->>'document_status' = 'Active';
+
+CREATE TABLE IF NOT EXISTS sources (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS sources_mask_id ON sources ((document->>'mask_id'));
+CREATE INDEX IF NOT EXISTS sources_group_id ON sources ((document->>'group_id'), created_at);
+
+CREATE TABLE IF NOT EXISTS subscriptions (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS subscriptions_app_id ON subscriptions ((document->>'group_id'), (document->>'app_id'));
+CREATE INDEX IF NOT EXISTS subscriptions_source_id ON subscriptions ((document->>'group_id'), (document->>'source_id'));
+CREATE INDEX IF NOT EXISTS subscriptions_event_types ON subscriptions USING GIN ((document->'filter_config'->'event_types'));
+CREATE UNIQUE INDEX IF NOT EXISTS subscriptions_unique ON subscriptions (
+	(document->>'app_id'), (document->>'group_id'), (document->>'source_id'),
+	(document->>'device_id'), (document->>'endpoint_id')
+) WHERE document->>'document_status' = 'Active';
+
+CREATE TABLE IF NOT EXISTS events (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS events_group_id ON events ((document->>'group_id'), (document->>'document_status'), created_at);
+CREATE INDEX IF NOT EXISTS events_app_id ON events ((document->>'app_id'), (document->>'document_status'), created_at);
+CREATE INDEX IF NOT EXISTS events_created_at ON events (created_at);
+
+CREATE TABLE IF NOT EXISTS event_deliveries (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS event_deliveries_event_id ON event_deliveries ((document->>'event_id'), (document->>'document_status'), created_at);
+CREATE INDEX IF NOT EXISTS event_deliveries_group_id ON event_deliveries ((document->>'group_id'), (document->>'document_status'), created_at);
+CREATE INDEX IF NOT EXISTS event_deliveries_status ON event_deliveries ((document->>'status'));
+CREATE INDEX IF NOT EXISTS event_deliveries_ordering_key ON event_deliveries ((document->>'ordering_key'), created_at, id);
+CREATE INDEX IF NOT EXISTS event_deliveries_created_at ON event_deliveries (created_at);
+
+CREATE TABLE IF NOT EXISTS dead_letters (
+	id BIGSERIAL PRIMARY KEY,
+	uid TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	document JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS dead_letters_group_id ON dead_letters ((document->>'group_id'), created_at);
+CREATE INDEX IF NOT EXISTS dead_letters_event_delivery_id ON dead_letters ((document->>'event_delivery_id'));
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgRepo struct {
+	store *store
+}
+
+func NewOrgRepo(db *sql.DB) datastore.OrganisationRepository {
+	return &orgRepo{store: newStore(db, OrganisationTable)}
+}
+
+func (db *orgRepo) LoadOrganisationsPaged(ctx context.Context, pageable datastore.Pageable) ([]datastore.Organisation, datastore.PaginationData, error) {
+	organisations := make([]datastore.Organisation, 0)
+
+	paginationData, err := db.store.paginate(ctx, newQuery().active(), "id ASC", pageable, &organisations)
+	if err != nil {
+		return organisations, datastore.PaginationData{}, err
+	}
+
+	return organisations, paginationData, nil
+}
+
+func (db *orgRepo) CreateOrganisation(ctx context.Context, org *datastore.Organisation) error {
+	org.ID = primitive.NewObjectID()
+	return db.store.insert(ctx, org.UID, org.CreatedAt, org)
+}
+
+func (db *orgRepo) UpdateOrganisation(ctx context.Context, org *datastore.Organisation) error {
+	org.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	update := bson.M{
+		"name":       org.Name,
+		"updated_at": org.UpdatedAt,
+	}
+
+	return db.store.update(ctx, newQuery().uid(org.UID), update)
+}
+
+func (db *orgRepo) DeleteOrganisation(ctx context.Context, uid string) error {
+	return db.store.delete(ctx, newQuery().uid(uid), false)
+}
+
+func (db *orgRepo) FetchOrganisationByID(ctx context.Context, id string) (*datastore.Organisation, error) {
+	org := new(datastore.Organisation)
+
+	err := db.store.findOne(ctx, newQuery().uid(id).active(), "", org)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrOrgNotFound
+	}
+
+	return org, err
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type orgMemberRepo struct {
+	store     *store
+	userStore *store
+}
+
+func NewOrgMemberRepo(db *sql.DB) datastore.OrganisationMemberRepository {
+	return &orgMemberRepo{
+		store:     newStore(db, OrganisationMembersTable),
+		userStore: newStore(db, UserTable),
+	}
+}
+
+func (o *orgMemberRepo) LoadOrganisationMembersPaged(ctx context.Context, organisationID string, pageable datastore.Pageable) ([]*datastore.OrganisationMember, datastore.PaginationData, error) {
+	q := newQuery().active()
+	if !util.IsStringEmpty(organisationID) {
+		q.eq("organisation_id", organisationID)
+	}
+
+	members := make([]*datastore.OrganisationMember, 0)
+	paginationData, err := o.store.paginate(ctx, q, sortOrder(pageable.Sort), pageable, &members)
+	if err != nil {
+		return members, datastore.PaginationData{}, err
+	}
+
+	err = o.fillOrgMemberUserMetadata(ctx, members)
+	if err != nil {
+		return members, datastore.PaginationData{}, err
+	}
+
+	return members, paginationData, nil
+}
+
+func (o *orgMemberRepo) LoadUserOrganisationsPaged(ctx context.Context, userID string, pageable datastore.Pageable) ([]datastore.Organisation, datastore.PaginationData, error) {
+	q := newQuery().
+		cond("m.document->>'user_id' = %s", userID).
+		cond("m.document->>'document_status' = %s", datastore.ActiveDocumentStatus).
+		cond("o.document->>'document_status' = %s", datastore.ActiveDocumentStatus)
+
+	from := fmt.Sprintf("%s m JOIN %s o ON o.uid = m.document->>'organisation_id'", OrganisationMembersTable, OrganisationTable)
+
+	var count int64
+	err := o.store.db.QueryRowContext(ctx, fmt.Sprintf("SELECT count(*) FROM %s%s", from, q), q.args...).Scan(&count)
+	if err != nil {
+		log.WithError(err).Error("failed to count user organisations")
+		return nil, datastore.PaginationData{}, err
+	}
+
+	data := paginationData(count, pageable)
+	stmt := fmt.Sprintf("SELECT o.document FROM %s%s ORDER BY m.created_at %s, m.id %s LIMIT %s OFFSET %s",
+		from, q, sortDirection(pageable.Sort), sortDirection(pageable.Sort), q.arg(data.PerPage), q.arg((data.Page-1)*data.PerPage))
+
+	organisations := make([]datastore.Organisation, 0)
+	err = o.store.query(ctx, stmt, q.args, &organisations)
+	if err != nil {
+		log.WithError(err).Error("failed to load user organisations")
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return organisations, data, nil
+}
+
+func (o *orgMemberRepo) CreateOrganisationMember(ctx context.Context, member *datastore.OrganisationMember) error {
+	member.ID = primitive.NewObjectID()
+	return o.store.insert(ctx, member.UID, member.CreatedAt, member)
+}
+
+func (o *orgMemberRepo) UpdateOrganisationMember(ctx context.Context, member *datastore.OrganisationMember) error {
+	member.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	update := bson.M{
+		"role":       member.Role,
+		"updated_at": member.UpdatedAt,
+	}
+
+	return o.store.update(ctx, newQuery().uid(member.UID), update)
+}
+
+func (o *orgMemberRepo) DeleteOrganisationMember(ctx context.Context, uid, orgID string) error {
+	return o.store.delete(ctx, newQuery().uid(uid).eq("organisation_id", orgID), false)
+}
+
+func (o *orgMemberRepo) FetchOrganisationMemberByID(ctx context.Context, uid, orgID string) (*datastore.OrganisationMember, error) {
+	return o.findMember(ctx, newQuery().uid(uid).eq("organisation_id", orgID).active())
+}
+
+func (o *orgMemberRepo) FetchOrganisationMemberByUserID(ctx context.Context, userID, orgID string) (*datastore.OrganisationMember, error) {
+	return o.findMember(ctx, newQuery().eq("user_id", userID).eq("organisation_id", orgID).active())
+}
+
+func (o *orgMemberRepo) findMember(ctx context.Context, q *query) (*datastore.OrganisationMember, error) {
+	member := new(datastore.OrganisationMember)
+
+	err := o.store.findOne(ctx, q, "", member)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, datastore.ErrOrgMemberNotFound
+		}
+
+		return nil, err
+	}
+
+	err = o.fillOrgMemberUserMetadata(ctx, []*datastore.OrganisationMember{member})
+	return member, err
+}
+
+func (o *orgMemberRepo) fillOrgMemberUserMetadata(ctx context.Context, members []*datastore.OrganisationMember) error {
+	userIDs := make([]string, 0, len(members))
+	for i := range members {
+		userIDs = append(userIDs, members[i].UserID)
+	}
+
+	users := make([]datastore.User, 0, len(userIDs))
+	err := o.userStore.findMany(ctx, newQuery().uids(userIDs), "", &users)
+	if err != nil {
+		log.WithError(err).Error("failed to load user metadata for organisation members")
+		return err
+	}
+
+	metaMap := map[string]*datastore.UserMetadata{}
+	for _, u := range users {
+		metaMap[u.UID] = &datastore.UserMetadata{
+			UserID:    u.UID,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Email:     u.Email,
+		}
+	}
+
+	for i := range members {
+		members[i].UserMetadata = metaMap[members[i].UserID]
+	}
+
+	return nil
+}
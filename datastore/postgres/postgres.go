@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	_ "github.com/lib/pq"
+)
+
+const (
+	ConfigTable              = "configurations"
+	GroupTable               = "groups"
+	OrganisationTable        = "organisations"
+	OrganisationInvitesTable = "organisation_invites"
+	OrganisationMembersTable = "organisation_members"
+	APIKeyTable              = "api_keys"
+	AppTable                 = "applications"
+	DeviceTable              = "devices"
+	EventTable               = "events"
+	EventDeliveryTable       = "event_deliveries"
+	SourceTable              = "sources"
+	UserTable                = "users"
+	SubscriptionTable        = "subscriptions"
+	DeadLetterTable          = "dead_letters"
+)
+
+type Client struct {
+	db                *sql.DB
+	apiKeyRepo        datastore.APIKeyRepository
+	groupRepo         datastore.GroupRepository
+	eventRepo         datastore.EventRepository
+	applicationRepo   datastore.ApplicationRepository
+	subscriptionRepo  datastore.SubscriptionRepository
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	sourceRepo        datastore.SourceRepository
+	orgRepo           datastore.OrganisationRepository
+	orgMemberRepo     datastore.OrganisationMemberRepository
+	orgInviteRepo     datastore.OrganisationInviteRepository
+	userRepo          datastore.UserRepository
+	deviceRepo        datastore.DeviceRepository
+	configRepo        datastore.ConfigurationRepository
+	deadLetterRepo    datastore.DeadLetterRepository
+}
+
+func New(cfg config.Configuration) (*Client, error) {
+	db, err := sql.Open("postgres", cfg.Database.Dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := Migrate(context.Background(), db); err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		db:                db,
+		apiKeyRepo:        NewApiKeyRepo(db),
+		groupRepo:         NewGroupRepo(db),
+		applicationRepo:   NewApplicationRepo(db),
+		subscriptionRepo:  NewSubscriptionRepo(db),
+		eventRepo:         NewEventRepository(db),
+		eventDeliveryRepo: NewEventDeliveryRepository(db),
+		sourceRepo:        NewSourceRepo(db),
+		deviceRepo:        NewDeviceRepository(db),
+		orgRepo:           NewOrgRepo(db),
+		orgMemberRepo:     NewOrgMemberRepo(db),
+		orgInviteRepo:     NewOrgInviteRepo(db),
+		userRepo:          NewUserRepo(db),
+		configRepo:        NewConfigRepo(db),
+		deadLetterRepo:    NewDeadLetterRepo(db),
+	}
+
+	return c, nil
+}
+
+func (c *Client) Disconnect(ctx context.Context) error {
+	return c.db.Close()
+}
+
+func (c *Client) GetName() string {
+	return "postgres"
+}
+
+func (c *Client) Client() interface{} {
+	return c.db
+}
+
+func (c *Client) APIRepo() datastore.APIKeyRepository {
+	return c.apiKeyRepo
+}
+
+func (c *Client) GroupRepo() datastore.GroupRepository {
+	return c.groupRepo
+}
+
+func (c *Client) AppRepo() datastore.ApplicationRepository {
+	return c.applicationRepo
+}
+
+func (c *Client) DeviceRepo() datastore.DeviceRepository {
+	return c.deviceRepo
+}
+
+func (c *Client) EventRepo() datastore.EventRepository {
+	return c.eventRepo
+}
+
+func (c *Client) EventDeliveryRepo() datastore.EventDeliveryRepository {
+	return c.eventDeliveryRepo
+}
+
+func (c *Client) SubRepo() datastore.SubscriptionRepository {
+	return c.subscriptionRepo
+}
+
+func (c *Client) SourceRepo() datastore.SourceRepository {
+	return c.sourceRepo
+}
+
+func (c *Client) OrganisationRepo() datastore.OrganisationRepository {
+	return c.orgRepo
+}
+
+func (c *Client) OrganisationMemberRepo() datastore.OrganisationMemberRepository {
+	return c.orgMemberRepo
+}
+
+func (c *Client) OrganisationInviteRepo() datastore.OrganisationInviteRepository {
+	return c.orgInviteRepo
+}
+
+func (c *Client) UserRepo() datastore.UserRepository {
+	return c.userRepo
+}
+
+func (c *Client) ConfigurationRepo() datastore.ConfigurationRepository {
+	return c.configRepo
+}
+
+func (c *Client) DeadLetterRepo() datastore.DeadLetterRepository {
+	return c.deadLetterRepo
+}
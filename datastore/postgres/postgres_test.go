@@ -0,0 +1,49 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/stretchr/testify/require"
+)
+
+var tables = []string{
+	ConfigTable, GroupTable, OrganisationTable, OrganisationInvitesTable,
+	OrganisationMembersTable, APIKeyTable, AppTable, DeviceTable, EventTable,
+	EventDeliveryTable, SourceTable, UserTable, SubscriptionTable, DeadLetterTable,
+}
+
+func getDSN() string {
+	return os.Getenv("TEST_POSTGRES_DSN")
+}
+
+func getConfig() config.Configuration {
+	return config.Configuration{
+		Database: config.DatabaseConfiguration{
+			Type: config.PostgresDatabaseProvider,
+			Dsn:  getDSN(),
+		},
+	}
+}
+
+func getDB(t *testing.T) (*sql.DB, func()) {
+	db, err := New(getConfig())
+	require.NoError(t, err)
+
+	client := db.Client().(*sql.DB)
+
+	return client, func() {
+		for _, table := range tables {
+			_, err := client.ExecContext(context.Background(), fmt.Sprintf("TRUNCATE %s", table))
+			require.NoError(t, err)
+		}
+		require.NoError(t, db.Disconnect(context.Background()))
+	}
+}
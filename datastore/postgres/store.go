@@ -0,0 +1,343 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// uniqueViolation is the postgres error code for a unique constraint violation.
+const uniqueViolation = "23505"
+
+// encode serialises a model as canonical extended JSON, this way every
+// bson tag on the datastore models round-trips the same way it does in mongo.
+func encode(v interface{}) ([]byte, error) {
+	return bson.MarshalExtJSON(v, true, false)
+}
+
+func decode(data []byte, v interface{}) error {
+	return bson.UnmarshalExtJSON(data, true, v)
+}
+
+// isDuplicate reports whether err was caused by the unique index named constraint.
+func isDuplicate(err error, constraint string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code == uniqueViolation && pqErr.Constraint == constraint
+}
+
+// field returns the sql expression that reads the text value of a
+// document field, nested fields are separated by dots e.g. "role.group".
+func field(name string) string {
+	parts := strings.Split(name, ".")
+	expr := "document"
+	for i, p := range parts {
+		op := "->"
+		if i == len(parts)-1 {
+			op = "->>"
+		}
+		expr += fmt.Sprintf("%s'%s'", op, p)
+	}
+
+	return expr
+}
+
+// query builds the where clause of a statement and its arguments.
+type query struct {
+	where []string
+	args  []interface{}
+}
+
+func newQuery() *query {
+	return &query{}
+}
+
+// arg adds v to the arguments and returns its placeholder.
+func (q *query) arg(v interface{}) string {
+	q.args = append(q.args, v)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+func (q *query) eq(name string, v interface{}) *query {
+	q.where = append(q.where, fmt.Sprintf("%s = %s", field(name), q.arg(v)))
+	return q
+}
+
+func (q *query) in(name string, values []string) *query {
+	q.where = append(q.where, fmt.Sprintf("%s = ANY(%s)", field(name), q.arg(pq.Array(values))))
+	return q
+}
+
+func (q *query) uid(uid string) *query {
+	q.where = append(q.where, "uid = "+q.arg(uid))
+	return q
+}
+
+func (q *query) uids(uids []string) *query {
+	q.where = append(q.where, fmt.Sprintf("uid = ANY(%s)", q.arg(pq.Array(uids))))
+	return q
+}
+
+func (q *query) active() *query {
+	return q.eq("document_status", datastore.ActiveDocumentStatus)
+}
+
+func (q *query) createdBetween(start, end int64) *query {
+	q.where = append(q.where, fmt.Sprintf("created_at BETWEEN %s AND %s",
+		q.arg(time.Unix(start, 0)), q.arg(time.Unix(end, 0))))
+	return q
+}
+
+// cond adds a raw condition, args are bound to the %s verbs in clause.
+func (q *query) cond(clause string, args ...interface{}) *query {
+	placeholders := make([]interface{}, len(args))
+	for i, a := range args {
+		placeholders[i] = q.arg(a)
+	}
+
+	q.where = append(q.where, fmt.Sprintf(clause, placeholders...))
+	return q
+}
+
+func (q *query) String() string {
+	if len(q.where) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(q.where, " AND ")
+}
+
+// store runs the document operations shared by every table.
+type store struct {
+	db    *sql.DB
+	table string
+}
+
+func newStore(db *sql.DB, table string) *store {
+	return &store{db: db, table: table}
+}
+
+func (s *store) insert(ctx context.Context, uid string, createdAt primitive.DateTime, doc interface{}) error {
+	b, err := encode(doc)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		fmt.Sprintf("INSERT INTO %s (uid, created_at, document) VALUES ($1, $2, $3)", s.table),
+		uid, createdAt.Time(), b)
+	return err
+}
+
+// findOne decodes the first document matched by q into out, it
+// returns sql.ErrNoRows when nothing matches.
+func (s *store) findOne(ctx context.Context, q *query, order string, out interface{}) error {
+	stmt := fmt.Sprintf("SELECT document FROM %s%s", s.table, q)
+	if order != "" {
+		stmt += " ORDER BY " + order
+	}
+
+	var b []byte
+	err := s.db.QueryRowContext(ctx, stmt+" LIMIT 1", q.args...).Scan(&b)
+	if err != nil {
+		return err
+	}
+
+	return decode(b, out)
+}
+
+// findMany decodes every document matched by q into out, which must be
+// a pointer to a slice of models or model pointers.
+func (s *store) findMany(ctx context.Context, q *query, order string, out interface{}) error {
+	stmt := fmt.Sprintf("SELECT document FROM %s%s", s.table, q)
+	if order != "" {
+		stmt += " ORDER BY " + order
+	}
+
+	return s.query(ctx, stmt, q.args, out)
+}
+
+func (s *store) query(ctx context.Context, stmt string, args []interface{}, out interface{}) error {
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return scanDocuments(rows, out)
+}
+
+func (s *store) count(ctx context.Context, q *query) (int64, error) {
+	var count int64
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT count(*) FROM %s%s", s.table, q), q.args...).Scan(&count)
+	return count, err
+}
+
+// update merges the top level fields of payload into every document matched by q.
+func (s *store) update(ctx context.Context, q *query, payload interface{}) error {
+	b, err := encode(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET document = document || %s::jsonb%s", s.table, q.arg(b), q), q.args...)
+	return err
+}
+
+// push appends item to the array field name, merges set into and removes
+// the unset fields from every document matched by q.
+func (s *store) push(ctx context.Context, q *query, name string, item interface{}, set bson.M, unset ...string) error {
+	it, err := encode(item)
+	if err != nil {
+		return err
+	}
+
+	st, err := encode(set)
+	if err != nil {
+		return err
+	}
+
+	doc := fmt.Sprintf(`jsonb_set(document, '{%s}',
+		COALESCE(NULLIF(document->'%s', 'null'::jsonb), '[]'::jsonb) || jsonb_build_array(%s::jsonb)) || %s::jsonb`,
+		name, name, q.arg(it), q.arg(st))
+
+	for _, f := range unset {
+		doc = fmt.Sprintf("(%s) - %s::text", doc, q.arg(f))
+	}
+
+	stmt := fmt.Sprintf("UPDATE %s SET document = %s%s", s.table, doc, q)
+
+	_, err = s.db.ExecContext(ctx, stmt, q.args...)
+	return err
+}
+
+// delete removes the documents matched by q, or marks them as deleted
+// when hardDelete is false.
+func (s *store) delete(ctx context.Context, q *query, hardDelete bool) error {
+	if !hardDelete {
+		return s.update(ctx, q, bson.M{
+			"deleted_at":      primitive.NewDateTimeFromTime(time.Now()),
+			"document_status": datastore.DeletedDocumentStatus,
+		})
+	}
+
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s%s", s.table, q), q.args...)
+	return err
+}
+
+// paginate loads a page of the documents matched by q into out.
+func (s *store) paginate(ctx context.Context, q *query, order string, pageable datastore.Pageable, out interface{}) (datastore.PaginationData, error) {
+	count, err := s.count(ctx, q)
+	if err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	data := paginationData(count, pageable)
+
+	stmt := fmt.Sprintf("SELECT document FROM %s%s ORDER BY %s LIMIT %s OFFSET %s",
+		s.table, q, order, q.arg(data.PerPage), q.arg((data.Page-1)*data.PerPage))
+
+	err = s.query(ctx, stmt, q.args, out)
+	if err != nil {
+		return datastore.PaginationData{}, err
+	}
+
+	return data, nil
+}
+
+// paginationData computes the page stats the same way the mongo pager does.
+func paginationData(total int64, pageable datastore.Pageable) datastore.PaginationData {
+	page, perPage := int64(pageable.Page), int64(pageable.PerPage)
+	if page < 1 {
+		page = 1
+	}
+
+	if perPage < 1 {
+		perPage = 10
+	}
+
+	data := datastore.PaginationData{
+		Total:     total,
+		Page:      page,
+		PerPage:   perPage,
+		TotalPage: int64(math.Ceil(float64(total) / float64(perPage))),
+	}
+
+	if total > 0 && page > 1 {
+		data.Prev = page - 1
+	}
+
+	if total > 0 && page < data.TotalPage {
+		data.Next = page + 1
+	}
+
+	return data
+}
+
+// sortOrder returns the order clause for a created_at sort direction.
+func sortOrder(sort int) string {
+	dir := sortDirection(sort)
+	return fmt.Sprintf("created_at %s, id %s", dir, dir)
+}
+
+// sortDirection maps a pageable sort to sql, 1 sorts in ascending
+// order and anything else in descending order.
+func sortDirection(sort int) string {
+	if sort == 1 {
+		return "ASC"
+	}
+
+	return "DESC"
+}
+
+func scanDocuments(rows *sql.Rows, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return datastore.ErrInvalidPtr
+	}
+
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	if slice.IsNil() {
+		slice.Set(reflect.MakeSlice(slice.Type(), 0, 0))
+	}
+
+	for rows.Next() {
+		var b []byte
+		if err := rows.Scan(&b); err != nil {
+			return err
+		}
+
+		elem := reflect.New(elemType)
+		if err := decode(b, elem.Interface()); err != nil {
+			return err
+		}
+
+		if !isPtr {
+			elem = elem.Elem()
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+
+	return rows.Err()
+}
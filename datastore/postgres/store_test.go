@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func Test_field(t *testing.T) {
+	require.Equal(t, "document->>'uid'", field("uid"))
+	require.Equal(t, "document->'role'->>'group'", field("role.group"))
+}
+
+func Test_query(t *testing.T) {
+	q := newQuery().uid("123").eq("group_id", "abc").active()
+
+	require.Equal(t, " WHERE uid = $1 AND document->>'group_id' = $2 AND document->>'document_status' = $3", q.String())
+	require.Equal(t, []interface{}{"123", "abc", datastore.ActiveDocumentStatus}, q.args)
+
+	q.cond("jsonb_exists(document->'tags', %s)", "tag")
+	require.Equal(t, "$5", q.arg(1))
+	require.Contains(t, q.String(), "jsonb_exists(document->'tags', $4)")
+
+	require.Equal(t, "", newQuery().String())
+}
+
+func Test_paginationData(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int64
+		pageable datastore.Pageable
+		expected datastore.PaginationData
+	}{
+		{
+			name:     "defaults",
+			total:    25,
+			pageable: datastore.Pageable{},
+			expected: datastore.PaginationData{Total: 25, Page: 1, PerPage: 10, Next: 2, TotalPage: 3},
+		},
+		{
+			name:     "middle page",
+			total:    25,
+			pageable: datastore.Pageable{Page: 2, PerPage: 10},
+			expected: datastore.PaginationData{Total: 25, Page: 2, PerPage: 10, Prev: 1, Next: 3, TotalPage: 3},
+		},
+		{
+			name:     "last page",
+			total:    25,
+			pageable: datastore.Pageable{Page: 3, PerPage: 10},
+			expected: datastore.PaginationData{Total: 25, Page: 3, PerPage: 10, Prev: 2, TotalPage: 3},
+		},
+		{
+			name:     "empty",
+			total:    0,
+			pageable: datastore.Pageable{Page: 2, PerPage: 10},
+			expected: datastore.PaginationData{Page: 2, PerPage: 10},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, paginationData(tc.total, tc.pageable))
+		})
+	}
+}
+
+func Test_sortOrder(t *testing.T) {
+	require.Equal(t, "created_at ASC, id ASC", sortOrder(1))
+	require.Equal(t, "created_at DESC, id DESC", sortOrder(-1))
+}
+
+func Test_encodeDecode(t *testing.T) {
+	app := &datastore.Application{
+		ID:             primitive.NewObjectID(),
+		UID:            "123",
+		Title:          "test",
+		GroupID:        "abc",
+		Tags:           []string{"a", "b"},
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	b, err := encode(app)
+	require.NoError(t, err)
+
+	decoded := &datastore.Application{}
+	require.NoError(t, decode(b, decoded))
+	require.Equal(t, app, decoded)
+}
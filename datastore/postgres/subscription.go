@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type subscriptionRepo struct {
+	store *store
+}
+
+func NewSubscriptionRepo(db *sql.DB) datastore.SubscriptionRepository {
+	return &subscriptionRepo{store: newStore(db, SubscriptionTable)}
+}
+
+func (s *subscriptionRepo) CreateSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
+	if groupId != subscription.GroupID {
+		return datastore.ErrNotAuthorisedToAccessDocument
+	}
+
+	subscription.ID = primitive.NewObjectID()
+	return s.store.insert(ctx, subscription.UID, subscription.CreatedAt, subscription)
+}
+
+func (s *subscriptionRepo) UpdateSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
+	if groupId != subscription.GroupID {
+		return datastore.ErrNotAuthorisedToAccessDocument
+	}
+
+	subscription.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	update := bson.M{
+		"name":             subscription.Name,
+		"source_id":        subscription.SourceID,
+		"endpoint_id":      subscription.EndpointID,
+		"delivery_mode":    subscription.DeliveryMode,
+		"ordering_key":     subscription.OrderingKey,
+		"filter_config":    subscription.FilterConfig,
+		"alert_config":     subscription.AlertConfig,
+		"retry_config":     subscription.RetryConfig,
+		"transform_config": subscription.TransformConfig,
+		"updated_at":       subscription.UpdatedAt,
+	}
+
+	q := newQuery().uid(subscription.UID).eq("group_id", groupId).active()
+	return s.store.update(ctx, q, update)
+}
+
+func (s *subscriptionRepo) LoadSubscriptionsPaged(ctx context.Context, groupId string, f *datastore.FilterBy, pageable datastore.Pageable) ([]datastore.Subscription, datastore.PaginationData, error) {
+	q := newQuery().eq("group_id", groupId).active()
+	if !util.IsStringEmpty(f.AppID) {
+		q.eq("app_id", f.AppID)
+	}
+
+	var subscriptions []datastore.Subscription
+	paginationData, err := s.store.paginate(ctx, q, "created_at DESC, id DESC", pageable, &subscriptions)
+	if err != nil {
+		return nil, datastore.PaginationData{}, err
+	}
+
+	return subscriptions, paginationData, nil
+}
+
+func (s *subscriptionRepo) DeleteSubscription(ctx context.Context, groupId string, subscription *datastore.Subscription) error {
+	if groupId != subscription.GroupID {
+		return datastore.ErrNotAuthorisedToAccessDocument
+	}
+
+	return s.store.delete(ctx, newQuery().uid(subscription.UID).eq("group_id", groupId), false)
+}
+
+func (s *subscriptionRepo) FindSubscriptionByID(ctx context.Context, groupId string, uid string) (*datastore.Subscription, error) {
+	subscription := &datastore.Subscription{}
+
+	err := s.store.findOne(ctx, newQuery().uid(uid).eq("group_id", groupId).active(), "", subscription)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = datastore.ErrSubscriptionNotFound
+	}
+
+	return subscription, err
+}
+
+func (s *subscriptionRepo) FindSubscriptionsByEventType(ctx context.Context, groupId string, appId string, eventType datastore.EventType) ([]datastore.Subscription, error) {
+	q := newQuery().
+		eq("group_id", groupId).
+		eq("app_id", appId).
+		cond("jsonb_exists(document->'filter_config'->'event_types', %s)", string(eventType)).
+		active()
+
+	subscriptions := make([]datastore.Subscription, 0)
+	err := s.store.findMany(ctx, q, "", &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *subscriptionRepo) FindSubscriptionsByAppID(ctx context.Context, groupId string, appID string) ([]datastore.Subscription, error) {
+	subscriptions := make([]datastore.Subscription, 0)
+
+	err := s.store.findMany(ctx, newQuery().eq("app_id", appID).eq("group_id", groupId).active(), "", &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *subscriptionRepo) FindSubscriptionByDeviceID(ctx context.Context, groupId string, deviceID string) (*datastore.Subscription, error) {
+	subscription := &datastore.Subscription{}
+
+	err := s.store.findOne(ctx, newQuery().eq("device_id", deviceID).eq("group_id", groupId).active(), "", subscription)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, datastore.ErrSubscriptionNotFound
+	}
+
+	return subscription, err
+}
+
+func (s *subscriptionRepo) FindSubscriptionsBySourceIDs(ctx context.Context, groupId string, sourceId string) ([]datastore.Subscription, error) {
+	subscriptions := make([]datastore.Subscription, 0)
+
+	err := s.store.findMany(ctx, newQuery().eq("group_id", groupId).eq("source_id", sourceId).active(), "", &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (s *subscriptionRepo) UpdateSubscriptionStatus(ctx context.Context, groupId string, subscriptionId string, status datastore.SubscriptionStatus) error {
+	update := bson.M{
+		"status":     status,
+		"updated_at": primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	return s.store.update(ctx, newQuery().uid(subscriptionId).eq("group_id", groupId).active(), update)
+}
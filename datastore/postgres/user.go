@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const userEmailIndex = "users_email_unique"
+
+type userRepo struct {
+	store *store
+}
+
+func NewUserRepo(db *sql.DB) datastore.UserRepository {
+	return &userRepo{store: newStore(db, UserTable)}
+}
+
+func (u *userRepo) CreateUser(ctx context.Context, user *datastore.User) error {
+	user.ID = primitive.NewObjectID()
+	user.ResetPasswordToken = uuid.NewString()
+
+	err := u.store.insert(ctx, user.UID, user.CreatedAt, user)
+	if isDuplicate(err, userEmailIndex) {
+		return datastore.ErrDuplicateEmail
+	}
+
+	return err
+}
+
+func (u *userRepo) FindUserByEmail(ctx context.Context, email string) (*datastore.User, error) {
+	return u.findUser(ctx, newQuery().eq("email", email).active())
+}
+
+func (u *userRepo) FindUserByID(ctx context.Context, id string) (*datastore.User, error) {
+	return u.findUser(ctx, newQuery().uid(id).active())
+}
+
+func (u *userRepo) FindUserByToken(ctx context.Context, token string) (*datastore.User, error) {
+	return u.findUser(ctx, newQuery().eq("reset_password_token", token).active())
+}
+
+func (u *userRepo) findUser(ctx context.Context, q *query) (*datastore.User, error) {
+	user := &datastore.User{}
+
+	err := u.store.findOne(ctx, q, "", user)
+	if errors.Is(err, sql.ErrNoRows) {
+		return user, datastore.ErrUserNotFound
+	}
+
+	return user, err
+}
+
+func (u *userRepo) LoadUsersPaged(ctx context.Context, pageable datastore.Pageable) ([]datastore.User, datastore.PaginationData, error) {
+	users := make([]datastore.User, 0)
+
+	paginationData, err := u.store.paginate(ctx, newQuery().active(), "created_at DESC, id DESC", pageable, &users)
+	if err != nil {
+		return users, datastore.PaginationData{}, err
+	}
+
+	return users, paginationData, nil
+}
+
+func (u *userRepo) UpdateUser(ctx context.Context, user *datastore.User) error {
+	update := bson.M{
+		"first_name":                user.FirstName,
+		"last_name":                 user.LastName,
+		"email":                     user.Email,
+		"password":                  user.Password,
+		"updated_at":                primitive.NewDateTimeFromTime(time.Now()),
+		"reset_password_token":      user.ResetPasswordToken,
+		"reset_password_expires_at": user.ResetPasswordExpiresAt,
+	}
+
+	err := u.store.update(ctx, newQuery().uid(user.UID), update)
+	if isDuplicate(err, userEmailIndex) {
+		return datastore.ErrDuplicateEmail
+	}
+
+	return err
+}
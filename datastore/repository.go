@@ -28,6 +28,19 @@ type EventDeliveryRepository interface {
 	CountEventDeliveries(context.Context, string, string, string, []EventDeliveryStatus, SearchParams) (int64, error)
 	DeleteGroupEventDeliveries(ctx context.Context, filter *EventDeliveryFilter, hardDelete bool) error
 	LoadEventDeliveriesPaged(context.Context, string, string, string, []EventDeliveryStatus, SearchParams, Pageable) ([]EventDelivery, PaginationData, error)
+
+	FindOrderingHead(ctx context.Context, orderingKey string) (*EventDelivery, error)
+	BlockEventDelivery(ctx context.Context, e EventDelivery, headID string) error
+	LoadOrderingQueues(ctx context.Context, subscriptionID string) ([]OrderingQueue, error)
+}
+
+type DeadLetterRepository interface {
+	CreateDeadLetter(context.Context, *DeadLetter) error
+	FindDeadLetterByID(ctx context.Context, groupID string, id string) (*DeadLetter, error)
+	FindDeadLettersByIDs(ctx context.Context, groupID string, ids []string) ([]DeadLetter, error)
+	FindDeadLetterByEventDeliveryID(ctx context.Context, eventDeliveryID string) (*DeadLetter, error)
+	LoadDeadLettersPaged(context.Context, *DeadLetterFilter, Pageable) ([]DeadLetter, PaginationData, error)
+	DeleteDeadLetters(ctx context.Context, groupID string, ids []string) error
 }
 
 type EventRepository interface {
@@ -89,6 +102,8 @@ type ApplicationRepository interface {
 	SearchApplicationsByGroupId(context.Context, string, SearchParams) ([]Application, error)
 	FindApplicationEndpointByID(context.Context, string, string) (*Endpoint, error)
 	CreateApplicationEndpoint(context.Context, string, string, *Endpoint) error
+	FindApplicationsByOwnerID(ctx context.Context, groupID string, ownerID string) ([]Application, error)
+	FindApplicationsByTags(ctx context.Context, groupID string, tags []string) ([]Application, error)
 }
 
 type SubscriptionRepository interface {
@@ -110,6 +125,8 @@ type SourceRepository interface {
 	FindSourceByID(ctx context.Context, groupID string, id string) (*Source, error)
 	FindSourceByMaskID(ctx context.Context, maskID string) (*Source, error)
 	DeleteSourceByID(ctx context.Context, groupID string, id string) error
+	UpdateSourceResumeToken(ctx context.Context, groupID string, id string, token string) error
+	UpdateSourceRestApiCursor(ctx context.Context, groupID string, id string, cursor *RestApiCursor) error
 	LoadSourcesPaged(ctx context.Context, groupID string, filter *SourceFilter, pageable Pageable) ([]Source, PaginationData, error)
 }
 
@@ -137,3 +154,26 @@ type ConfigurationRepository interface {
 	LoadConfiguration(context.Context) (*Configuration, error)
 	UpdateConfiguration(context.Context, *Configuration) error
 }
+
+// Database is implemented by every storage backend, it exposes the
+// repositories the rest of convoy works with.
+type Database interface {
+	GetName() string
+	Client() interface{}
+	Disconnect(context.Context) error
+
+	APIRepo() APIKeyRepository
+	GroupRepo() GroupRepository
+	AppRepo() ApplicationRepository
+	DeviceRepo() DeviceRepository
+	EventRepo() EventRepository
+	EventDeliveryRepo() EventDeliveryRepository
+	SubRepo() SubscriptionRepository
+	SourceRepo() SourceRepository
+	OrganisationRepo() OrganisationRepository
+	OrganisationMemberRepo() OrganisationMemberRepository
+	OrganisationInviteRepo() OrganisationInviteRepository
+	UserRepo() UserRepository
+	ConfigurationRepo() ConfigurationRepository
+	DeadLetterRepo() DeadLetterRepository
+}
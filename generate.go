@@ -4,6 +4,7 @@ package convoy
 //go:generate mockgen --source queue/queue.go --destination mocks/queue.go -package mocks
 //go:generate mockgen --source tracer/tracer.go --destination mocks/tracer.go -package mocks
 //go:generate mockgen --source limiter/limiter.go --destination mocks/limiter.go -package mocks
+//go:generate mockgen --source circuitbreaker/circuitbreaker.go --destination mocks/circuit_breaker.go -package mocks
 //go:generate mockgen --source cache/cache.go --destination mocks/cache.go -package mocks
 //go:generate mockgen --source internal/pkg/searcher/searcher.go --destination mocks/searcher.go -package mocks
 //go:generate mockgen --source internal/pkg/smtp/smtp.go --destination mocks/smtp.go -package mocks
@@ -21,10 +21,11 @@ const (
 type TemplateName string
 
 const (
-	TemplateEndpointUpdate     TemplateName = "endpoint.update"
-	TemplateOrganisationInvite TemplateName = "organisation.invite"
-	TemplateResetPassword      TemplateName = "reset.password"
-	TemplateTwitterSource      TemplateName = "twitter.source"
+	TemplateEndpointUpdate       TemplateName = "endpoint.update"
+	TemplateOrganisationInvite   TemplateName = "organisation.invite"
+	TemplateResetPassword        TemplateName = "reset.password"
+	TemplateTwitterSource        TemplateName = "twitter.source"
+	TemplateCircuitBreakerUpdate TemplateName = "circuit.breaker.update"
 )
 
 func (t TemplateName) String() string {
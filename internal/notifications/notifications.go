@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/circuitbreaker"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/email"
 	"github.com/frain-dev/convoy/queue"
@@ -85,6 +86,73 @@ func SendEndpointNotification(ctx context.Context,
 			log.Error("Invalid notification type")
 			continue
 		}
+	}
+
+	writeNotifications(ns, q)
+	return nil
+}
+
+// SendCircuitBreakerNotification notifies the application's support channels
+// when the circuit breaker of one of its endpoints opens or closes.
+func SendCircuitBreakerNotification(ctx context.Context,
+	app *datastore.Application,
+	endpoint *datastore.Endpoint,
+	group *datastore.Group,
+	state circuitbreaker.State,
+	q queue.Queuer,
+) error {
+	var ns []*Notification
+
+	if !util.IsStringEmpty(app.SupportEmail) {
+		ns = append(ns, &Notification{NotificationType: EmailNotificationType})
+	}
+
+	if !util.IsStringEmpty(app.SlackWebhookURL) {
+		ns = append(ns, &Notification{NotificationType: SlackNotificationType})
+	}
+
+	for _, v := range ns {
+		switch v.NotificationType {
+		case EmailNotificationType:
+			v.Payload = email.Message{
+				Email:        app.SupportEmail,
+				Subject:      "Endpoint Circuit Breaker Update",
+				TemplateName: email.TemplateCircuitBreakerUpdate,
+				Params: map[string]string{
+					"logo_url":              group.LogoURL,
+					"target_url":            endpoint.TargetURL,
+					"circuit_breaker_state": string(state),
+				},
+			}
+		case SlackNotificationType:
+			payload := SlackNotification{
+				WebhookURL: app.SlackWebhookURL,
+			}
+
+			var text string
+			if state == circuitbreaker.StateOpen {
+				text = fmt.Sprintf("the circuit breaker of endpoint url (%s) has been opened after repeated delivery failures, event deliveries are paused", endpoint.TargetURL)
+			} else {
+				text = fmt.Sprintf("the circuit breaker of endpoint url (%s) has been closed, event deliveries have resumed", endpoint.TargetURL)
+			}
+
+			payload.Text = text
+			v.Payload = payload
+		default:
+			log.Error("Invalid notification type")
+			continue
+		}
+	}
+
+	writeNotifications(ns, q)
+	return nil
+}
+
+func writeNotifications(ns []*Notification, q queue.Queuer) {
+	for _, v := range ns {
+		if v.Payload == nil {
+			continue
+		}
 
 		buf, err := json.Marshal(v)
 		if err != nil {
@@ -102,6 +170,4 @@ func SendEndpointNotification(ctx context.Context,
 			log.WithError(err).Error("Failed to write new notification to the queue")
 		}
 	}
-
-	return nil
 }
@@ -15,8 +15,9 @@ import (
 
 var reg *prometheus.Registry
 var requestDuration *prometheus.HistogramVec
+var dispatcherConnections *prometheus.CounterVec
 
-var re, rd sync.Once
+var re, rd, dc sync.Once
 
 func Reg() *prometheus.Registry {
 	re.Do(func() {
@@ -28,8 +29,8 @@ func Reg() *prometheus.Registry {
 
 // Reset is only intended for use in tests
 func Reset() {
-	requestDuration, reg = nil, nil
-	re, rd = sync.Once{}, sync.Once{}
+	requestDuration, reg, dispatcherConnections = nil, nil, nil
+	re, rd, dc = sync.Once{}, sync.Once{}, sync.Once{}
 	prometheus.DefaultRegisterer = prometheus.NewRegistry()
 }
 
@@ -45,6 +46,24 @@ func RequestDuration() *prometheus.HistogramVec {
 	return requestDuration
 }
 
+// DispatcherConnections counts the connections deliveries are sent on, by
+// whether they were reused from the dispatcher's connection pool.
+func DispatcherConnections() *prometheus.CounterVec {
+	dc.Do(func() {
+		dispatcherConnections = prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "dispatcher",
+			Name:      "connections_total",
+			Help:      "Number of connections deliveries were sent on, by whether they were reused.",
+		}, []string{"reused"})
+	})
+
+	return dispatcherConnections
+}
+
+func RegisterDispatcherMetrics() {
+	Reg().MustRegister(DispatcherConnections())
+}
+
 func RegisterQueueMetrics(q queue.Queuer) {
 	Reg().MustRegister(
 		metrics.NewQueueMetricsCollector(q.(*redisqueue.RedisQueue).Inspector()),
@@ -27,6 +27,7 @@ import (
 	"github.com/frain-dev/convoy/auth/realm_chain"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	convoyNet "github.com/frain-dev/convoy/net"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
 
@@ -358,6 +359,28 @@ func ParseEndpointFromBody(r *http.Request) (models.Endpoint, error) {
 		return e, err
 	}
 
+	cfg, err := config.Get()
+	if err != nil {
+		return e, err
+	}
+
+	policy, err := convoyNet.NewEgressPolicy(cfg.Egress)
+	if err != nil {
+		return e, err
+	}
+
+	err = policy.CheckURL(r.Context(), e.URL)
+	if err != nil {
+		return e, err
+	}
+
+	if e.Authentication != nil && e.Authentication.OAuth2 != nil {
+		err = policy.CheckURL(r.Context(), e.Authentication.OAuth2.TokenURL)
+		if err != nil {
+			return e, err
+		}
+	}
+
 	return e, nil
 }
 
@@ -0,0 +1,115 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	amqp "github.com/rabbitmq/amqp091-go"
+	log "github.com/sirupsen/logrus"
+)
+
+type amqpConsumer struct {
+	source  *datastore.Source
+	handler Handler
+}
+
+// Start consumes the source's queue with manual acks, the prefetch count is
+// the source's worker count so at most that many messages are in flight.
+// Failed messages are requeued, invalid messages are rejected.
+func (a *amqpConsumer) Start(ctx context.Context) error {
+	cfg := a.source.PubSub.AMQP
+
+	conn, err := amqp.Dial(cfg.DSN)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	workers := a.source.PubSub.WorkerCount()
+	if err = ch.Qos(workers, 0, false); err != nil {
+		return err
+	}
+
+	if !util.IsStringEmpty(cfg.Exchange) {
+		err = ch.QueueBind(cfg.Queue, cfg.RoutingKey, cfg.Exchange, false, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	name := consumerName()
+	deliveries, err := ch.Consume(cfg.Queue, name, false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range deliveries {
+				a.process(ctx, d)
+			}
+		}()
+	}
+
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+
+	select {
+	case <-ctx.Done():
+		// let the workers finish the deliveries already received.
+		err = ch.Cancel(name, false)
+		wg.Wait()
+		return err
+	case e := <-closed:
+		wg.Wait()
+		if e == nil {
+			return errors.New("amqp connection closed")
+		}
+		return e
+	}
+}
+
+func (a *amqpConsumer) process(ctx context.Context, d amqp.Delivery) {
+	err := a.handler(ctx, a.source, amqpMessage(d))
+	if err == nil {
+		if err = d.Ack(false); err != nil {
+			log.WithError(err).Errorf("failed to ack message %s of source %s", d.MessageId, a.source.UID)
+		}
+		return
+	}
+
+	requeue := !errors.Is(err, ErrInvalidMessage)
+	log.WithError(err).Errorf("failed to process message %s of source %s", d.MessageId, a.source.UID)
+
+	if err = d.Nack(false, requeue); err != nil {
+		log.WithError(err).Errorf("failed to nack message %s of source %s", d.MessageId, a.source.UID)
+	}
+}
+
+func amqpMessage(d amqp.Delivery) *Message {
+	msg := &Message{
+		ID:        d.MessageId,
+		EventType: d.Type,
+		Data:      d.Body,
+		Headers:   http.Header{},
+	}
+
+	for k, v := range d.Headers {
+		msg.Headers.Set(k, fmt.Sprint(v))
+	}
+
+	return msg
+}
@@ -0,0 +1,150 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/frain-dev/convoy/datastore"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// operations maps the change stream operation types to event type suffixes.
+var operations = map[string]string{
+	"insert":  "created",
+	"update":  "updated",
+	"replace": "updated",
+	"delete":  "deleted",
+}
+
+type changeStreamConsumer struct {
+	source  *datastore.Source
+	handler Handler
+}
+
+func newChangeStreamConsumer(source *datastore.Source, handler Handler) (Consumer, error) {
+	if source.ChangeStream == nil {
+		return nil, errors.New("source has no change stream config")
+	}
+
+	switch source.ChangeStream.Type {
+	case datastore.MongoChangeStream:
+		return &changeStreamConsumer{source: source, handler: handler}, nil
+	default:
+		return nil, fmt.Errorf("unsupported change stream type %s", source.ChangeStream.Type)
+	}
+}
+
+// Start tails the collection after the source's resume token, the token
+// is advanced only after a change has been handled so a restart replays
+// every change that didn't make it to the event queue.
+func (c *changeStreamConsumer) Start(ctx context.Context) error {
+	cfg := c.source.ChangeStream
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.DSN))
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+
+	ops := make([]string, 0, len(operations))
+	for op := range operations {
+		ops = append(ops, op)
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"operationType": bson.M{"$in": ops}}}}}
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if c.source.ResumeToken != "" {
+		opts.SetResumeAfter(bson.M{"_data": c.source.ResumeToken})
+	}
+
+	stream, err := client.Database(cfg.Database).Collection(cfg.Collection).Watch(ctx, pipeline, opts)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		token, ok := stream.ResumeToken().Lookup("_data").StringValueOK()
+		if !ok {
+			return errors.New("change stream resume token has no _data field")
+		}
+
+		msg, err := changeMessage(stream.Current)
+		if err != nil {
+			return err
+		}
+		msg.ID, msg.ResumeToken = token, token
+
+		err = c.handler(ctx, c.source, msg)
+		if err != nil && !errors.Is(err, ErrInvalidMessage) {
+			return err
+		}
+
+		c.source.ResumeToken = token
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return stream.Err()
+}
+
+type changeEvent struct {
+	OperationType string `bson:"operationType"`
+	Namespace     struct {
+		Collection string `bson:"coll"`
+	} `bson:"ns"`
+	DocumentKey       bson.Raw `bson:"documentKey"`
+	FullDocument      bson.Raw `bson:"fullDocument"`
+	UpdateDescription *struct {
+		UpdatedFields bson.Raw `bson:"updatedFields"`
+		RemovedFields []string `bson:"removedFields"`
+	} `bson:"updateDescription"`
+}
+
+// changeMessage converts a change event to a message with an event type
+// like users.updated, the data holds the changed document as relaxed
+// extended json.
+func changeMessage(raw bson.Raw) (*Message, error) {
+	var change changeEvent
+	if err := bson.Unmarshal(raw, &change); err != nil {
+		return nil, err
+	}
+
+	operation := operations[change.OperationType]
+
+	data := bson.D{
+		{Key: "operation", Value: operation},
+		{Key: "collection", Value: change.Namespace.Collection},
+		{Key: "document_key", Value: change.DocumentKey},
+	}
+
+	if len(change.FullDocument) > 0 {
+		data = append(data, bson.E{Key: "document", Value: change.FullDocument})
+	}
+
+	if u := change.UpdateDescription; u != nil {
+		if len(u.UpdatedFields) > 0 {
+			data = append(data, bson.E{Key: "updated_fields", Value: u.UpdatedFields})
+		}
+		data = append(data, bson.E{Key: "removed_fields", Value: u.RemovedFields})
+	}
+
+	b, err := bson.MarshalExtJSON(data, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{
+		EventType: fmt.Sprintf("%s.%s", change.Namespace.Collection, operation),
+		Data:      b,
+		Headers:   http.Header{},
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,64 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_changeMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		change        bson.M
+		wantEventType string
+		wantData      string
+	}{
+		{
+			name: "should_map_insert",
+			change: bson.M{
+				"operationType": "insert",
+				"ns":            bson.M{"db": "app", "coll": "users"},
+				"documentKey":   bson.M{"_id": "1"},
+				"fullDocument":  bson.M{"_id": "1", "name": "convoy"},
+			},
+			wantEventType: "users.created",
+			wantData:      `{"operation":"created","collection":"users","document_key":{"_id":"1"},"document":{"_id":"1","name":"convoy"}}`,
+		},
+		{
+			name: "should_map_update",
+			change: bson.M{
+				"operationType":     "update",
+				"ns":                bson.M{"db": "app", "coll": "users"},
+				"documentKey":       bson.M{"_id": "1"},
+				"fullDocument":      bson.M{"_id": "1", "name": "frain"},
+				"updateDescription": bson.M{"updatedFields": bson.M{"name": "frain"}, "removedFields": bson.A{"email"}},
+			},
+			wantEventType: "users.updated",
+			wantData:      `{"operation":"updated","collection":"users","document_key":{"_id":"1"},"document":{"_id":"1","name":"frain"},"updated_fields":{"name":"frain"},"removed_fields":["email"]}`,
+		},
+		{
+			name: "should_map_delete",
+			change: bson.M{
+				"operationType": "delete",
+				"ns":            bson.M{"db": "app", "coll": "users"},
+				"documentKey":   bson.M{"_id": "1"},
+			},
+			wantEventType: "users.deleted",
+			wantData:      `{"operation":"deleted","collection":"users","document_key":{"_id":"1"}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := bson.Marshal(tc.change)
+			require.NoError(t, err)
+
+			msg, err := changeMessage(raw)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wantEventType, msg.EventType)
+			require.JSONEq(t, tc.wantData, string(msg.Data))
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/queue"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	syncInterval = time.Minute
+	retryDelay   = 5 * time.Second
+	perPage      = 100
+)
+
+// Ingester runs a consumer for every enabled pub_sub and db_change_stream
+// source, it reloads the sources periodically to pick up created, updated
+// and deleted sources. Change streams don't share work between consumers,
+// so with a leaser a change stream is only consumed by the worker holding
+// its source's lease.
+type Ingester struct {
+	sourceRepo  datastore.SourceRepository
+	handler     Handler
+	leaser      Leaser
+	interval    time.Duration
+	newConsumer func(*datastore.Source, Handler) (Consumer, error)
+	consumers   map[string]*runningConsumer
+}
+
+type runningConsumer struct {
+	updatedAt primitive.DateTime
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+func (r *runningConsumer) stop() {
+	r.cancel()
+	<-r.done
+}
+
+// exited reports whether the consumer stopped on its own, e.g after losing its lease.
+func (r *runningConsumer) exited() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewIngester returns an ingester for the sources, change streams are
+// consumed by every worker when leaser is nil.
+func NewIngester(sourceRepo datastore.SourceRepository, q queue.Queuer, leaser Leaser) *Ingester {
+	return &Ingester{
+		sourceRepo:  sourceRepo,
+		handler:     NewEventHandler(q),
+		leaser:      leaser,
+		interval:    syncInterval,
+		newConsumer: NewConsumer,
+		consumers:   map[string]*runningConsumer{},
+	}
+}
+
+// Run blocks until ctx is cancelled, then stops every consumer.
+func (i *Ingester) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := i.sync(ctx); err != nil {
+			log.WithError(err).Error("failed to sync pub sub sources")
+		}
+
+		select {
+		case <-ctx.Done():
+			for uid, c := range i.consumers {
+				c.stop()
+				delete(i.consumers, uid)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (i *Ingester) sync(ctx context.Context) error {
+	sources, err := i.loadSources(ctx)
+	if err != nil {
+		return err
+	}
+
+	active := map[string]bool{}
+	for idx := range sources {
+		source := &sources[idx]
+		if source.IsDisabled {
+			continue
+		}
+
+		active[source.UID] = true
+
+		c, ok := i.consumers[source.UID]
+		if ok && c.updatedAt == source.UpdatedAt && !c.exited() {
+			continue
+		}
+
+		if ok {
+			c.stop()
+			delete(i.consumers, source.UID)
+		}
+
+		leased := i.needsLease(source)
+		if leased {
+			acquired, err := i.leaser.Acquire(ctx, sourceLeaseKey(source), leaseTTL)
+			if err != nil {
+				log.WithError(err).Errorf("failed to acquire the lease of source %s", source.UID)
+				continue
+			}
+
+			// another worker consumes the source.
+			if !acquired {
+				continue
+			}
+		}
+
+		consumer, err := i.newConsumer(source, i.handle)
+		if err != nil {
+			log.WithError(err).Errorf("failed to create consumer for source %s", source.UID)
+			if leased {
+				i.release(source)
+			}
+			continue
+		}
+
+		i.consumers[source.UID] = i.start(ctx, source, consumer, leased)
+	}
+
+	for uid, c := range i.consumers {
+		if !active[uid] {
+			c.stop()
+			delete(i.consumers, uid)
+		}
+	}
+
+	return nil
+}
+
+// handle runs the handler, then persists the message's
+// resume token so the source resumes after it.
+func (i *Ingester) handle(ctx context.Context, source *datastore.Source, msg *Message) error {
+	err := i.handler(ctx, source, msg)
+	if err != nil && !errors.Is(err, ErrInvalidMessage) {
+		return err
+	}
+
+	if msg.ResumeToken != "" {
+		if err := i.sourceRepo.UpdateSourceResumeToken(ctx, source.GroupID, source.UID, msg.ResumeToken); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// start runs the consumer until it is stopped, restarting it
+// whenever it loses its connection to the broker. A leased consumer
+// renews its lease while it runs and stops when the lease is lost.
+func (i *Ingester) start(ctx context.Context, source *datastore.Source, consumer Consumer, leased bool) *runningConsumer {
+	ctx, cancel := context.WithCancel(ctx)
+	c := &runningConsumer{updatedAt: source.UpdatedAt, cancel: cancel, done: make(chan struct{})}
+
+	if leased {
+		go i.renew(ctx, cancel, source)
+	}
+
+	go func() {
+		defer close(c.done)
+		if leased {
+			defer i.release(source)
+		}
+
+		for ctx.Err() == nil {
+			err := consumer.Start(ctx)
+			if err != nil {
+				log.WithError(err).Errorf("consumer of source %s stopped", source.UID)
+			}
+
+			sleep(ctx, retryDelay)
+		}
+	}()
+
+	return c
+}
+
+func (i *Ingester) needsLease(source *datastore.Source) bool {
+	return i.leaser != nil && source.Type == datastore.DBChangeStream
+}
+
+// renew keeps the source's lease until ctx is cancelled, it cancels
+// the consumer when the lease can't be renewed.
+func (i *Ingester) renew(ctx context.Context, cancel context.CancelFunc, source *datastore.Source) {
+	ticker := time.NewTicker(leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		acquired, err := i.leaser.Acquire(ctx, sourceLeaseKey(source), leaseTTL)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil || !acquired {
+			log.WithError(err).Errorf("lost the lease of source %s", source.UID)
+			cancel()
+			return
+		}
+	}
+}
+
+func (i *Ingester) release(source *datastore.Source) {
+	ctx, cancel := context.WithTimeout(context.Background(), retryDelay)
+	defer cancel()
+
+	if err := i.leaser.Release(ctx, sourceLeaseKey(source)); err != nil {
+		log.WithError(err).Errorf("failed to release the lease of source %s", source.UID)
+	}
+}
+
+func sourceLeaseKey(source *datastore.Source) string {
+	return convoy.SourceLeasesCacheKey.Get(source.UID).String()
+}
+
+func (i *Ingester) loadSources(ctx context.Context) ([]datastore.Source, error) {
+	var sources []datastore.Source
+
+	for _, sourceType := range []datastore.SourceType{datastore.PubSubSource, datastore.DBChangeStream} {
+		f := &datastore.SourceFilter{Type: string(sourceType)}
+
+		for page := 1; ; page++ {
+			s, _, err := i.sourceRepo.LoadSourcesPaged(ctx, "", f, datastore.Pageable{Page: page, PerPage: perPage})
+			if err != nil {
+				return nil, err
+			}
+
+			sources = append(sources, s...)
+			if len(s) < perPage {
+				break
+			}
+		}
+	}
+
+	return sources, nil
+}
@@ -0,0 +1,226 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeConsumer struct {
+	mu      sync.Mutex
+	running bool
+}
+
+func (f *fakeConsumer) Start(ctx context.Context) error {
+	f.setRunning(true)
+	<-ctx.Done()
+	f.setRunning(false)
+	return nil
+}
+
+func (f *fakeConsumer) setRunning(running bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.running = running
+}
+
+func (f *fakeConsumer) isRunning() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.running
+}
+
+func pubSubSource(uid string, updatedAt time.Time) datastore.Source {
+	return datastore.Source{
+		UID:       uid,
+		Type:      datastore.PubSubSource,
+		UpdatedAt: primitive.NewDateTimeFromTime(updatedAt),
+		PubSub: &datastore.PubSubConfig{
+			Type:         datastore.RedisStreamsPubSub,
+			RedisStreams: &datastore.RedisStreamsPubSubConfig{},
+		},
+	}
+}
+
+func TestIngester_sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+
+	var consumers []*fakeConsumer
+	i := NewIngester(sourceRepo, nil, nil)
+	i.newConsumer = func(source *datastore.Source, handler Handler) (Consumer, error) {
+		c := &fakeConsumer{}
+		consumers = append(consumers, c)
+		return c, nil
+	}
+
+	now := time.Now()
+	disabled := pubSubSource("source-3", now)
+	disabled.IsDisabled = true
+
+	f := &datastore.SourceFilter{Type: string(datastore.PubSubSource)}
+	changeStreams := &datastore.SourceFilter{Type: string(datastore.DBChangeStream)}
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", changeStreams, datastore.Pageable{Page: 1, PerPage: perPage}).
+		Times(3).Return([]datastore.Source{}, datastore.PaginationData{}, nil)
+
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", f, datastore.Pageable{Page: 1, PerPage: perPage}).
+		Return([]datastore.Source{pubSubSource("source-1", now), pubSubSource("source-2", now), disabled}, datastore.PaginationData{}, nil)
+
+	require.NoError(t, i.sync(ctx))
+	require.Len(t, consumers, 2)
+	require.Len(t, i.consumers, 2)
+	require.Eventually(t, func() bool {
+		return consumers[0].isRunning() && consumers[1].isRunning()
+	}, time.Second, 10*time.Millisecond)
+
+	// source-1 was updated and source-2 was deleted.
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", f, datastore.Pageable{Page: 1, PerPage: perPage}).
+		Return([]datastore.Source{pubSubSource("source-1", now.Add(time.Minute))}, datastore.PaginationData{}, nil)
+
+	require.NoError(t, i.sync(ctx))
+	require.Len(t, consumers, 3)
+	require.Len(t, i.consumers, 1)
+	require.False(t, consumers[0].isRunning())
+	require.False(t, consumers[1].isRunning())
+	require.Eventually(t, consumers[2].isRunning, time.Second, 10*time.Millisecond)
+
+	// nothing changed.
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", f, datastore.Pageable{Page: 1, PerPage: perPage}).
+		Return([]datastore.Source{pubSubSource("source-1", now.Add(time.Minute))}, datastore.PaginationData{}, nil)
+
+	require.NoError(t, i.sync(ctx))
+	require.Len(t, consumers, 3)
+	require.True(t, consumers[2].isRunning())
+}
+
+func TestIngester_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+	q := mocks.NewMockQueuer(ctrl)
+
+	i := NewIngester(sourceRepo, q, nil)
+	source := &datastore.Source{UID: "source-1", GroupID: "group-1", Type: datastore.DBChangeStream}
+
+	// the resume token is only persisted once the event is queued.
+	q.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed"))
+	require.Error(t, i.handle(context.Background(), source, &Message{Data: []byte(`{}`), ResumeToken: "token-1"}))
+
+	q.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	sourceRepo.EXPECT().UpdateSourceResumeToken(gomock.Any(), "group-1", "source-1", "token-1").Return(nil)
+	require.NoError(t, i.handle(context.Background(), source, &Message{Data: []byte(`{}`), ResumeToken: "token-1"}))
+
+	// invalid messages are skipped.
+	sourceRepo.EXPECT().UpdateSourceResumeToken(gomock.Any(), "group-1", "source-1", "token-2").Return(nil)
+	require.ErrorIs(t, i.handle(context.Background(), source, &Message{Data: []byte(`-`), ResumeToken: "token-2"}), ErrInvalidMessage)
+}
+
+type fakeLeases struct {
+	mu     sync.Mutex
+	owners map[string]string
+}
+
+type fakeLeaser struct {
+	leases *fakeLeases
+	owner  string
+}
+
+func (f *fakeLeaser) Acquire(_ context.Context, key string, _ time.Duration) (bool, error) {
+	f.leases.mu.Lock()
+	defer f.leases.mu.Unlock()
+
+	owner, ok := f.leases.owners[key]
+	if ok && owner != f.owner {
+		return false, nil
+	}
+
+	f.leases.owners[key] = f.owner
+	return true, nil
+}
+
+func (f *fakeLeaser) Release(_ context.Context, key string) error {
+	f.leases.mu.Lock()
+	defer f.leases.mu.Unlock()
+
+	if f.leases.owners[key] == f.owner {
+		delete(f.leases.owners, key)
+	}
+	return nil
+}
+
+func TestIngester_sync_LeasesChangeStreams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+	leases := &fakeLeases{owners: map[string]string{}}
+
+	var consumers []*fakeConsumer
+	newIngester := func(owner string) *Ingester {
+		i := NewIngester(sourceRepo, nil, &fakeLeaser{leases: leases, owner: owner})
+		i.newConsumer = func(source *datastore.Source, handler Handler) (Consumer, error) {
+			c := &fakeConsumer{}
+			consumers = append(consumers, c)
+			return c, nil
+		}
+		return i
+	}
+
+	worker1, worker2 := newIngester("worker-1"), newIngester("worker-2")
+
+	now := time.Now()
+	changeStream := datastore.Source{
+		UID:          "source-1",
+		Type:         datastore.DBChangeStream,
+		UpdatedAt:    primitive.NewDateTimeFromTime(now),
+		ChangeStream: &datastore.ChangeStreamConfig{},
+	}
+
+	f := &datastore.SourceFilter{Type: string(datastore.PubSubSource)}
+	changeStreams := &datastore.SourceFilter{Type: string(datastore.DBChangeStream)}
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", f, datastore.Pageable{Page: 1, PerPage: perPage}).
+		AnyTimes().Return([]datastore.Source{}, datastore.PaginationData{}, nil)
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", changeStreams, datastore.Pageable{Page: 1, PerPage: perPage}).
+		Times(2).Return([]datastore.Source{changeStream}, datastore.PaginationData{}, nil)
+
+	// only the worker holding the lease consumes the change stream.
+	require.NoError(t, worker1.sync(ctx))
+	require.NoError(t, worker2.sync(ctx))
+	require.Len(t, consumers, 1)
+	require.Len(t, worker1.consumers, 1)
+	require.Empty(t, worker2.consumers)
+	require.Equal(t, "worker-1", leases.owners[sourceLeaseKey(&changeStream)])
+
+	// the lease is released when the source is deleted, another worker takes it over.
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", changeStreams, datastore.Pageable{Page: 1, PerPage: perPage}).
+		Times(1).Return([]datastore.Source{}, datastore.PaginationData{}, nil)
+
+	require.NoError(t, worker1.sync(ctx))
+	require.Empty(t, worker1.consumers)
+	require.False(t, consumers[0].isRunning())
+
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", changeStreams, datastore.Pageable{Page: 1, PerPage: perPage}).
+		Times(1).Return([]datastore.Source{changeStream}, datastore.PaginationData{}, nil)
+
+	require.NoError(t, worker2.sync(ctx))
+	require.Len(t, consumers, 2)
+	require.Len(t, worker2.consumers, 1)
+	require.Equal(t, "worker-2", leases.owners[sourceLeaseKey(&changeStream)])
+}
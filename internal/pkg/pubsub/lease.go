@@ -0,0 +1,63 @@
+package pubsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// leaseTTL is how long a worker holds a source's lease without renewing
+// it, another worker takes the source over when the lease expires.
+const leaseTTL = 30 * time.Second
+
+// Leaser grants a worker the exclusive lease of a source, sources whose
+// consumers don't share work between workers are only consumed by the
+// worker holding their lease.
+type Leaser interface {
+	// Acquire takes the lease of key, or renews it when this worker
+	// already holds it, it reports whether this worker holds the lease.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Release gives up the lease of key when this worker holds it.
+	Release(ctx context.Context, key string) error
+}
+
+var acquireScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+return 0`)
+
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// RedisLeaser keeps leases in redis, every leaser is a different owner.
+type RedisLeaser struct {
+	client *redis.Client
+	owner  string
+}
+
+func NewRedisLeaser(client *redis.Client) *RedisLeaser {
+	return &RedisLeaser{client: client, owner: uuid.NewString()}
+}
+
+func (r *RedisLeaser) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	n, err := acquireScript.Run(ctx, r.client, []string{key}, r.owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
+func (r *RedisLeaser) Release(ctx context.Context, key string) error {
+	return releaseScript.Run(ctx, r.client, []string{key}, r.owner).Err()
+}
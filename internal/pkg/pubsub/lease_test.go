@@ -0,0 +1,50 @@
+//go:build integration
+// +build integration
+
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RedisLeaser(t *testing.T) {
+	opts, err := redis.ParseURL(getDSN())
+	require.NoError(t, err)
+
+	client := redis.NewClient(opts)
+	defer client.Close()
+
+	ctx := context.Background()
+	require.NoError(t, client.Del(ctx, "source_leases:source-1").Err())
+
+	worker1, worker2 := NewRedisLeaser(client), NewRedisLeaser(client)
+
+	acquired, err := worker1.Acquire(ctx, "source_leases:source-1", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// the holder renews its lease, other workers can't take it.
+	acquired, err = worker1.Acquire(ctx, "source_leases:source-1", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	acquired, err = worker2.Acquire(ctx, "source_leases:source-1", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	// only the holder can release its lease.
+	require.NoError(t, worker2.Release(ctx, "source_leases:source-1"))
+	acquired, err = worker2.Acquire(ctx, "source_leases:source-1", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	require.NoError(t, worker1.Release(ctx, "source_leases:source-1"))
+	acquired, err = worker2.Acquire(ctx, "source_leases:source-1", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
@@ -0,0 +1,144 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrInvalidMessage is returned by a Handler for messages that can never
+// be processed, they are dropped instead of being redelivered.
+var ErrInvalidMessage = errors.New("invalid pub sub message")
+
+// Message is a message received from a source's broker.
+type Message struct {
+	ID        string
+	EventType string
+	Data      []byte
+	Headers   http.Header
+
+	// ResumeToken is set by change stream sources, it is
+	// persisted on the source once the message is handled.
+	ResumeToken string
+}
+
+// Handler processes a message, consumers acknowledge the
+// message only when the handler returns without an error.
+type Handler func(ctx context.Context, source *datastore.Source, msg *Message) error
+
+// Consumer reads the messages of a single source.
+type Consumer interface {
+	// Start consumes messages until ctx is cancelled
+	// or the connection to the broker is lost.
+	Start(ctx context.Context) error
+}
+
+// NewConsumer returns the consumer for the source's broker or database.
+func NewConsumer(source *datastore.Source, handler Handler) (Consumer, error) {
+	if source.Type == datastore.DBChangeStream {
+		return newChangeStreamConsumer(source, handler)
+	}
+
+	if source.PubSub == nil {
+		return nil, errors.New("source has no pub sub config")
+	}
+
+	switch source.PubSub.Type {
+	case datastore.RedisStreamsPubSub:
+		if source.PubSub.RedisStreams == nil {
+			return nil, errors.New("source has no redis streams config")
+		}
+		return &redisStreamsConsumer{source: source, handler: handler}, nil
+	case datastore.AMQPPubSub:
+		if source.PubSub.AMQP == nil {
+			return nil, errors.New("source has no amqp config")
+		}
+		return &amqpConsumer{source: source, handler: handler}, nil
+	default:
+		return nil, fmt.Errorf("unsupported pub sub type %s", source.PubSub.Type)
+	}
+}
+
+// NewEvent converts a message to an event of the source, the event
+// type defaults to the source's mask id like events ingested over http.
+func NewEvent(source *datastore.Source, msg *Message) (*datastore.Event, error) {
+	if !json.Valid(msg.Data) {
+		return nil, ErrInvalidMessage
+	}
+
+	eventType := msg.EventType
+	if util.IsStringEmpty(eventType) {
+		eventType = source.MaskID
+	}
+
+	event := &datastore.Event{
+		UID:            uuid.New().String(),
+		EventType:      datastore.EventType(eventType),
+		SourceID:       source.UID,
+		GroupID:        source.GroupID,
+		Data:           msg.Data,
+		Headers:        httpheader.HTTPHeader(msg.Headers),
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}
+
+	return event, nil
+}
+
+// NewEventHandler returns a Handler that writes each message
+// to the create event queue.
+func NewEventHandler(q queue.Queuer) Handler {
+	return func(ctx context.Context, source *datastore.Source, msg *Message) error {
+		event, err := NewEvent(source, msg)
+		if err != nil {
+			return err
+		}
+
+		eventByte, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		job := &queue.Job{
+			ID:      event.UID,
+			Payload: eventByte,
+			Delay:   0,
+		}
+
+		return q.Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
+	}
+}
+
+// consumerName identifies this process to the brokers.
+func consumerName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "convoy"
+	}
+
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// sleep waits for d, it returns early when ctx is cancelled.
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
@@ -0,0 +1,116 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewEvent(t *testing.T) {
+	source := &datastore.Source{UID: "source-1", GroupID: "group-1", MaskID: "mask-1"}
+
+	tests := []struct {
+		name          string
+		msg           *Message
+		wantEventType datastore.EventType
+		wantErr       error
+	}{
+		{
+			name:          "should_use_message_event_type",
+			msg:           &Message{EventType: "user.created", Data: []byte(`{"name":"convoy"}`)},
+			wantEventType: "user.created",
+		},
+		{
+			name:          "should_default_event_type_to_mask_id",
+			msg:           &Message{Data: []byte(`{"name":"convoy"}`)},
+			wantEventType: "mask-1",
+		},
+		{
+			name:    "should_reject_invalid_json",
+			msg:     &Message{Data: []byte(`name=convoy`)},
+			wantErr: ErrInvalidMessage,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := NewEvent(source, tc.msg)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotEmpty(t, event.UID)
+			require.Equal(t, tc.wantEventType, event.EventType)
+			require.Equal(t, source.UID, event.SourceID)
+			require.Equal(t, source.GroupID, event.GroupID)
+			require.Equal(t, datastore.ActiveDocumentStatus, event.DocumentStatus)
+			require.JSONEq(t, string(tc.msg.Data), string(event.Data))
+		})
+	}
+}
+
+func Test_NewEventHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	q := mocks.NewMockQueuer(ctrl)
+	source := &datastore.Source{UID: "source-1", GroupID: "group-1", MaskID: "mask-1"}
+
+	q.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).
+		DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+			event := &datastore.Event{}
+			require.NoError(t, json.Unmarshal(job.Payload, event))
+			require.Equal(t, job.ID, event.UID)
+			require.Equal(t, "source-1", event.SourceID)
+			return nil
+		})
+
+	handler := NewEventHandler(q)
+	require.NoError(t, handler(context.Background(), source, &Message{Data: []byte(`{}`)}))
+
+	q.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("failed"))
+	require.Error(t, handler(context.Background(), source, &Message{Data: []byte(`{}`)}))
+}
+
+func Test_redisMessage(t *testing.T) {
+	msg := redisMessage(redis.XMessage{
+		ID: "1-0",
+		Values: map[string]interface{}{
+			"data":         `{"name":"convoy"}`,
+			"event_type":   "user.created",
+			"x-request-id": "abc",
+		},
+	})
+
+	require.Equal(t, "1-0", msg.ID)
+	require.Equal(t, "user.created", msg.EventType)
+	require.Equal(t, `{"name":"convoy"}`, string(msg.Data))
+	require.Equal(t, "abc", msg.Headers.Get("X-Request-Id"))
+}
+
+func Test_amqpMessage(t *testing.T) {
+	msg := amqpMessage(amqp.Delivery{
+		MessageId: "1",
+		Type:      "user.created",
+		Body:      []byte(`{"name":"convoy"}`),
+		Headers:   amqp.Table{"x-retries": int32(2)},
+	})
+
+	require.Equal(t, "1", msg.ID)
+	require.Equal(t, "user.created", msg.EventType)
+	require.Equal(t, `{"name":"convoy"}`, string(msg.Data))
+	require.Equal(t, http.Header{"X-Retries": []string{"2"}}, msg.Headers)
+}
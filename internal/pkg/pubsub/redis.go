@@ -0,0 +1,153 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/rdb"
+	"github.com/go-redis/redis/v8"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// redisDataField and redisEventTypeField are the stream entry fields
+	// holding the event payload and type, every other field is a header.
+	redisDataField      = "data"
+	redisEventTypeField = "event_type"
+
+	redisBlock = time.Second
+)
+
+type redisStreamsConsumer struct {
+	source  *datastore.Source
+	handler Handler
+}
+
+// Start reads the stream as a member of the source's consumer group. Entries
+// are read in batches of the source's worker count, an entry is only acked
+// after it has been handled so failed entries stay pending and are read
+// again before any new entry.
+func (r *redisStreamsConsumer) Start(ctx context.Context) error {
+	cfg := r.source.PubSub.RedisStreams
+
+	rc, err := rdb.NewClient(cfg.DSN)
+	if err != nil {
+		return err
+	}
+
+	client := rc.Client()
+	defer client.Close()
+
+	err = client.XGroupCreateMkStream(ctx, cfg.Stream, cfg.ConsumerGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+
+	name := consumerName()
+	workers := r.source.PubSub.WorkerCount()
+
+	// "0" reads the entries delivered to this consumer but not acked yet.
+	id := "0"
+	for ctx.Err() == nil {
+		streams, err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    cfg.ConsumerGroup,
+			Consumer: name,
+			Streams:  []string{cfg.Stream, id},
+			Count:    int64(workers),
+			Block:    redisBlock,
+		}).Result()
+
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		var messages []redis.XMessage
+		for _, s := range streams {
+			messages = append(messages, s.Messages...)
+		}
+
+		if id == "0" && len(messages) == 0 {
+			id = ">"
+			continue
+		}
+
+		if failed := r.process(ctx, client, messages); failed {
+			id = "0"
+			sleep(ctx, redisBlock)
+		}
+	}
+
+	return nil
+}
+
+// process handles the messages concurrently, it reports whether
+// any message failed and has to be read again.
+func (r *redisStreamsConsumer) process(ctx context.Context, client *redis.Client, messages []redis.XMessage) bool {
+	cfg := r.source.PubSub.RedisStreams
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed bool
+	)
+
+	for _, m := range messages {
+		wg.Add(1)
+		go func(m redis.XMessage) {
+			defer wg.Done()
+
+			err := r.handler(ctx, r.source, redisMessage(m))
+			if err != nil && !errors.Is(err, ErrInvalidMessage) {
+				log.WithError(err).Errorf("failed to process message %s of source %s", m.ID, r.source.UID)
+
+				mu.Lock()
+				failed = true
+				mu.Unlock()
+				return
+			}
+
+			if err != nil {
+				log.WithError(err).Errorf("dropping message %s of source %s", m.ID, r.source.UID)
+			}
+
+			err = client.XAck(ctx, cfg.Stream, cfg.ConsumerGroup, m.ID).Err()
+			if err != nil {
+				log.WithError(err).Errorf("failed to ack message %s of source %s", m.ID, r.source.UID)
+			}
+		}(m)
+	}
+
+	wg.Wait()
+	return failed
+}
+
+func redisMessage(m redis.XMessage) *Message {
+	msg := &Message{ID: m.ID, Headers: http.Header{}}
+
+	for k, v := range m.Values {
+		s, _ := v.(string)
+
+		switch k {
+		case redisDataField:
+			msg.Data = []byte(s)
+		case redisEventTypeField:
+			msg.EventType = s
+		default:
+			msg.Headers.Set(k, s)
+		}
+	}
+
+	return msg
+}
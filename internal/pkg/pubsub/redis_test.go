@@ -0,0 +1,100 @@
+//go:build integration
+// +build integration
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func getDSN() string {
+	return os.Getenv("TEST_REDIS_DSN")
+}
+
+func Test_RedisStreamsConsumer(t *testing.T) {
+	dsn := getDSN()
+
+	opts, err := redis.ParseURL(dsn)
+	require.NoError(t, err)
+
+	client := redis.NewClient(opts)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, client.FlushAll(ctx).Err())
+
+	source := &datastore.Source{
+		UID:    "source-1",
+		MaskID: "mask-1",
+		PubSub: &datastore.PubSubConfig{
+			Type:    datastore.RedisStreamsPubSub,
+			Workers: 2,
+			RedisStreams: &datastore.RedisStreamsPubSubConfig{
+				DSN:           dsn,
+				Stream:        "events",
+				ConsumerGroup: "convoy",
+			},
+		},
+	}
+
+	for _, data := range []string{`{"id":1}`, `{"id":2}`, `not json`} {
+		err = client.XAdd(ctx, &redis.XAddArgs{Stream: "events", Values: map[string]interface{}{"data": data}}).Err()
+		require.NoError(t, err)
+	}
+
+	var (
+		mu       sync.Mutex
+		received []string
+		failed   bool
+	)
+
+	handler := func(ctx context.Context, s *datastore.Source, msg *Message) error {
+		if _, err := NewEvent(s, msg); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		// fail the first message once, it must be delivered again.
+		if string(msg.Data) == `{"id":1}` && !failed {
+			failed = true
+			return errors.New("failed")
+		}
+
+		received = append(received, string(msg.Data))
+		return nil
+	}
+
+	consumer, err := NewConsumer(source, handler)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() { done <- consumer.Start(ctx) }()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		pending, err := client.XPending(ctx, "events", "convoy").Result()
+		return err == nil && pending.Count == 0
+	}, 10*time.Second, 100*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	require.ElementsMatch(t, []string{`{"id":1}`, `{"id":2}`}, received)
+}
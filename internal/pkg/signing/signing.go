@@ -0,0 +1,210 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const rsaKeySize = 2048
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrInvalidPrivateKey    = errors.New("invalid private key")
+	ErrInvalidSignature     = errors.New("invalid signature")
+)
+
+// GenerateKey creates a key pair for the algorithm.
+func GenerateKey(alg datastore.SigningAlgorithm) (*datastore.SigningKey, error) {
+	var priv interface{}
+	var err error
+
+	switch alg {
+	case datastore.Ed25519SigningAlgorithm:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	case datastore.RSASigningAlgorithm:
+		priv, err = rsa.GenerateKey(rand.Reader, rsaKeySize)
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.SigningKey{
+		UID:        uuid.New().String(),
+		Algorithm:  alg,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		CreatedAt:  primitive.NewDateTimeFromTime(time.Now()),
+	}, nil
+}
+
+// Sign returns the signature of data, Ed25519 signs data as is while
+// RS256 signs its SHA-256 digest with PKCS #1 v1.5.
+func Sign(key *datastore.SigningKey, data []byte) ([]byte, error) {
+	priv, err := privateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := priv.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, data), nil
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256(data)
+		return rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+// SignatureHeader signs data and formats the signature header endpoints
+// receive, the key id selects the public key to verify it with.
+func SignatureHeader(key *datastore.SigningKey, data []byte) (string, error) {
+	sig, err := Sign(key, data)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("kid=%s,alg=%s,sig=%s", key.UID, key.Algorithm, base64.RawURLEncoding.EncodeToString(sig)), nil
+}
+
+func privateKey(key *datastore.SigningKey) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	switch signer.(type) {
+	case ed25519.PrivateKey:
+		if key.Algorithm != datastore.Ed25519SigningAlgorithm {
+			return nil, ErrInvalidPrivateKey
+		}
+	case *rsa.PrivateKey:
+		if key.Algorithm != datastore.RSASigningAlgorithm {
+			return nil, ErrInvalidPrivateKey
+		}
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	return signer, nil
+}
+
+// JSONWebKey is the public key of a signing key as described in RFC 7517,
+// Ed25519 keys are OKP keys as described in RFC 8037.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+
+	// Ed25519
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// NewJSONWebKeySet returns the public keys of the signing keys.
+func NewJSONWebKeySet(keys []datastore.SigningKey) (*JSONWebKeySet, error) {
+	set := &JSONWebKeySet{Keys: []JSONWebKey{}}
+
+	for i := range keys {
+		priv, err := privateKey(&keys[i])
+		if err != nil {
+			return nil, err
+		}
+
+		jwk := JSONWebKey{
+			KeyID:     keys[i].UID,
+			Use:       "sig",
+			Algorithm: string(keys[i].Algorithm),
+		}
+
+		switch pub := priv.Public().(type) {
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set, nil
+}
+
+// Verify checks the signature of data with the public key of the jwk.
+func Verify(jwk *JSONWebKey, data, sig []byte) error {
+	switch datastore.SigningAlgorithm(jwk.Algorithm) {
+	case datastore.Ed25519SigningAlgorithm:
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return ErrInvalidSignature
+		}
+
+		if !ed25519.Verify(ed25519.PublicKey(x), data, sig) {
+			return ErrInvalidSignature
+		}
+	case datastore.RSASigningAlgorithm:
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		digest := sha256.Sum256(data)
+		if err = rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			return ErrInvalidSignature
+		}
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package signing
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureHeader(t *testing.T) {
+	payload := []byte(`{"event":"payment.created"}`)
+
+	tests := map[string]struct {
+		alg     datastore.SigningAlgorithm
+		keyType string
+	}{
+		"ed25519": {alg: datastore.Ed25519SigningAlgorithm, keyType: "OKP"},
+		"rsa":     {alg: datastore.RSASigningAlgorithm, keyType: "RSA"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			key, err := GenerateKey(tc.alg)
+			require.NoError(t, err)
+			require.Equal(t, tc.alg, key.Algorithm)
+
+			header, err := SignatureHeader(key, payload)
+			require.NoError(t, err)
+
+			parts := strings.Split(header, ",")
+			require.Len(t, parts, 3)
+			require.Equal(t, "kid="+key.UID, parts[0])
+			require.Equal(t, "alg="+string(tc.alg), parts[1])
+
+			sig, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(parts[2], "sig="))
+			require.NoError(t, err)
+
+			jwks, err := NewJSONWebKeySet([]datastore.SigningKey{*key})
+			require.NoError(t, err)
+			require.Len(t, jwks.Keys, 1)
+
+			jwk := jwks.Keys[0]
+			require.Equal(t, key.UID, jwk.KeyID)
+			require.Equal(t, tc.keyType, jwk.KeyType)
+			require.Equal(t, "sig", jwk.Use)
+
+			require.NoError(t, Verify(&jwk, payload, sig))
+			require.ErrorIs(t, Verify(&jwk, []byte(`{"event":"payment.failed"}`), sig), ErrInvalidSignature)
+		})
+	}
+}
+
+func TestGenerateKey_UnsupportedAlgorithm(t *testing.T) {
+	_, err := GenerateKey("HS256")
+	require.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
+
+func TestSign_InvalidPrivateKey(t *testing.T) {
+	key, err := GenerateKey(datastore.Ed25519SigningAlgorithm)
+	require.NoError(t, err)
+
+	// the key's algorithm must match its private key
+	key.Algorithm = datastore.RSASigningAlgorithm
+	_, err = Sign(key, []byte(`{}`))
+	require.ErrorIs(t, err, ErrInvalidPrivateKey)
+
+	_, err = Sign(&datastore.SigningKey{Algorithm: datastore.Ed25519SigningAlgorithm, PrivateKey: "key"}, []byte(`{}`))
+	require.ErrorIs(t, err, ErrInvalidPrivateKey)
+}
@@ -76,3 +76,18 @@ func (mr *MockCacheMockRecorder) Set(ctx, key, data, expiration interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), ctx, key, data, expiration)
 }
+
+// SetIfNotExists mocks base method.
+func (m *MockCache) SetIfNotExists(ctx context.Context, key string, data interface{}, expiration time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIfNotExists", ctx, key, data, expiration)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIfNotExists indicates an expected call of SetIfNotExists.
+func (mr *MockCacheMockRecorder) SetIfNotExists(ctx, key, data, expiration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIfNotExists", reflect.TypeOf((*MockCache)(nil).SetIfNotExists), ctx, key, data, expiration)
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: circuitbreaker/circuitbreaker.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	circuitbreaker "github.com/frain-dev/convoy/circuitbreaker"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCircuitBreaker is a mock of CircuitBreaker interface.
+type MockCircuitBreaker struct {
+	ctrl     *gomock.Controller
+	recorder *MockCircuitBreakerMockRecorder
+}
+
+// MockCircuitBreakerMockRecorder is the mock recorder for MockCircuitBreaker.
+type MockCircuitBreakerMockRecorder struct {
+	mock *MockCircuitBreaker
+}
+
+// NewMockCircuitBreaker creates a new mock instance.
+func NewMockCircuitBreaker(ctrl *gomock.Controller) *MockCircuitBreaker {
+	mock := &MockCircuitBreaker{ctrl: ctrl}
+	mock.recorder = &MockCircuitBreakerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCircuitBreaker) EXPECT() *MockCircuitBreakerMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockCircuitBreaker) Allow(ctx context.Context, key string) (*circuitbreaker.Breaker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", ctx, key)
+	ret0, _ := ret[0].(*circuitbreaker.Breaker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockCircuitBreakerMockRecorder) Allow(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockCircuitBreaker)(nil).Allow), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockCircuitBreaker) Get(ctx context.Context, key string) (*circuitbreaker.Breaker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*circuitbreaker.Breaker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCircuitBreakerMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCircuitBreaker)(nil).Get), ctx, key)
+}
+
+// Record mocks base method.
+func (m *MockCircuitBreaker) Record(ctx context.Context, key string, success bool) (*circuitbreaker.Breaker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, key, success)
+	ret0, _ := ret[0].(*circuitbreaker.Breaker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockCircuitBreakerMockRecorder) Record(ctx, key, success interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockCircuitBreaker)(nil).Record), ctx, key, success)
+}
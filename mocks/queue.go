@@ -62,3 +62,17 @@ func (mr *MockQueuerMockRecorder) Write(arg0, arg1, arg2 interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockQueuer)(nil).Write), arg0, arg1, arg2)
 }
+
+// WriteMany mocks base method.
+func (m *MockQueuer) WriteMany(arg0 convoy.TaskName, arg1 convoy.QueueName, arg2 []*queue.Job) []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteMany", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// WriteMany indicates an expected call of WriteMany.
+func (mr *MockQueuerMockRecorder) WriteMany(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteMany", reflect.TypeOf((*MockQueuer)(nil).WriteMany), arg0, arg1, arg2)
+}
@@ -161,6 +161,20 @@ func (m *MockEventDeliveryRepository) EXPECT() *MockEventDeliveryRepositoryMockR
 	return m.recorder
 }
 
+// BlockEventDelivery mocks base method.
+func (m *MockEventDeliveryRepository) BlockEventDelivery(ctx context.Context, e datastore.EventDelivery, headID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockEventDelivery", ctx, e, headID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockEventDelivery indicates an expected call of BlockEventDelivery.
+func (mr *MockEventDeliveryRepositoryMockRecorder) BlockEventDelivery(ctx, e, headID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockEventDelivery", reflect.TypeOf((*MockEventDeliveryRepository)(nil).BlockEventDelivery), ctx, e, headID)
+}
+
 // CountDeliveriesByStatus mocks base method.
 func (m *MockEventDeliveryRepository) CountDeliveriesByStatus(arg0 context.Context, arg1 datastore.EventDeliveryStatus, arg2 datastore.SearchParams) (int64, error) {
 	m.ctrl.T.Helper()
@@ -279,6 +293,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) FindEventDeliveryByID(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEventDeliveryByID", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindEventDeliveryByID), arg0, arg1)
 }
 
+// FindOrderingHead mocks base method.
+func (m *MockEventDeliveryRepository) FindOrderingHead(ctx context.Context, orderingKey string) (*datastore.EventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrderingHead", ctx, orderingKey)
+	ret0, _ := ret[0].(*datastore.EventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrderingHead indicates an expected call of FindOrderingHead.
+func (mr *MockEventDeliveryRepositoryMockRecorder) FindOrderingHead(ctx, orderingKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrderingHead", reflect.TypeOf((*MockEventDeliveryRepository)(nil).FindOrderingHead), ctx, orderingKey)
+}
+
 // LoadEventDeliveriesPaged mocks base method.
 func (m *MockEventDeliveryRepository) LoadEventDeliveriesPaged(arg0 context.Context, arg1, arg2, arg3 string, arg4 []datastore.EventDeliveryStatus, arg5 datastore.SearchParams, arg6 datastore.Pageable) ([]datastore.EventDelivery, datastore.PaginationData, error) {
 	m.ctrl.T.Helper()
@@ -295,6 +324,21 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) LoadEventDeliveriesPaged(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadEventDeliveriesPaged", reflect.TypeOf((*MockEventDeliveryRepository)(nil).LoadEventDeliveriesPaged), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// LoadOrderingQueues mocks base method.
+func (m *MockEventDeliveryRepository) LoadOrderingQueues(ctx context.Context, subscriptionID string) ([]datastore.OrderingQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOrderingQueues", ctx, subscriptionID)
+	ret0, _ := ret[0].([]datastore.OrderingQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOrderingQueues indicates an expected call of LoadOrderingQueues.
+func (mr *MockEventDeliveryRepositoryMockRecorder) LoadOrderingQueues(ctx, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOrderingQueues", reflect.TypeOf((*MockEventDeliveryRepository)(nil).LoadOrderingQueues), ctx, subscriptionID)
+}
+
 // UpdateEventDeliveryWithAttempt mocks base method.
 func (m *MockEventDeliveryRepository) UpdateEventDeliveryWithAttempt(arg0 context.Context, arg1 datastore.EventDelivery, arg2 datastore.DeliveryAttempt) error {
 	m.ctrl.T.Helper()
@@ -337,6 +381,118 @@ func (mr *MockEventDeliveryRepositoryMockRecorder) UpdateStatusOfEventDelivery(a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusOfEventDelivery", reflect.TypeOf((*MockEventDeliveryRepository)(nil).UpdateStatusOfEventDelivery), arg0, arg1, arg2)
 }
 
+// MockDeadLetterRepository is a mock of DeadLetterRepository interface.
+type MockDeadLetterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterRepositoryMockRecorder
+}
+
+// MockDeadLetterRepositoryMockRecorder is the mock recorder for MockDeadLetterRepository.
+type MockDeadLetterRepositoryMockRecorder struct {
+	mock *MockDeadLetterRepository
+}
+
+// NewMockDeadLetterRepository creates a new mock instance.
+func NewMockDeadLetterRepository(ctrl *gomock.Controller) *MockDeadLetterRepository {
+	mock := &MockDeadLetterRepository{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterRepository) EXPECT() *MockDeadLetterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateDeadLetter mocks base method.
+func (m *MockDeadLetterRepository) CreateDeadLetter(arg0 context.Context, arg1 *datastore.DeadLetter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeadLetter", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeadLetter indicates an expected call of CreateDeadLetter.
+func (mr *MockDeadLetterRepositoryMockRecorder) CreateDeadLetter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeadLetter", reflect.TypeOf((*MockDeadLetterRepository)(nil).CreateDeadLetter), arg0, arg1)
+}
+
+// DeleteDeadLetters mocks base method.
+func (m *MockDeadLetterRepository) DeleteDeadLetters(ctx context.Context, groupID string, ids []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeadLetters", ctx, groupID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDeadLetters indicates an expected call of DeleteDeadLetters.
+func (mr *MockDeadLetterRepositoryMockRecorder) DeleteDeadLetters(ctx, groupID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeadLetters", reflect.TypeOf((*MockDeadLetterRepository)(nil).DeleteDeadLetters), ctx, groupID, ids)
+}
+
+// FindDeadLetterByEventDeliveryID mocks base method.
+func (m *MockDeadLetterRepository) FindDeadLetterByEventDeliveryID(ctx context.Context, eventDeliveryID string) (*datastore.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeadLetterByEventDeliveryID", ctx, eventDeliveryID)
+	ret0, _ := ret[0].(*datastore.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeadLetterByEventDeliveryID indicates an expected call of FindDeadLetterByEventDeliveryID.
+func (mr *MockDeadLetterRepositoryMockRecorder) FindDeadLetterByEventDeliveryID(ctx, eventDeliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeadLetterByEventDeliveryID", reflect.TypeOf((*MockDeadLetterRepository)(nil).FindDeadLetterByEventDeliveryID), ctx, eventDeliveryID)
+}
+
+// FindDeadLetterByID mocks base method.
+func (m *MockDeadLetterRepository) FindDeadLetterByID(ctx context.Context, groupID, id string) (*datastore.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeadLetterByID", ctx, groupID, id)
+	ret0, _ := ret[0].(*datastore.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeadLetterByID indicates an expected call of FindDeadLetterByID.
+func (mr *MockDeadLetterRepositoryMockRecorder) FindDeadLetterByID(ctx, groupID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeadLetterByID", reflect.TypeOf((*MockDeadLetterRepository)(nil).FindDeadLetterByID), ctx, groupID, id)
+}
+
+// FindDeadLettersByIDs mocks base method.
+func (m *MockDeadLetterRepository) FindDeadLettersByIDs(ctx context.Context, groupID string, ids []string) ([]datastore.DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeadLettersByIDs", ctx, groupID, ids)
+	ret0, _ := ret[0].([]datastore.DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeadLettersByIDs indicates an expected call of FindDeadLettersByIDs.
+func (mr *MockDeadLetterRepositoryMockRecorder) FindDeadLettersByIDs(ctx, groupID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeadLettersByIDs", reflect.TypeOf((*MockDeadLetterRepository)(nil).FindDeadLettersByIDs), ctx, groupID, ids)
+}
+
+// LoadDeadLettersPaged mocks base method.
+func (m *MockDeadLetterRepository) LoadDeadLettersPaged(arg0 context.Context, arg1 *datastore.DeadLetterFilter, arg2 datastore.Pageable) ([]datastore.DeadLetter, datastore.PaginationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDeadLettersPaged", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]datastore.DeadLetter)
+	ret1, _ := ret[1].(datastore.PaginationData)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LoadDeadLettersPaged indicates an expected call of LoadDeadLettersPaged.
+func (mr *MockDeadLetterRepositoryMockRecorder) LoadDeadLettersPaged(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDeadLettersPaged", reflect.TypeOf((*MockDeadLetterRepository)(nil).LoadDeadLettersPaged), arg0, arg1, arg2)
+}
+
 // MockEventRepository is a mock of EventRepository interface.
 type MockEventRepository struct {
 	ctrl     *gomock.Controller
@@ -1046,6 +1202,36 @@ func (mr *MockApplicationRepositoryMockRecorder) FindApplicationEndpointByID(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicationEndpointByID", reflect.TypeOf((*MockApplicationRepository)(nil).FindApplicationEndpointByID), arg0, arg1, arg2)
 }
 
+// FindApplicationsByOwnerID mocks base method.
+func (m *MockApplicationRepository) FindApplicationsByOwnerID(ctx context.Context, groupID, ownerID string) ([]datastore.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApplicationsByOwnerID", ctx, groupID, ownerID)
+	ret0, _ := ret[0].([]datastore.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApplicationsByOwnerID indicates an expected call of FindApplicationsByOwnerID.
+func (mr *MockApplicationRepositoryMockRecorder) FindApplicationsByOwnerID(ctx, groupID, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicationsByOwnerID", reflect.TypeOf((*MockApplicationRepository)(nil).FindApplicationsByOwnerID), ctx, groupID, ownerID)
+}
+
+// FindApplicationsByTags mocks base method.
+func (m *MockApplicationRepository) FindApplicationsByTags(ctx context.Context, groupID string, tags []string) ([]datastore.Application, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApplicationsByTags", ctx, groupID, tags)
+	ret0, _ := ret[0].([]datastore.Application)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApplicationsByTags indicates an expected call of FindApplicationsByTags.
+func (mr *MockApplicationRepositoryMockRecorder) FindApplicationsByTags(ctx, groupID, tags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicationsByTags", reflect.TypeOf((*MockApplicationRepository)(nil).FindApplicationsByTags), ctx, groupID, tags)
+}
+
 // LoadApplicationsPaged mocks base method.
 func (m *MockApplicationRepository) LoadApplicationsPaged(arg0 context.Context, arg1, arg2 string, arg3 datastore.Pageable) ([]datastore.Application, datastore.PaginationData, error) {
 	m.ctrl.T.Helper()
@@ -1388,6 +1574,34 @@ func (mr *MockSourceRepositoryMockRecorder) UpdateSource(ctx, groupID, source in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSource", reflect.TypeOf((*MockSourceRepository)(nil).UpdateSource), ctx, groupID, source)
 }
 
+// UpdateSourceRestApiCursor mocks base method.
+func (m *MockSourceRepository) UpdateSourceRestApiCursor(ctx context.Context, groupID, id string, cursor *datastore.RestApiCursor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSourceRestApiCursor", ctx, groupID, id, cursor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSourceRestApiCursor indicates an expected call of UpdateSourceRestApiCursor.
+func (mr *MockSourceRepositoryMockRecorder) UpdateSourceRestApiCursor(ctx, groupID, id, cursor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSourceRestApiCursor", reflect.TypeOf((*MockSourceRepository)(nil).UpdateSourceRestApiCursor), ctx, groupID, id, cursor)
+}
+
+// UpdateSourceResumeToken mocks base method.
+func (m *MockSourceRepository) UpdateSourceResumeToken(ctx context.Context, groupID, id, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSourceResumeToken", ctx, groupID, id, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSourceResumeToken indicates an expected call of UpdateSourceResumeToken.
+func (mr *MockSourceRepositoryMockRecorder) UpdateSourceResumeToken(ctx, groupID, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSourceResumeToken", reflect.TypeOf((*MockSourceRepository)(nil).UpdateSourceResumeToken), ctx, groupID, id, token)
+}
+
 // MockDeviceRepository is a mock of DeviceRepository interface.
 type MockDeviceRepository struct {
 	ctrl     *gomock.Controller
@@ -1690,3 +1904,264 @@ func (mr *MockConfigurationRepositoryMockRecorder) UpdateConfiguration(arg0, arg
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConfiguration", reflect.TypeOf((*MockConfigurationRepository)(nil).UpdateConfiguration), arg0, arg1)
 }
+
+// MockDatabase is a mock of Database interface.
+type MockDatabase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabaseMockRecorder
+}
+
+// MockDatabaseMockRecorder is the mock recorder for MockDatabase.
+type MockDatabaseMockRecorder struct {
+	mock *MockDatabase
+}
+
+// NewMockDatabase creates a new mock instance.
+func NewMockDatabase(ctrl *gomock.Controller) *MockDatabase {
+	mock := &MockDatabase{ctrl: ctrl}
+	mock.recorder = &MockDatabaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatabase) EXPECT() *MockDatabaseMockRecorder {
+	return m.recorder
+}
+
+// APIRepo mocks base method.
+func (m *MockDatabase) APIRepo() datastore.APIKeyRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIRepo")
+	ret0, _ := ret[0].(datastore.APIKeyRepository)
+	return ret0
+}
+
+// APIRepo indicates an expected call of APIRepo.
+func (mr *MockDatabaseMockRecorder) APIRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIRepo", reflect.TypeOf((*MockDatabase)(nil).APIRepo))
+}
+
+// AppRepo mocks base method.
+func (m *MockDatabase) AppRepo() datastore.ApplicationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppRepo")
+	ret0, _ := ret[0].(datastore.ApplicationRepository)
+	return ret0
+}
+
+// AppRepo indicates an expected call of AppRepo.
+func (mr *MockDatabaseMockRecorder) AppRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppRepo", reflect.TypeOf((*MockDatabase)(nil).AppRepo))
+}
+
+// Client mocks base method.
+func (m *MockDatabase) Client() interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Client")
+	ret0, _ := ret[0].(interface{})
+	return ret0
+}
+
+// Client indicates an expected call of Client.
+func (mr *MockDatabaseMockRecorder) Client() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Client", reflect.TypeOf((*MockDatabase)(nil).Client))
+}
+
+// ConfigurationRepo mocks base method.
+func (m *MockDatabase) ConfigurationRepo() datastore.ConfigurationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigurationRepo")
+	ret0, _ := ret[0].(datastore.ConfigurationRepository)
+	return ret0
+}
+
+// ConfigurationRepo indicates an expected call of ConfigurationRepo.
+func (mr *MockDatabaseMockRecorder) ConfigurationRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigurationRepo", reflect.TypeOf((*MockDatabase)(nil).ConfigurationRepo))
+}
+
+// DeadLetterRepo mocks base method.
+func (m *MockDatabase) DeadLetterRepo() datastore.DeadLetterRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetterRepo")
+	ret0, _ := ret[0].(datastore.DeadLetterRepository)
+	return ret0
+}
+
+// DeadLetterRepo indicates an expected call of DeadLetterRepo.
+func (mr *MockDatabaseMockRecorder) DeadLetterRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetterRepo", reflect.TypeOf((*MockDatabase)(nil).DeadLetterRepo))
+}
+
+// DeviceRepo mocks base method.
+func (m *MockDatabase) DeviceRepo() datastore.DeviceRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeviceRepo")
+	ret0, _ := ret[0].(datastore.DeviceRepository)
+	return ret0
+}
+
+// DeviceRepo indicates an expected call of DeviceRepo.
+func (mr *MockDatabaseMockRecorder) DeviceRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeviceRepo", reflect.TypeOf((*MockDatabase)(nil).DeviceRepo))
+}
+
+// Disconnect mocks base method.
+func (m *MockDatabase) Disconnect(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disconnect", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disconnect indicates an expected call of Disconnect.
+func (mr *MockDatabaseMockRecorder) Disconnect(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockDatabase)(nil).Disconnect), arg0)
+}
+
+// EventDeliveryRepo mocks base method.
+func (m *MockDatabase) EventDeliveryRepo() datastore.EventDeliveryRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventDeliveryRepo")
+	ret0, _ := ret[0].(datastore.EventDeliveryRepository)
+	return ret0
+}
+
+// EventDeliveryRepo indicates an expected call of EventDeliveryRepo.
+func (mr *MockDatabaseMockRecorder) EventDeliveryRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventDeliveryRepo", reflect.TypeOf((*MockDatabase)(nil).EventDeliveryRepo))
+}
+
+// EventRepo mocks base method.
+func (m *MockDatabase) EventRepo() datastore.EventRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventRepo")
+	ret0, _ := ret[0].(datastore.EventRepository)
+	return ret0
+}
+
+// EventRepo indicates an expected call of EventRepo.
+func (mr *MockDatabaseMockRecorder) EventRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventRepo", reflect.TypeOf((*MockDatabase)(nil).EventRepo))
+}
+
+// GetName mocks base method.
+func (m *MockDatabase) GetName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetName indicates an expected call of GetName.
+func (mr *MockDatabaseMockRecorder) GetName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetName", reflect.TypeOf((*MockDatabase)(nil).GetName))
+}
+
+// GroupRepo mocks base method.
+func (m *MockDatabase) GroupRepo() datastore.GroupRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupRepo")
+	ret0, _ := ret[0].(datastore.GroupRepository)
+	return ret0
+}
+
+// GroupRepo indicates an expected call of GroupRepo.
+func (mr *MockDatabaseMockRecorder) GroupRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupRepo", reflect.TypeOf((*MockDatabase)(nil).GroupRepo))
+}
+
+// OrganisationInviteRepo mocks base method.
+func (m *MockDatabase) OrganisationInviteRepo() datastore.OrganisationInviteRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganisationInviteRepo")
+	ret0, _ := ret[0].(datastore.OrganisationInviteRepository)
+	return ret0
+}
+
+// OrganisationInviteRepo indicates an expected call of OrganisationInviteRepo.
+func (mr *MockDatabaseMockRecorder) OrganisationInviteRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganisationInviteRepo", reflect.TypeOf((*MockDatabase)(nil).OrganisationInviteRepo))
+}
+
+// OrganisationMemberRepo mocks base method.
+func (m *MockDatabase) OrganisationMemberRepo() datastore.OrganisationMemberRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganisationMemberRepo")
+	ret0, _ := ret[0].(datastore.OrganisationMemberRepository)
+	return ret0
+}
+
+// OrganisationMemberRepo indicates an expected call of OrganisationMemberRepo.
+func (mr *MockDatabaseMockRecorder) OrganisationMemberRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganisationMemberRepo", reflect.TypeOf((*MockDatabase)(nil).OrganisationMemberRepo))
+}
+
+// OrganisationRepo mocks base method.
+func (m *MockDatabase) OrganisationRepo() datastore.OrganisationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganisationRepo")
+	ret0, _ := ret[0].(datastore.OrganisationRepository)
+	return ret0
+}
+
+// OrganisationRepo indicates an expected call of OrganisationRepo.
+func (mr *MockDatabaseMockRecorder) OrganisationRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganisationRepo", reflect.TypeOf((*MockDatabase)(nil).OrganisationRepo))
+}
+
+// SourceRepo mocks base method.
+func (m *MockDatabase) SourceRepo() datastore.SourceRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SourceRepo")
+	ret0, _ := ret[0].(datastore.SourceRepository)
+	return ret0
+}
+
+// SourceRepo indicates an expected call of SourceRepo.
+func (mr *MockDatabaseMockRecorder) SourceRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SourceRepo", reflect.TypeOf((*MockDatabase)(nil).SourceRepo))
+}
+
+// SubRepo mocks base method.
+func (m *MockDatabase) SubRepo() datastore.SubscriptionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubRepo")
+	ret0, _ := ret[0].(datastore.SubscriptionRepository)
+	return ret0
+}
+
+// SubRepo indicates an expected call of SubRepo.
+func (mr *MockDatabaseMockRecorder) SubRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubRepo", reflect.TypeOf((*MockDatabase)(nil).SubRepo))
+}
+
+// UserRepo mocks base method.
+func (m *MockDatabase) UserRepo() datastore.UserRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserRepo")
+	ret0, _ := ret[0].(datastore.UserRepository)
+	return ret0
+}
+
+// UserRepo indicates an expected call of UserRepo.
+func (mr *MockDatabaseMockRecorder) UserRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRepo", reflect.TypeOf((*MockDatabase)(nil).UserRepo))
+}
@@ -0,0 +1,206 @@
+package net
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+const (
+	// tokenExpirySkew renews cached tokens before they expire in transit.
+	tokenExpirySkew = 30 * time.Second
+
+	// defaultTokenTTL is used when the token response has no expires_in.
+	defaultTokenTTL = 5 * time.Minute
+)
+
+var ErrOAuth2TokenRequest = errors.New("failed to fetch oauth2 token")
+
+// OAuth2Token is the access token cached for an oauth2 configuration.
+type OAuth2Token struct {
+	AccessToken string
+	TokenType   string
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Authenticator sets the authorization header of deliveries to an endpoint.
+// OAuth2 tokens are fetched with the client credentials grant and cached
+// until they expire.
+type Authenticator struct {
+	auth   *datastore.EndpointAuthentication
+	cache  cache.Cache
+	client *http.Client
+
+	// policy checks the token url of proxied token requests.
+	policy *EgressPolicy
+}
+
+// NewAuthenticator returns an authenticator for auth, client makes the
+// oauth2 token requests.
+func NewAuthenticator(auth *datastore.EndpointAuthentication, c cache.Cache, client *http.Client) *Authenticator {
+	return &Authenticator{
+		auth:   auth,
+		cache:  c,
+		client: client,
+	}
+}
+
+// Refreshable reports whether a rejected request can be retried with a new token.
+func (a *Authenticator) Refreshable() bool {
+	return a.auth.Type == datastore.OAuth2EndpointAuthentication
+}
+
+// Authorize sets the authorization header of req, refresh skips the cached
+// oauth2 token and fetches a new one.
+func (a *Authenticator) Authorize(ctx context.Context, req *http.Request, refresh bool) error {
+	switch a.auth.Type {
+	case datastore.BearerEndpointAuthentication:
+		req.Header.Set("Authorization", "Bearer "+a.auth.Token)
+	case datastore.BasicEndpointAuthentication:
+		if a.auth.BasicAuth == nil {
+			return errors.New("basic auth credentials are required")
+		}
+		req.SetBasicAuth(a.auth.BasicAuth.UserName, a.auth.BasicAuth.Password)
+	case datastore.OAuth2EndpointAuthentication:
+		token, err := a.token(ctx, refresh)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", token.TokenType+" "+token.AccessToken)
+	default:
+		return fmt.Errorf("unsupported authentication type %q", a.auth.Type)
+	}
+
+	return nil
+}
+
+func (a *Authenticator) token(ctx context.Context, refresh bool) (*OAuth2Token, error) {
+	if a.auth.OAuth2 == nil {
+		return nil, errors.New("oauth2 configuration is required")
+	}
+
+	key := oauth2TokenCacheKey(a.auth.OAuth2)
+	if !refresh {
+		var token *OAuth2Token
+		err := a.cache.Get(ctx, key, &token)
+		if err != nil {
+			return nil, err
+		}
+
+		if token != nil && len(token.AccessToken) > 0 {
+			return token, nil
+		}
+	}
+
+	token, ttl, err := a.fetchToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.cache.Set(ctx, key, token, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// fetchToken requests a token with the client credentials grant, the client
+// authenticates with basic auth as recommended by RFC 6749.
+func (a *Authenticator) fetchToken(ctx context.Context) (*OAuth2Token, time.Duration, error) {
+	cfg := a.auth.OAuth2
+
+	err := a.policy.checkProxiedURL(ctx, cfg.TokenURL)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(cfg.Scopes, " "))
+	}
+	if len(cfg.Audience) > 0 {
+		form.Set("audience", cfg.Audience)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrOAuth2TokenRequest, err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", defaultUserAgent())
+	req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+
+	res, err := a.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrOAuth2TokenRequest, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrOAuth2TokenRequest, err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, 0, fmt.Errorf("%w: token endpoint responded with %s", ErrOAuth2TokenRequest, res.Status)
+	}
+
+	var t tokenResponse
+	err = json.Unmarshal(body, &t)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrOAuth2TokenRequest, err)
+	}
+
+	if len(t.AccessToken) == 0 {
+		return nil, 0, fmt.Errorf("%w: token response has no access_token", ErrOAuth2TokenRequest)
+	}
+
+	// the token type is case insensitive, most gateways only accept "Bearer".
+	tokenType := t.TokenType
+	if len(tokenType) == 0 || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+
+	ttl := defaultTokenTTL
+	if t.ExpiresIn > 0 {
+		ttl = time.Duration(t.ExpiresIn) * time.Second
+		if ttl > 2*tokenExpirySkew {
+			ttl -= tokenExpirySkew
+		}
+	}
+
+	return &OAuth2Token{AccessToken: t.AccessToken, TokenType: tokenType}, ttl, nil
+}
+
+// oauth2TokenCacheKey hashes the whole configuration, so changing any part
+// of it, the client secret included, doesn't reuse a stale token.
+func oauth2TokenCacheKey(cfg *datastore.OAuth2Config) string {
+	h := sha256.New()
+	for _, v := range []string{cfg.TokenURL, cfg.ClientID, cfg.ClientSecret, strings.Join(cfg.Scopes, " "), cfg.Audience} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	return convoy.OAuth2TokenCacheKey.Get(hex.EncodeToString(h.Sum(nil))).String()
+}
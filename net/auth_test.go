@@ -0,0 +1,169 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mcache "github.com/frain-dev/convoy/cache/memory"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenServer issues a new access token for every valid request made
+// with the client credentials grant.
+func newTokenServer(issued *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "client" || clientSecret != "secret" {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+
+		if r.ParseForm() != nil || r.PostForm.Get("grant_type") != "client_credentials" ||
+			r.PostForm.Get("scope") != "read write" || r.PostForm.Get("audience") != "convoy" {
+			http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+			return
+		}
+
+		n := atomic.AddInt32(issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token-` + string(rune('0'+n)) + `","token_type":"bearer","expires_in":3600}`))
+	}))
+}
+
+func oauth2Authentication(tokenURL string) *datastore.EndpointAuthentication {
+	return &datastore.EndpointAuthentication{
+		Type: datastore.OAuth2EndpointAuthentication,
+		OAuth2: &datastore.OAuth2Config{
+			TokenURL:     tokenURL,
+			ClientID:     "client",
+			ClientSecret: "secret",
+			Scopes:       []string{"read", "write"},
+			Audience:     "convoy",
+		},
+	}
+}
+
+func TestAuthenticator_Authorize(t *testing.T) {
+	var issued int32
+	tokenServer := newTokenServer(&issued)
+	defer tokenServer.Close()
+
+	tests := map[string]struct {
+		auth       *datastore.EndpointAuthentication
+		wantHeader string
+		wantErr    bool
+	}{
+		"should_set_bearer_token": {
+			auth:       &datastore.EndpointAuthentication{Type: datastore.BearerEndpointAuthentication, Token: "static"},
+			wantHeader: "Bearer static",
+		},
+		"should_set_basic_auth": {
+			auth: &datastore.EndpointAuthentication{
+				Type:      datastore.BasicEndpointAuthentication,
+				BasicAuth: &datastore.BasicAuth{UserName: "user", Password: "password"},
+			},
+			wantHeader: "Basic dXNlcjpwYXNzd29yZA==",
+		},
+		"should_set_oauth2_token": {
+			auth:       oauth2Authentication(tokenServer.URL),
+			wantHeader: "Bearer token-1",
+		},
+		"should_error_for_rejected_client_credentials": {
+			auth: &datastore.EndpointAuthentication{
+				Type:   datastore.OAuth2EndpointAuthentication,
+				OAuth2: &datastore.OAuth2Config{TokenURL: tokenServer.URL, ClientID: "client", ClientSecret: "wrong"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			atomic.StoreInt32(&issued, 0)
+			a := NewAuthenticator(tc.auth, mcache.NewMemoryCache(), &http.Client{Timeout: 5 * time.Second})
+
+			req, err := http.NewRequest(http.MethodPost, "https://example.com", nil)
+			require.NoError(t, err)
+
+			err = a.Authorize(context.Background(), req, false)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrOAuth2TokenRequest)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.wantHeader, req.Header.Get("Authorization"))
+		})
+	}
+}
+
+func TestAuthenticator_CachesOAuth2Token(t *testing.T) {
+	var issued int32
+	tokenServer := newTokenServer(&issued)
+	defer tokenServer.Close()
+
+	a := NewAuthenticator(oauth2Authentication(tokenServer.URL), mcache.NewMemoryCache(), &http.Client{Timeout: 5 * time.Second})
+
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodPost, "https://example.com", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, a.Authorize(context.Background(), req, false))
+		require.Equal(t, "Bearer token-1", req.Header.Get("Authorization"))
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&issued))
+
+	req, err := http.NewRequest(http.MethodPost, "https://example.com", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, a.Authorize(context.Background(), req, true))
+	require.Equal(t, "Bearer token-2", req.Header.Get("Authorization"))
+	require.Equal(t, int32(2), atomic.LoadInt32(&issued))
+}
+
+func TestDispatcher_SendRequest_RefreshesOAuth2TokenOn401(t *testing.T) {
+	var issued int32
+	tokenServer := newTokenServer(&issued)
+	defer tokenServer.Close()
+
+	c := mcache.NewMemoryCache()
+	auth := oauth2Authentication(tokenServer.URL)
+
+	// the cached token was revoked before it expired.
+	require.NoError(t, c.Set(context.Background(), oauth2TokenCacheKey(auth.OAuth2), &OAuth2Token{AccessToken: "revoked", TokenType: "Bearer"}, time.Hour))
+
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("Authorization"))
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write(successBody)
+	}))
+	defer srv.Close()
+
+	d, err := newTestDispatcher(t, config.Configuration{}, &datastore.Endpoint{Authentication: auth}, c, nil)
+	require.NoError(t, err)
+
+	g := &datastore.Group{
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.SignatureConfiguration{Header: config.DefaultSignatureHeader},
+		},
+	}
+
+	got, err := d.SendRequest(srv.URL, http.MethodPost, []byte(`{"event":"payment.created"}`), g, &util.Signature{Hmac: "12345"}, config.MaxResponseSize, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, got.StatusCode)
+	require.Equal(t, successBody, got.Body)
+	require.Equal(t, []string{"Bearer revoked", "Bearer token-1"}, received)
+	require.Equal(t, datastore.MaskedSecret, got.RequestHeader.Get("Authorization"))
+	require.Equal(t, "12345", got.RequestHeader.Get(config.DefaultSignatureHeader.String()))
+}
@@ -6,15 +6,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	gonet "net"
 	"net/http"
 	"net/http/httptrace"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/internal/pkg/signing"
 	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/util"
 	log "github.com/sirupsen/logrus"
@@ -22,6 +26,12 @@ import (
 
 type Dispatcher struct {
 	client *http.Client
+	auth   *Authenticator
+	proxy  string
+
+	// policy checks the endpoints of proxied deliveries, the connections
+	// of direct deliveries are checked when they are dialed.
+	policy *EgressPolicy
 }
 
 func NewDispatcher(timeout time.Duration) *Dispatcher {
@@ -30,33 +40,22 @@ func NewDispatcher(timeout time.Duration) *Dispatcher {
 	}
 }
 
-func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessage, g *datastore.Group, hmac string, timestamp string, maxResponseSize int64, headers httpheader.HTTPHeader) (*Response, error) {
+func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessage, g *datastore.Group, sig *util.Signature, maxResponseSize int64, headers httpheader.HTTPHeader) (*Response, error) {
 	r := &Response{}
-	signatureHeader := g.Config.Signature.Header.String()
-	if util.IsStringEmpty(signatureHeader) || util.IsStringEmpty(hmac) {
-		err := errors.New("signature header and hmac are required")
-		log.WithError(err).Error("Dispatcher invalid arguments")
-		r.Error = err.Error()
-		return r, err
-	}
-
 	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		log.WithError(err).Error("error occurred while creating request")
 		return r, err
 	}
 
-	req.Header.Set(signatureHeader, hmac)
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("User-Agent", defaultUserAgent())
-	if g.Config.ReplayAttacks {
-		if util.IsStringEmpty(timestamp) {
-			err := errors.New("timestamp is required")
-			log.WithError(err).Error("Dispatcher invalid arguments")
-			r.Error = err.Error()
-			return r, err
-		}
-		req.Header.Set("Convoy-Timestamp", timestamp)
+
+	err = setSignatureHeaders(req.Header, g, sig, jsonData)
+	if err != nil {
+		log.WithError(err).Error("Dispatcher invalid arguments")
+		r.Error = err.Error()
+		return r, err
 	}
 
 	header := httpheader.HTTPHeader(req.Header)
@@ -67,12 +66,123 @@ func (d *Dispatcher) SendRequest(endpoint, method string, jsonData json.RawMessa
 	r.RequestHeader = req.Header
 	r.URL = req.URL
 	r.Method = req.Method
+	r.Proxy = d.proxy
+
+	err = d.policy.checkProxiedURL(req.Context(), endpoint)
+	if err != nil {
+		r.Error = err.Error()
+		return r, err
+	}
+
+	if d.auth == nil {
+		err = d.do(req, r, maxResponseSize)
+		return r, err
+	}
+
+	err = d.authorize(req, r, false)
+	if err != nil {
+		return r, err
+	}
+
+	err = d.do(req, r, maxResponseSize)
+	if err != nil || r.StatusCode != http.StatusUnauthorized || !d.auth.Refreshable() {
+		return r, err
+	}
+
+	// the cached token may have been revoked before it expired,
+	// retry once with a new one.
+	req, err = http.NewRequest(method, endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return r, err
+	}
+	req.Header = http.Header(header)
+
+	err = d.authorize(req, r, true)
+	if err != nil {
+		return r, err
+	}
 
 	err = d.do(req, r, maxResponseSize)
 
 	return r, err
 }
 
+// authorize sets the authorization header of req, the request header kept
+// in the response masks the credentials as it is stored with the attempt.
+func (d *Dispatcher) authorize(req *http.Request, r *Response, refresh bool) error {
+	req.Header = req.Header.Clone()
+
+	err := d.auth.Authorize(req.Context(), req, refresh)
+	if err != nil {
+		log.WithError(err).Error("failed to authorize request")
+		r.Error = err.Error()
+		return err
+	}
+
+	r.RequestHeader = req.Header.Clone()
+	r.RequestHeader.Set("Authorization", datastore.MaskedSecret)
+
+	return nil
+}
+
+// setSignatureHeaders sets the headers of the group's signature scheme,
+// followed by the asymmetric signature if the group has one configured.
+func setSignatureHeaders(header http.Header, g *datastore.Group, sig *util.Signature, jsonData json.RawMessage) error {
+	if sig == nil || util.IsStringEmpty(sig.Hmac) {
+		return errors.New("signature header and hmac are required")
+	}
+
+	switch g.Config.Signature.Scheme {
+	case datastore.StandardWebhooksSignatureScheme:
+		if util.IsStringEmpty(sig.ID) || util.IsStringEmpty(sig.Timestamp) {
+			return errors.New("webhook id and timestamp are required")
+		}
+
+		header.Set("webhook-id", sig.ID)
+		header.Set("webhook-timestamp", sig.Timestamp)
+		header.Set("webhook-signature", sig.Hmac)
+	default:
+		signatureHeader := g.Config.Signature.Header.String()
+		if util.IsStringEmpty(signatureHeader) {
+			return errors.New("signature header and hmac are required")
+		}
+
+		header.Set(signatureHeader, sig.Hmac)
+		if g.Config.ReplayAttacks {
+			if util.IsStringEmpty(sig.Timestamp) {
+				return errors.New("timestamp is required")
+			}
+			header.Set("Convoy-Timestamp", sig.Timestamp)
+		}
+	}
+
+	if asymmetric := g.Config.Signature.Asymmetric; asymmetric != nil {
+		s, err := asymmetricSignature(g, asymmetric.Algorithm, sig.Timestamp, jsonData)
+		if err != nil {
+			return err
+		}
+		header.Set(asymmetric.HeaderName(), s)
+	}
+
+	return nil
+}
+
+// asymmetricSignature signs the payload with the group's key, the payload is
+// prefixed with the timestamp like the hmac when replay attacks are prevented.
+func asymmetricSignature(g *datastore.Group, alg datastore.SigningAlgorithm, timestamp string, jsonData json.RawMessage) (string, error) {
+	key := g.SigningKey(alg)
+	if key == nil {
+		return "", fmt.Errorf("group has no %s signing key", alg)
+	}
+
+	data := []byte(jsonData)
+	if g.Config.ReplayAttacks {
+		data = append([]byte(timestamp+","), jsonData...)
+	}
+
+	return signing.SignatureHeader(key, data)
+}
+
 func (d *Dispatcher) SendCliRequest(url string, method convoy.HttpMethod, apiKey string, jsonData json.RawMessage) (*Response, error) {
 	r := &Response{}
 
@@ -130,6 +240,7 @@ type Response struct {
 	ResponseHeader http.Header
 	Body           []byte
 	IP             string
+	Proxy          string
 	Error          string
 }
 
@@ -150,6 +261,7 @@ func defaultUserAgent() string {
 func (d *Dispatcher) do(req *http.Request, res *Response, maxResponseSize int64) error {
 	trace := &httptrace.ClientTrace{
 		GotConn: func(connInfo httptrace.GotConnInfo) {
+			metrics.DispatcherConnections().WithLabelValues(strconv.FormatBool(connInfo.Reused)).Inc()
 			res.IP = connInfo.Conn.RemoteAddr().String()
 			log.Infof("IP address resolved to: %s", connInfo.Conn.RemoteAddr())
 		},
@@ -161,6 +273,12 @@ func (d *Dispatcher) do(req *http.Request, res *Response, maxResponseSize int64)
 	if err != nil {
 		log.WithError(err).Error("error sending request to API endpoint")
 		res.Error = err.Error()
+
+		// the egress policy's reason is clearer than the dial error around it.
+		var opErr *gonet.OpError
+		if errors.Is(err, ErrBlockedDestination) && errors.As(err, &opErr) {
+			res.Error = opErr.Err.Error()
+		}
 		return err
 	}
 	updateDispatchHeaders(res, response)
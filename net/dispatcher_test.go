@@ -2,15 +2,19 @@ package net
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"math/rand"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/signing"
 	"github.com/frain-dev/convoy/pkg/httpheader"
+	"github.com/frain-dev/convoy/util"
 	"github.com/jarcoal/httpmock"
 
 	"github.com/frain-dev/convoy/config"
@@ -318,7 +322,7 @@ func TestDispatcher_SendRequest(t *testing.T) {
 				defer deferFn()
 			}
 
-			got, err := d.SendRequest(tt.args.endpoint, tt.args.method, tt.args.jsonData, tt.args.group, tt.args.hmac, tt.args.convoyTimestamp, config.MaxResponseSize, tt.args.headers)
+			got, err := d.SendRequest(tt.args.endpoint, tt.args.method, tt.args.jsonData, tt.args.group, &util.Signature{Hmac: tt.args.hmac, Timestamp: tt.args.convoyTimestamp}, config.MaxResponseSize, tt.args.headers)
 			if tt.wantErr {
 				require.NotNil(t, err)
 				require.Contains(t, err.Error(), tt.want.Error)
@@ -334,3 +338,84 @@ func TestDispatcher_SendRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestDispatcher_SendRequest_AsymmetricSignature(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPost, "https://google.com",
+		httpmock.NewStringResponder(http.StatusOK, string(successBody)))
+
+	key, err := signing.GenerateKey(datastore.Ed25519SigningAlgorithm)
+	require.NoError(t, err)
+
+	timestamp := fmt.Sprint(time.Now().Unix())
+	payload := []byte(`{"event":"payment.created"}`)
+	g := &datastore.Group{
+		UID: "12345",
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.SignatureConfiguration{
+				Header:     config.DefaultSignatureHeader,
+				Asymmetric: &datastore.AsymmetricSignatureConfiguration{Algorithm: datastore.Ed25519SigningAlgorithm},
+			},
+			ReplayAttacks: true,
+		},
+		SigningKeys: []datastore.SigningKey{*key},
+	}
+
+	d := &Dispatcher{client: http.DefaultClient}
+	got, err := d.SendRequest("https://google.com", http.MethodPost, payload, g, &util.Signature{Hmac: "12345", Timestamp: timestamp}, config.MaxResponseSize, nil)
+	require.NoError(t, err)
+
+	header := got.RequestHeader.Get(config.DefaultAsymmetricSignatureHeader.String())
+	prefix := fmt.Sprintf("kid=%s,alg=EdDSA,sig=", key.UID)
+	require.True(t, strings.HasPrefix(header, prefix))
+
+	sig, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(header, prefix))
+	require.NoError(t, err)
+
+	jwks, err := signing.NewJSONWebKeySet(g.SigningKeys)
+	require.NoError(t, err)
+	require.NoError(t, signing.Verify(&jwks.Keys[0], []byte(timestamp+","+string(payload)), sig))
+
+	// a group without a key for the algorithm can't sign requests
+	g.SigningKeys = nil
+	_, err = d.SendRequest("https://google.com", http.MethodPost, payload, g, &util.Signature{Hmac: "12345", Timestamp: timestamp}, config.MaxResponseSize, nil)
+	require.Error(t, err)
+}
+
+func TestDispatcher_SendRequest_StandardWebhooks(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(http.MethodPost, "https://google.com",
+		httpmock.NewStringResponder(http.StatusOK, string(successBody)))
+
+	g := &datastore.Group{
+		UID: "12345",
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.SignatureConfiguration{
+				Header: config.DefaultSignatureHeader,
+				Scheme: datastore.StandardWebhooksSignatureScheme,
+			},
+		},
+	}
+
+	d := &Dispatcher{client: http.DefaultClient}
+	sig := &util.Signature{ID: "msg-1", Timestamp: "1614265330", Hmac: "v1,abc v1,def"}
+
+	got, err := d.SendRequest("https://google.com", http.MethodPost, []byte(`{}`), g, sig, config.MaxResponseSize, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.Header{
+		"Content-Type":      []string{"application/json"},
+		"User-Agent":        []string{defaultUserAgent()},
+		"Webhook-Id":        []string{"msg-1"},
+		"Webhook-Timestamp": []string{"1614265330"},
+		"Webhook-Signature": []string{"v1,abc v1,def"},
+	}, got.RequestHeader)
+
+	sig.ID = ""
+	got, err = d.SendRequest("https://google.com", http.MethodPost, []byte(`{}`), g, sig, config.MaxResponseSize, nil)
+	require.Error(t, err)
+	require.Equal(t, "webhook id and timestamp are required", got.Error)
+}
@@ -0,0 +1,178 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gonet "net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"github.com/frain-dev/convoy/config"
+)
+
+var ErrBlockedDestination = errors.New("destination is blocked by the egress policy")
+
+// EgressPolicy decides which destinations deliveries can connect to.
+type EgressPolicy struct {
+	blocked []*gonet.IPNet
+	allowed []string
+}
+
+// NewEgressPolicy returns the policy of cfg, nil when egress isn't restricted.
+func NewEgressPolicy(cfg config.EgressConfiguration) (*EgressPolicy, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	cidrs := cfg.BlockedCIDRs
+	if len(cidrs) == 0 {
+		cidrs = config.DefaultBlockedCIDRs
+	}
+
+	p := &EgressPolicy{}
+	for _, cidr := range cidrs {
+		_, ipNet, err := gonet.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid egress blocked cidr: %s", cidr)
+		}
+		p.blocked = append(p.blocked, ipNet)
+	}
+
+	for _, host := range cfg.AllowedHosts {
+		p.allowed = append(p.allowed, strings.ToLower(strings.TrimSpace(host)))
+	}
+
+	return p, nil
+}
+
+// CheckURL checks the host of an endpoint url and the ips it currently
+// resolves to. Hosts that don't resolve are let through, deliveries are
+// still checked when they connect.
+func (p *EgressPolicy) CheckURL(ctx context.Context, rawURL string) error {
+	return p.checkURL(ctx, rawURL, false)
+}
+
+// checkProxiedURL checks the url of a request sent through the proxy. The
+// connection is made by the proxy, so hosts that don't resolve here, e.g
+// names of an internal zone only the proxy resolves, are refused.
+func (p *EgressPolicy) checkProxiedURL(ctx context.Context, rawURL string) error {
+	return p.checkURL(ctx, rawURL, true)
+}
+
+// checkProxiedRedirect is the CheckRedirect of proxied clients, every hop of
+// a redirect is checked as the proxy follows it to wherever it points.
+func (p *EgressPolicy) checkProxiedRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	return p.checkProxiedURL(req.Context(), req.URL.String())
+}
+
+func (p *EgressPolicy) checkURL(ctx context.Context, rawURL string, proxied bool) error {
+	if p == nil {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := u.Hostname()
+	if p.allowsHost(host) {
+		return nil
+	}
+
+	if ip := gonet.ParseIP(host); ip != nil {
+		return p.checkIP(ip)
+	}
+
+	addrs, err := gonet.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		if proxied {
+			return fmt.Errorf("%w: %s doesn't resolve", ErrBlockedDestination, host)
+		}
+		return nil
+	}
+
+	for _, addr := range addrs {
+		if err = p.checkIP(addr.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DialContext returns a dial function that refuses connections to blocked
+// ips. The check runs on the resolved address right before connecting, so
+// a host re-resolving to a blocked ip after CheckURL is still refused.
+// Connections to the exempt hosts, e.g the proxy deliveries go through,
+// aren't checked.
+func (p *EgressPolicy) DialContext(dialer *gonet.Dialer, exempt ...string) func(ctx context.Context, network, addr string) (gonet.Conn, error) {
+	guarded := *dialer
+	guarded.Control = func(network, address string, _ syscall.RawConn) error {
+		host, _, err := gonet.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+
+		ip := gonet.ParseIP(host)
+		if ip == nil {
+			return fmt.Errorf("%w: %s", ErrBlockedDestination, address)
+		}
+
+		return p.checkIP(ip)
+	}
+
+	return func(ctx context.Context, network, addr string) (gonet.Conn, error) {
+		host, _, err := gonet.SplitHostPort(addr)
+		if err == nil && (p.allowsHost(host) || isExempt(host, exempt)) {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		return guarded.DialContext(ctx, network, addr)
+	}
+}
+
+func isExempt(host string, exempt []string) bool {
+	for _, h := range exempt {
+		if strings.EqualFold(host, h) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *EgressPolicy) allowsHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, allowed := range p.allowed {
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+			continue
+		}
+
+		if host == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *EgressPolicy) checkIP(ip gonet.IP) error {
+	for _, ipNet := range p.blocked {
+		if ipNet.Contains(ip) {
+			return fmt.Errorf("%w: %s is in %s", ErrBlockedDestination, ip, ipNet)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEgressPolicy(t *testing.T) {
+	p, err := NewEgressPolicy(config.EgressConfiguration{BlockedCIDRs: []string{"10.0.0.0/8"}})
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	p, err = NewEgressPolicy(config.EgressConfiguration{Enabled: true})
+	require.NoError(t, err)
+	require.Len(t, p.blocked, len(config.DefaultBlockedCIDRs))
+
+	_, err = NewEgressPolicy(config.EgressConfiguration{Enabled: true, BlockedCIDRs: []string{"10.0.0.1"}})
+	require.EqualError(t, err, "invalid egress blocked cidr: 10.0.0.1")
+}
+
+func TestEgressPolicy_CheckURL(t *testing.T) {
+	p, err := NewEgressPolicy(config.EgressConfiguration{
+		Enabled:      true,
+		AllowedHosts: []string{"127.0.0.2", "*.internal.convoy.test"},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		url     string
+		blocked bool
+	}{
+		"should_block_loopback":                     {url: "http://127.0.0.1:8080/webhook", blocked: true},
+		"should_block_localhost":                    {url: "http://localhost/webhook", blocked: true},
+		"should_block_metadata_ip":                  {url: "http://169.254.169.254/latest/meta-data", blocked: true},
+		"should_block_private_range":                {url: "https://10.1.2.3/webhook", blocked: true},
+		"should_block_ipv4_mapped_ipv6":             {url: "http://[::ffff:192.168.0.1]/webhook", blocked: true},
+		"should_block_ipv6_loopback":                {url: "http://[::1]/webhook", blocked: true},
+		"should_allow_public_ip":                    {url: "https://8.8.8.8/webhook"},
+		"should_allow_allowed_host":                 {url: "http://127.0.0.2/webhook"},
+		"should_allow_allowed_subdomain":            {url: "http://hooks.internal.convoy.test/webhook"},
+		"should_allow_unresolvable_host_until_dial": {url: "https://unknown.invalid/webhook"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := p.CheckURL(context.Background(), tc.url)
+			if tc.blocked {
+				require.ErrorIs(t, err, ErrBlockedDestination)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	err = p.checkProxiedURL(context.Background(), "https://unknown.invalid/webhook")
+	require.ErrorIs(t, err, ErrBlockedDestination)
+	require.NoError(t, p.checkProxiedURL(context.Background(), "http://hooks.internal.convoy.test/webhook"))
+
+	var nilPolicy *EgressPolicy
+	require.NoError(t, nilPolicy.CheckURL(context.Background(), "http://127.0.0.1"))
+	require.NoError(t, nilPolicy.checkProxiedURL(context.Background(), "https://unknown.invalid/webhook"))
+}
+
+func TestDispatcherPool_Dispatcher_EgressPolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(successBody)
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	g := &datastore.Group{
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.SignatureConfiguration{Header: config.DefaultSignatureHeader},
+		},
+	}
+	sig := &util.Signature{Hmac: "12345"}
+
+	tests := map[string]struct {
+		cfg     config.EgressConfiguration
+		url     string
+		wantErr bool
+	}{
+		"should_refuse_blocked_ip": {
+			cfg:     config.EgressConfiguration{Enabled: true},
+			url:     srv.URL,
+			wantErr: true,
+		},
+		"should_refuse_host_resolving_to_blocked_ip": {
+			cfg:     config.EgressConfiguration{Enabled: true},
+			url:     "http://localhost:" + u.Port(),
+			wantErr: true,
+		},
+		"should_send_to_allowed_host": {
+			cfg: config.EgressConfiguration{Enabled: true, AllowedHosts: []string{"localhost"}},
+			url: "http://localhost:" + u.Port(),
+		},
+		"should_send_when_disabled": {
+			url: srv.URL,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, err := newTestDispatcher(t, config.Configuration{Egress: tc.cfg}, &datastore.Endpoint{}, nil, nil)
+			require.NoError(t, err)
+
+			got, err := d.SendRequest(tc.url, http.MethodPost, []byte(`{}`), g, sig, config.MaxResponseSize, nil)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrBlockedDestination)
+				require.True(t, strings.HasPrefix(got.Error, "destination is blocked by the egress policy: "))
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, got.StatusCode)
+			require.Equal(t, successBody, got.Body)
+		})
+	}
+}
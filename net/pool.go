@@ -0,0 +1,215 @@
+package net
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	gonet "net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+)
+
+const (
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 * time.Second
+
+	// maxPooledTransports bounds the transports left behind by endpoints
+	// changing their tls or proxy settings.
+	maxPooledTransports = 1000
+)
+
+// DispatcherPool shares transports between the dispatchers of a worker, so
+// connections to endpoints are kept alive and reused across deliveries
+// instead of paying for a new connection and tls handshake every time.
+// Endpoints with the same tls and proxy settings share a transport.
+type DispatcherPool struct {
+	cfg    config.DispatcherConfiguration
+	policy *EgressPolicy
+	base   http.RoundTripper
+
+	mu         sync.Mutex
+	transports map[string]http.RoundTripper
+}
+
+// NewDispatcherPool returns a pool with the connection limits of cfg, its
+// connections are restricted by cfg's egress policy.
+func NewDispatcherPool(cfg config.Configuration) (*DispatcherPool, error) {
+	policy, err := NewEgressPolicy(cfg.Egress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DispatcherPool{
+		cfg:        cfg.Dispatcher,
+		policy:     policy,
+		base:       http.DefaultTransport,
+		transports: map[string]http.RoundTripper{},
+	}, nil
+}
+
+// Dispatcher returns a dispatcher that connects to the endpoint with its tls
+// configuration, client certificates and private CAs included, and
+// authenticates requests with the endpoint's authentication, oauth2 tokens
+// are cached in c. Requests, token requests included, are sent through
+// proxyURL when it isn't nil.
+func (p *DispatcherPool) Dispatcher(timeout time.Duration, endpoint *datastore.Endpoint, c cache.Cache, proxyURL *url.URL) (*Dispatcher, error) {
+	transport, err := p.transport(endpoint.TLS, proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	d := NewDispatcher(timeout)
+	d.client.Transport = transport
+	d.proxy = redactProxyURL(proxyURL)
+
+	if proxyURL != nil && p.policy != nil {
+		d.policy = p.policy
+		d.client.CheckRedirect = p.policy.checkProxiedRedirect
+	}
+
+	if endpoint.Authentication != nil {
+		transport, err = p.transport(nil, proxyURL)
+		if err != nil {
+			return nil, err
+		}
+
+		client := &http.Client{Timeout: timeout, Transport: transport}
+		d.auth = NewAuthenticator(endpoint.Authentication, c, client)
+
+		if proxyURL != nil && p.policy != nil {
+			d.auth.policy = p.policy
+			client.CheckRedirect = p.policy.checkProxiedRedirect
+		}
+	}
+
+	return d, nil
+}
+
+// Client returns a client for requests made to tenant configured urls other
+// than endpoints, e.g the apis of rest_api sources. Its connections are
+// restricted by the pool's egress policy.
+func (p *DispatcherPool) Client(timeout time.Duration) (*http.Client, error) {
+	transport, err := p.transport(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
+// transport returns the pooled transport of the tls and proxy settings, it
+// is created the first time they are used.
+func (p *DispatcherPool) transport(cfg *datastore.EndpointTLSConfig, proxyURL *url.URL) (http.RoundTripper, error) {
+	key := transportKey(cfg, proxyURL)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if transport, ok := p.transports[key]; ok {
+		return transport, nil
+	}
+
+	tlsConfig, err := NewTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.transports) >= maxPooledTransports {
+		for _, transport := range p.transports {
+			if t, ok := transport.(*http.Transport); ok {
+				t.CloseIdleConnections()
+			}
+		}
+		p.transports = map[string]http.RoundTripper{}
+	}
+
+	transport := p.newTransport(tlsConfig, proxyURL)
+	p.transports[key] = transport
+
+	return transport, nil
+}
+
+func (p *DispatcherPool) newTransport(tlsConfig *tls.Config, proxyURL *url.URL) http.RoundTripper {
+	base, ok := p.base.(*http.Transport)
+	if !ok {
+		// the default transport was replaced, e.g by a mock in tests,
+		// it is used as is unless the connections need settings of their own.
+		if tlsConfig == nil && proxyURL == nil && p.policy == nil {
+			return p.base
+		}
+		base = &http.Transport{Proxy: http.ProxyFromEnvironment, ForceAttemptHTTP2: true}
+	}
+
+	transport := base.Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.MaxIdleConns = defaultMaxIdleConns
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	transport.MaxConnsPerHost = p.cfg.MaxConnsPerHost
+	transport.IdleConnTimeout = defaultIdleConnTimeout
+
+	if p.cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = p.cfg.MaxIdleConns
+	}
+
+	if p.cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = p.cfg.MaxIdleConnsPerHost
+	}
+
+	if p.cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = time.Duration(p.cfg.IdleConnTimeout) * time.Second
+	}
+
+	if p.cfg.DisableHTTP2 {
+		// a non nil, empty map turns off the transport's http2 support.
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	if proxyURL != nil {
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if p.policy != nil {
+		// a proxied transport only connects to the proxy, the destinations
+		// are checked before requests are sent instead.
+		var exempt []string
+		if proxyURL != nil {
+			exempt = append(exempt, proxyURL.Hostname())
+		}
+
+		transport.DialContext = p.policy.DialContext(&gonet.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}, exempt...)
+	}
+
+	return transport
+}
+
+// transportKey hashes the settings a transport is created with, the proxy
+// credentials and tls keys included.
+func transportKey(cfg *datastore.EndpointTLSConfig, proxyURL *url.URL) string {
+	h := sha256.New()
+
+	values := make([]string, 0, 6)
+	if cfg != nil {
+		values = append(values, cfg.ClientCert, cfg.ClientKey, cfg.CACert, cfg.MinVersion, cfg.ServerName)
+	}
+	if proxyURL != nil {
+		values = append(values, proxyURL.String())
+	}
+
+	for _, v := range values {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
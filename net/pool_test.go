@@ -0,0 +1,127 @@
+package net
+
+import (
+	"crypto/tls"
+	gonet "net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/metrics"
+	"github.com/frain-dev/convoy/util"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDispatcher(t *testing.T, cfg config.Configuration, endpoint *datastore.Endpoint, c cache.Cache, proxyURL *url.URL) (*Dispatcher, error) {
+	t.Helper()
+
+	p, err := NewDispatcherPool(cfg)
+	require.NoError(t, err)
+
+	return p.Dispatcher(5*time.Second, endpoint, c, proxyURL)
+}
+
+func TestDispatcherPool_Dispatcher_ReusesConnections(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(successBody)
+	}))
+	srv.Config.ConnState = func(_ gonet.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	p, err := NewDispatcherPool(config.Configuration{})
+	require.NoError(t, err)
+
+	g := &datastore.Group{
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.SignatureConfiguration{Header: config.DefaultSignatureHeader},
+		},
+	}
+	sig := &util.Signature{Hmac: "12345"}
+
+	reused := testutil.ToFloat64(metrics.DispatcherConnections().WithLabelValues("true"))
+
+	for i := 0; i < 3; i++ {
+		d, err := p.Dispatcher(5*time.Second, &datastore.Endpoint{}, nil, nil)
+		require.NoError(t, err)
+
+		got, err := d.SendRequest(srv.URL, http.MethodPost, []byte(`{}`), g, sig, config.MaxResponseSize, nil)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, got.StatusCode)
+	}
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&conns))
+	require.Equal(t, reused+2, testutil.ToFloat64(metrics.DispatcherConnections().WithLabelValues("true")))
+}
+
+func TestDispatcherPool_Transport(t *testing.T) {
+	p, err := NewDispatcherPool(config.Configuration{
+		Dispatcher: config.DispatcherConfiguration{
+			MaxIdleConns:        50,
+			MaxIdleConnsPerHost: 5,
+			MaxConnsPerHost:     20,
+			IdleConnTimeout:     30,
+			DisableHTTP2:        true,
+		},
+	})
+	require.NoError(t, err)
+	p.base = &http.Transport{ForceAttemptHTTP2: true}
+
+	rt, err := p.transport(nil, nil)
+	require.NoError(t, err)
+
+	transport, ok := rt.(*http.Transport)
+	require.True(t, ok)
+	require.Equal(t, 50, transport.MaxIdleConns)
+	require.Equal(t, 5, transport.MaxIdleConnsPerHost)
+	require.Equal(t, 20, transport.MaxConnsPerHost)
+	require.Equal(t, 30*time.Second, transport.IdleConnTimeout)
+	require.False(t, transport.ForceAttemptHTTP2)
+	require.NotNil(t, transport.TLSNextProto)
+	require.Empty(t, transport.TLSNextProto)
+
+	same, err := p.transport(nil, nil)
+	require.NoError(t, err)
+	require.True(t, rt == same)
+
+	proxyURL, err := NewProxyURL("http://proxy.convoy.test:8080", "", "")
+	require.NoError(t, err)
+
+	proxied, err := p.transport(nil, proxyURL)
+	require.NoError(t, err)
+	require.False(t, rt == proxied)
+
+	tlsCfg := &datastore.EndpointTLSConfig{MinVersion: "1.3"}
+	secured, err := p.transport(tlsCfg, nil)
+	require.NoError(t, err)
+	require.False(t, rt == secured)
+	require.Equal(t, uint16(tls.VersionTLS13), secured.(*http.Transport).TLSClientConfig.MinVersion)
+}
+
+func TestDispatcherPool_Transport_Defaults(t *testing.T) {
+	p, err := NewDispatcherPool(config.Configuration{})
+	require.NoError(t, err)
+	p.base = &http.Transport{ForceAttemptHTTP2: true}
+
+	rt, err := p.transport(nil, nil)
+	require.NoError(t, err)
+
+	transport := rt.(*http.Transport)
+	require.Equal(t, defaultMaxIdleConns, transport.MaxIdleConns)
+	require.Equal(t, defaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	require.Equal(t, defaultIdleConnTimeout, transport.IdleConnTimeout)
+	require.True(t, transport.ForceAttemptHTTP2)
+	require.Nil(t, transport.TLSNextProto)
+}
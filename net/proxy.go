@@ -0,0 +1,45 @@
+package net
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/frain-dev/convoy/util"
+)
+
+var ErrInvalidProxyURL = errors.New("invalid proxy url, the scheme must be http, https or socks5")
+
+// NewProxyURL returns the url of the proxy deliveries are sent through, with
+// its credentials set for the proxy auth. It returns nil when rawURL is empty.
+func NewProxyURL(rawURL, username, password string) (*url.URL, error) {
+	if util.IsStringEmpty(rawURL) {
+		return nil, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || util.IsStringEmpty(u.Host) {
+		return nil, ErrInvalidProxyURL
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, ErrInvalidProxyURL
+	}
+
+	if !util.IsStringEmpty(username) {
+		u.User = url.UserPassword(username, password)
+	}
+
+	return u, nil
+}
+
+// redactProxyURL returns the proxy recorded on delivery attempts, without
+// its credentials.
+func redactProxyURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+}
@@ -0,0 +1,59 @@
+package net
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+)
+
+var (
+	ErrInvalidTLSVersion        = errors.New("invalid tls min version")
+	ErrInvalidClientCertificate = errors.New("invalid tls client certificate or key")
+	ErrInvalidCACertificate     = errors.New("invalid tls ca certificate")
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// NewTLSConfig returns the tls configuration of an endpoint's deliveries,
+// it returns nil when the endpoint uses the default configuration.
+func NewTLSConfig(cfg *datastore.EndpointTLSConfig) (*tls.Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	c := &tls.Config{ServerName: cfg.ServerName}
+
+	if !util.IsStringEmpty(cfg.MinVersion) {
+		v, ok := tlsVersions[cfg.MinVersion]
+		if !ok {
+			return nil, ErrInvalidTLSVersion
+		}
+		c.MinVersion = v
+	}
+
+	if !util.IsStringEmpty(cfg.ClientCert) || !util.IsStringEmpty(cfg.ClientKey) {
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCert), []byte(cfg.ClientKey))
+		if err != nil {
+			return nil, ErrInvalidClientCertificate
+		}
+		c.Certificates = []tls.Certificate{cert}
+	}
+
+	if !util.IsStringEmpty(cfg.CACert) {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.CACert)) {
+			return nil, ErrInvalidCACertificate
+		}
+		c.RootCAs = pool
+	}
+
+	return c, nil
+}
@@ -0,0 +1,184 @@
+package net
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+}
+
+// newTestCert creates a certificate signed by parent, or a self signed CA when parent is nil.
+func newTestCert(t *testing.T, parent *testCert, commonName string, dnsNames ...string) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	ca := newTestCert(t, nil, "Convoy Test CA")
+	client := newTestCert(t, ca, "client")
+
+	c, err := NewTLSConfig(nil)
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	c, err = NewTLSConfig(&datastore.EndpointTLSConfig{
+		ClientCert: client.certPEM,
+		ClientKey:  client.keyPEM,
+		CACert:     ca.certPEM,
+		MinVersion: "1.3",
+		ServerName: "convoy.test",
+	})
+	require.NoError(t, err)
+	require.Len(t, c.Certificates, 1)
+	require.NotNil(t, c.RootCAs)
+	require.Equal(t, uint16(tls.VersionTLS13), c.MinVersion)
+	require.Equal(t, "convoy.test", c.ServerName)
+
+	_, err = NewTLSConfig(&datastore.EndpointTLSConfig{MinVersion: "1.4"})
+	require.ErrorIs(t, err, ErrInvalidTLSVersion)
+
+	_, err = NewTLSConfig(&datastore.EndpointTLSConfig{ClientCert: client.certPEM})
+	require.ErrorIs(t, err, ErrInvalidClientCertificate)
+
+	_, err = NewTLSConfig(&datastore.EndpointTLSConfig{ClientCert: client.certPEM, ClientKey: datastore.MaskedSecret})
+	require.ErrorIs(t, err, ErrInvalidClientCertificate)
+
+	_, err = NewTLSConfig(&datastore.EndpointTLSConfig{CACert: "ca"})
+	require.ErrorIs(t, err, ErrInvalidCACertificate)
+}
+
+func TestDispatcherPool_Dispatcher_MutualTLS(t *testing.T) {
+	ca := newTestCert(t, nil, "Convoy Test CA")
+	server := newTestCert(t, ca, "server", "convoy.test")
+	client := newTestCert(t, ca, "client")
+
+	serverCert, err := tls.X509KeyPair([]byte(server.certPEM), []byte(server.keyPEM))
+	require.NoError(t, err)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(successBody)
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	g := &datastore.Group{
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.SignatureConfiguration{Header: config.DefaultSignatureHeader},
+		},
+	}
+	sig := &util.Signature{Hmac: "12345"}
+
+	tests := map[string]struct {
+		cfg     *datastore.EndpointTLSConfig
+		wantErr bool
+	}{
+		"should_send_with_client_certificate": {
+			cfg: &datastore.EndpointTLSConfig{
+				ClientCert: client.certPEM,
+				ClientKey:  client.keyPEM,
+				CACert:     ca.certPEM,
+				ServerName: "convoy.test",
+			},
+		},
+		"should_fail_without_client_certificate": {
+			cfg:     &datastore.EndpointTLSConfig{CACert: ca.certPEM, ServerName: "convoy.test"},
+			wantErr: true,
+		},
+		"should_fail_without_ca_certificate": {
+			cfg: &datastore.EndpointTLSConfig{
+				ClientCert: client.certPEM,
+				ClientKey:  client.keyPEM,
+				ServerName: "convoy.test",
+			},
+			wantErr: true,
+		},
+		"should_fail_for_mismatched_server_name": {
+			cfg: &datastore.EndpointTLSConfig{
+				ClientCert: client.certPEM,
+				ClientKey:  client.keyPEM,
+				CACert:     ca.certPEM,
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, err := newTestDispatcher(t, config.Configuration{}, &datastore.Endpoint{TLS: tc.cfg}, nil, nil)
+			require.NoError(t, err)
+
+			got, err := d.SendRequest(srv.URL, http.MethodPost, []byte(`{}`), g, sig, config.MaxResponseSize, nil)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, got.StatusCode)
+			require.Equal(t, successBody, got.Body)
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	andOperator = "$and"
+	orOperator  = "$or"
+)
+
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Filter is a compiled payload filter. Filters are written as JSON
+// objects whose keys are dot separated paths into the payload, e.g.
+//
+//	{"event": "invoice.paid", "data.amount": {"$gt": 1000}}
+//
+// A value is either compared for equality or is an object of operators
+// ($eq, $neq, $gt, $gte, $lt, $lte, $in, $nin, $exists). Nested objects
+// that are not operators filter the value at that path, and conditions
+// can be combined with $and and $or.
+type Filter struct {
+	schema map[string]interface{}
+}
+
+// New validates the filter schema and returns a Filter for it. An empty
+// schema matches every payload.
+func New(schema map[string]interface{}) (*Filter, error) {
+	normalized, err := normalize(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validate(normalized); err != nil {
+		return nil, err
+	}
+
+	return &Filter{schema: normalized}, nil
+}
+
+// NewWithKeys is New with the field names of the schema, including those
+// of filters nested in $and and $or, rewritten by key.
+func NewWithKeys(schema map[string]interface{}, key func(string) string) (*Filter, error) {
+	normalized, err := normalize(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validate(normalized); err != nil {
+		return nil, err
+	}
+
+	return &Filter{schema: rewriteKeys(normalized, key)}, nil
+}
+
+func rewriteKeys(schema map[string]interface{}, key func(string) string) map[string]interface{} {
+	m := make(map[string]interface{}, len(schema))
+	for k, cond := range schema {
+		switch k {
+		case andOperator, orOperator:
+			conds := cond.([]interface{})
+			rewritten := make([]interface{}, len(conds))
+			for i := range conds {
+				rewritten[i] = rewriteKeys(conds[i].(map[string]interface{}), key)
+			}
+			m[k] = rewritten
+		default:
+			m[key(k)] = cond
+		}
+	}
+
+	return m
+}
+
+// Match reports whether the decoded JSON value v satisfies the filter.
+func (f *Filter) Match(v interface{}) bool {
+	return matchDocument(f.schema, v)
+}
+
+// MatchJSON decodes data and reports whether it satisfies the filter.
+func (f *Filter) MatchJSON(data json.RawMessage) (bool, error) {
+	if len(f.schema) == 0 {
+		return true, nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return false, fmt.Errorf("failed to decode payload: %v", err)
+	}
+
+	return f.Match(v), nil
+}
+
+// normalize round trips the schema through JSON so values read back from
+// the datastore have the same types as those decoded from a request.
+func normalize(schema map[string]interface{}) (map[string]interface{}, error) {
+	if len(schema) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFilter, err)
+	}
+
+	return normalized, nil
+}
+
+func validate(schema map[string]interface{}) error {
+	for key, cond := range schema {
+		switch key {
+		case andOperator, orOperator:
+			conds, ok := cond.([]interface{})
+			if !ok || len(conds) == 0 {
+				return fmt.Errorf("%w: %s must be a non-empty array of filters", ErrInvalidFilter, key)
+			}
+
+			for _, c := range conds {
+				sub, ok := c.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("%w: %s must be a non-empty array of filters", ErrInvalidFilter, key)
+				}
+
+				if err := validate(sub); err != nil {
+					return err
+				}
+			}
+		default:
+			if strings.HasPrefix(key, "$") {
+				return fmt.Errorf("%w: unknown operator %s", ErrInvalidFilter, key)
+			}
+
+			if len(strings.TrimSpace(key)) == 0 {
+				return fmt.Errorf("%w: field path cannot be empty", ErrInvalidFilter)
+			}
+
+			if err := validateCondition(key, cond); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateCondition(key string, cond interface{}) error {
+	m, ok := cond.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if !isOperatorObject(m) {
+		for k := range m {
+			if strings.HasPrefix(k, "$") {
+				return fmt.Errorf("%w: %s cannot mix operators and fields", ErrInvalidFilter, key)
+			}
+		}
+
+		return validate(m)
+	}
+
+	for op, arg := range m {
+		switch op {
+		case "$eq", "$neq":
+		case "$gt", "$gte", "$lt", "$lte":
+			switch arg.(type) {
+			case float64, string:
+			default:
+				return fmt.Errorf("%w: %s on %s must be a number or string", ErrInvalidFilter, op, key)
+			}
+		case "$in", "$nin":
+			if _, ok := arg.([]interface{}); !ok {
+				return fmt.Errorf("%w: %s on %s must be an array", ErrInvalidFilter, op, key)
+			}
+		case "$exists":
+			if _, ok := arg.(bool); !ok {
+				return fmt.Errorf("%w: %s on %s must be a boolean", ErrInvalidFilter, op, key)
+			}
+		default:
+			return fmt.Errorf("%w: unknown operator %s", ErrInvalidFilter, op)
+		}
+	}
+
+	return nil
+}
+
+func matchDocument(schema map[string]interface{}, doc interface{}) bool {
+	for key, cond := range schema {
+		switch key {
+		case andOperator:
+			for _, c := range cond.([]interface{}) {
+				if !matchDocument(c.(map[string]interface{}), doc) {
+					return false
+				}
+			}
+		case orOperator:
+			matched := false
+			for _, c := range cond.([]interface{}) {
+				if matchDocument(c.(map[string]interface{}), doc) {
+					matched = true
+					break
+				}
+			}
+
+			if !matched {
+				return false
+			}
+		default:
+			v, ok := lookup(doc, key)
+			if !matchCondition(cond, v, ok) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func matchCondition(cond interface{}, v interface{}, exists bool) bool {
+	m, ok := cond.(map[string]interface{})
+	if !ok {
+		return exists && equal(v, cond)
+	}
+
+	if !isOperatorObject(m) {
+		return exists && matchDocument(m, v)
+	}
+
+	for op, arg := range m {
+		var matched bool
+		switch op {
+		case "$eq":
+			matched = exists && equal(v, arg)
+		case "$neq":
+			matched = !exists || !equal(v, arg)
+		case "$gt", "$gte", "$lt", "$lte":
+			matched = exists && compare(op, v, arg)
+		case "$in":
+			matched = exists && in(v, arg.([]interface{}))
+		case "$nin":
+			matched = !exists || !in(v, arg.([]interface{}))
+		case "$exists":
+			matched = exists == arg.(bool)
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isOperatorObject(m map[string]interface{}) bool {
+	if len(m) == 0 {
+		return false
+	}
+
+	for k := range m {
+		if !strings.HasPrefix(k, "$") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equal compares v to arg. When v is an array and arg is not, it matches
+// if any element of v is equal to arg.
+func equal(v, arg interface{}) bool {
+	if reflect.DeepEqual(v, arg) {
+		return true
+	}
+
+	if _, ok := arg.([]interface{}); ok {
+		return false
+	}
+
+	if arr, ok := v.([]interface{}); ok {
+		for _, e := range arr {
+			if reflect.DeepEqual(e, arg) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func in(v interface{}, args []interface{}) bool {
+	for _, arg := range args {
+		if equal(v, arg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func compare(op string, v, arg interface{}) bool {
+	var c int
+	switch a := arg.(type) {
+	case float64:
+		n, ok := v.(float64)
+		if !ok {
+			return false
+		}
+
+		switch {
+		case n < a:
+			c = -1
+		case n > a:
+			c = 1
+		}
+	case string:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+
+		c = strings.Compare(s, a)
+	default:
+		return false
+	}
+
+	switch op {
+	case "$gt":
+		return c > 0
+	case "$gte":
+		return c >= 0
+	case "$lt":
+		return c < 0
+	case "$lte":
+		return c <= 0
+	}
+
+	return false
+}
+
+func lookup(v interface{}, path string) (interface{}, bool) {
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			next, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			v = next
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return v, true
+}
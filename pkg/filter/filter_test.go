@@ -0,0 +1,111 @@
+package filter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Match(t *testing.T) {
+	payload := `{
+		"event": "invoice.paid",
+		"data": {
+			"amount": 2000,
+			"currency": "NGN",
+			"tags": ["priority", "retail"],
+			"customer": {"id": "cus_1", "email": null}
+		}
+	}`
+
+	tests := map[string]struct {
+		schema string
+		want   bool
+	}{
+		"empty_filter":              {schema: `{}`, want: true},
+		"equality":                  {schema: `{"event": "invoice.paid"}`, want: true},
+		"equality_mismatch":         {schema: `{"event": "invoice.created"}`, want: false},
+		"nested_path":               {schema: `{"data.customer.id": "cus_1"}`, want: true},
+		"nested_object":             {schema: `{"data": {"customer": {"id": "cus_1"}}}`, want: true},
+		"array_contains":            {schema: `{"data.tags": "retail"}`, want: true},
+		"array_index":               {schema: `{"data.tags.0": "priority"}`, want: true},
+		"greater_than":              {schema: `{"data.amount": {"$gt": 1000}}`, want: true},
+		"greater_than_mismatch":     {schema: `{"data.amount": {"$gt": 2000}}`, want: false},
+		"range":                     {schema: `{"data.amount": {"$gte": 2000, "$lt": 3000}}`, want: true},
+		"string_comparison":         {schema: `{"data.currency": {"$lte": "NGN"}}`, want: true},
+		"type_mismatch_comparison":  {schema: `{"data.currency": {"$gt": 10}}`, want: false},
+		"in":                        {schema: `{"data.currency": {"$in": ["NGN", "USD"]}}`, want: true},
+		"not_in":                    {schema: `{"data.currency": {"$nin": ["NGN", "USD"]}}`, want: false},
+		"not_equal":                 {schema: `{"event": {"$neq": "invoice.created"}}`, want: true},
+		"exists":                    {schema: `{"data.customer.email": {"$exists": true}}`, want: true},
+		"not_exists":                {schema: `{"data.customer.phone": {"$exists": false}}`, want: true},
+		"missing_field":             {schema: `{"data.customer.phone": "0800"}`, want: false},
+		"and":                       {schema: `{"$and": [{"event": "invoice.paid"}, {"data.amount": {"$gt": 1000}}]}`, want: true},
+		"and_mismatch":              {schema: `{"$and": [{"event": "invoice.paid"}, {"data.amount": {"$lt": 1000}}]}`, want: false},
+		"or":                        {schema: `{"$or": [{"event": "invoice.created"}, {"data.amount": {"$gt": 1000}}]}`, want: true},
+		"or_mismatch":               {schema: `{"$or": [{"event": "invoice.created"}, {"data.amount": {"$lt": 1000}}]}`, want: false},
+		"fields_are_implicitly_and": {schema: `{"event": "invoice.paid", "data.currency": "USD"}`, want: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var schema map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			f, err := New(schema)
+			require.NoError(t, err)
+
+			got, err := f.MatchJSON(json.RawMessage(payload))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func Test_New(t *testing.T) {
+	tests := map[string]struct {
+		schema  string
+		wantErr bool
+	}{
+		"valid_filter":          {schema: `{"data.amount": {"$gt": 1000}, "$or": [{"event": "a"}, {"event": "b"}]}`},
+		"unknown_operator":      {schema: `{"data.amount": {"$regex": "1.*"}}`, wantErr: true},
+		"unknown_top_level":     {schema: `{"$not": {"event": "a"}}`, wantErr: true},
+		"in_requires_array":     {schema: `{"event": {"$in": "a"}}`, wantErr: true},
+		"exists_requires_bool":  {schema: `{"event": {"$exists": "yes"}}`, wantErr: true},
+		"gt_requires_scalar":    {schema: `{"event": {"$gt": [1]}}`, wantErr: true},
+		"or_requires_array":     {schema: `{"$or": {"event": "a"}}`, wantErr: true},
+		"mixed_fields_and_ops":  {schema: `{"data": {"$gt": 1, "amount": 2}}`, wantErr: true},
+		"nested_invalid_filter": {schema: `{"$and": [{"event": {"$in": 1}}]}`, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var schema map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(tc.schema), &schema))
+
+			_, err := New(schema)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidFilter)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_NewWithKeys(t *testing.T) {
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"$or": [{"x-tenant": "acme"}, {"$and": [{"x-region": "eu"}, {"x-tier": {"$in": ["gold"]}}]}]}`), &schema))
+
+	f, err := NewWithKeys(schema, strings.ToUpper)
+	require.NoError(t, err)
+
+	require.True(t, f.Match(map[string]interface{}{"X-TENANT": "acme"}))
+	require.True(t, f.Match(map[string]interface{}{"X-REGION": "eu", "X-TIER": "gold"}))
+	require.False(t, f.Match(map[string]interface{}{"x-tenant": "acme"}))
+
+	_, err = NewWithKeys(map[string]interface{}{"$or": "x-tenant"}, strings.ToUpper)
+	require.ErrorIs(t, err, ErrInvalidFilter)
+}
@@ -0,0 +1,166 @@
+package transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var ErrUnsupportedTransform = errors.New("unsupported transform type")
+var ErrEmptyTemplate = errors.New("transform template cannot be empty")
+var ErrEmptyMapping = errors.New("transform mapping cannot be empty")
+var ErrInvalidOutput = errors.New("transformed payload is not valid JSON")
+
+// Transformer rewrites an event payload before it is sent to an endpoint.
+type Transformer interface {
+	Transform(payload json.RawMessage) (json.RawMessage, error)
+}
+
+// Field maps a value from the source payload to the transformed payload.
+// Both paths are dot separated, array elements are addressed by their
+// index, e.g. "data.items.0.id". The source path "$" refers to the
+// whole payload.
+type Field struct {
+	From string
+	To   string
+}
+
+type Mapper struct {
+	fields []Field
+}
+
+func NewMapper(fields []Field) (*Mapper, error) {
+	if len(fields) == 0 {
+		return nil, ErrEmptyMapping
+	}
+
+	for _, f := range fields {
+		if len(strings.TrimSpace(f.From)) == 0 || len(strings.TrimSpace(f.To)) == 0 {
+			return nil, errors.New("transform mapping fields must have a from and to path")
+		}
+	}
+
+	return &Mapper{fields: fields}, nil
+}
+
+// Transform builds a new JSON object from the mapped fields. Fields whose
+// source path does not exist in the payload are left out.
+func (m *Mapper) Transform(payload json.RawMessage) (json.RawMessage, error) {
+	var src interface{}
+	if err := json.Unmarshal(payload, &src); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %v", err)
+	}
+
+	out := map[string]interface{}{}
+	for _, f := range m.fields {
+		v, ok := Lookup(src, f.From)
+		if !ok {
+			continue
+		}
+
+		set(out, f.To, v)
+	}
+
+	return json.Marshal(out)
+}
+
+type Template struct {
+	tmpl *template.Template
+}
+
+func NewTemplate(tmpl string) (*Template, error) {
+	if len(strings.TrimSpace(tmpl)) == 0 {
+		return nil, ErrEmptyTemplate
+	}
+
+	t, err := template.New("transform").
+		Option("missingkey=zero").
+		Funcs(template.FuncMap{"json": toJSON}).
+		Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transform template: %v", err)
+	}
+
+	return &Template{tmpl: t}, nil
+}
+
+// Transform executes the template with the decoded payload as its data.
+// Values can be written as JSON with the json function,
+// e.g. {"total": {{ json .data.amount }}}.
+func (t *Template) Transform(payload json.RawMessage) (json.RawMessage, error) {
+	var src interface{}
+	if err := json.Unmarshal(payload, &src); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := t.tmpl.Execute(buf, src); err != nil {
+		return nil, fmt.Errorf("failed to execute transform template: %v", err)
+	}
+
+	out := bytes.TrimSpace(buf.Bytes())
+	if !json.Valid(out) {
+		return nil, ErrInvalidOutput
+	}
+
+	return json.RawMessage(out), nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// Lookup resolves a dot path such as $.data.id or data.items.0 in a decoded json value.
+func Lookup(v interface{}, path string) (interface{}, bool) {
+	path = strings.TrimSpace(path)
+	if path == "$" {
+		return v, true
+	}
+
+	path = strings.TrimPrefix(path, "$.")
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			next, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			v = next
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			v = node[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return v, true
+}
+
+func set(out map[string]interface{}, path string, v interface{}) {
+	keys := strings.Split(strings.TrimSpace(path), ".")
+
+	node := out
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := node[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			node[key] = next
+		}
+		node = next
+	}
+
+	node[keys[len(keys)-1]] = v
+}
@@ -0,0 +1,129 @@
+package transform
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Mapper(t *testing.T) {
+	tests := map[string]struct {
+		fields   []Field
+		payload  string
+		expected string
+		wantErr  bool
+	}{
+		"map_top_level_fields": {
+			fields: []Field{
+				{From: "event", To: "type"},
+				{From: "data.amount", To: "amount"},
+			},
+			payload:  `{"event": "invoice.paid", "data": {"amount": 2000}}`,
+			expected: `{"type": "invoice.paid", "amount": 2000}`,
+		},
+		"map_into_nested_fields": {
+			fields: []Field{
+				{From: "data.customer.id", To: "customer.uid"},
+				{From: "data.items.0.name", To: "customer.first_item"},
+			},
+			payload:  `{"data": {"customer": {"id": "cus_1"}, "items": [{"name": "shoe"}]}}`,
+			expected: `{"customer": {"uid": "cus_1", "first_item": "shoe"}}`,
+		},
+		"map_whole_payload": {
+			fields: []Field{
+				{From: "$", To: "payload"},
+			},
+			payload:  `{"event": "invoice.paid"}`,
+			expected: `{"payload": {"event": "invoice.paid"}}`,
+		},
+		"skip_missing_fields": {
+			fields: []Field{
+				{From: "event", To: "type"},
+				{From: "data.amount", To: "amount"},
+			},
+			payload:  `{"event": "invoice.paid"}`,
+			expected: `{"type": "invoice.paid"}`,
+		},
+		"invalid_payload": {
+			fields: []Field{
+				{From: "event", To: "type"},
+			},
+			payload: `{"event": `,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, err := NewMapper(tc.fields)
+			require.NoError(t, err)
+
+			out, err := m.Transform(json.RawMessage(tc.payload))
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expected, string(out))
+		})
+	}
+}
+
+func Test_Template(t *testing.T) {
+	tests := map[string]struct {
+		tmpl     string
+		payload  string
+		expected string
+		wantErr  error
+	}{
+		"render_template": {
+			tmpl:     `{"text": "Invoice {{ .data.id }} was paid", "amount": {{ json .data.amount }}}`,
+			payload:  `{"data": {"id": "inv_1", "amount": 2000}}`,
+			expected: `{"text": "Invoice inv_1 was paid", "amount": 2000}`,
+		},
+		"render_nested_values_as_json": {
+			tmpl:     `{"customer": {{ json .data.customer }}}`,
+			payload:  `{"data": {"customer": {"id": "cus_1", "tags": ["a", "b"]}}}`,
+			expected: `{"customer": {"id": "cus_1", "tags": ["a", "b"]}}`,
+		},
+		"invalid_json_output": {
+			tmpl:    `{"text": {{ .data.id }}}`,
+			payload: `{"data": {"id": "inv_1"}}`,
+			wantErr: ErrInvalidOutput,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := NewTemplate(tc.tmpl)
+			require.NoError(t, err)
+
+			out, err := tmpl.Transform(json.RawMessage(tc.payload))
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expected, string(out))
+		})
+	}
+}
+
+func Test_NewTemplate(t *testing.T) {
+	_, err := NewTemplate(" ")
+	require.ErrorIs(t, err, ErrEmptyTemplate)
+
+	_, err = NewTemplate("{{ .data ")
+	require.Error(t, err)
+}
+
+func Test_NewMapper(t *testing.T) {
+	_, err := NewMapper(nil)
+	require.ErrorIs(t, err, ErrEmptyMapping)
+
+	_, err = NewMapper([]Field{{From: "event"}})
+	require.Error(t, err)
+}
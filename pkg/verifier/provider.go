@@ -0,0 +1,336 @@
+package verifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrProviderNotFound = errors.New("Provider type undefined")
+var ErrTimestampOutsideTolerance = errors.New("Timestamp is outside the tolerance window")
+
+// DefaultTolerance is how old a signed timestamp can be, it is the
+// window Stripe and Slack recommend to reject replayed requests.
+const DefaultTolerance = 5 * time.Minute
+
+// ProviderFunc returns the verifier of a provider for the source's secret.
+type ProviderFunc func(secret string) Verifier
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]ProviderFunc{}
+)
+
+// Register makes a provider's verifier available to NewProviderVerifier,
+// registering a provider twice replaces the previous verifier.
+func Register(provider string, fn ProviderFunc) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	providers[provider] = fn
+}
+
+// IsRegistered reports whether the provider has a verifier.
+func IsRegistered(provider string) bool {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	_, ok := providers[provider]
+	return ok
+}
+
+// NewProviderVerifier returns the verifier registered for the provider.
+func NewProviderVerifier(provider string, secret string) (Verifier, error) {
+	providersMu.RLock()
+	fn, ok := providers[provider]
+	providersMu.RUnlock()
+
+	if !ok {
+		return nil, ErrProviderNotFound
+	}
+
+	return fn(secret), nil
+}
+
+func init() {
+	Register("github", func(secret string) Verifier { return NewGithubVerifier(secret) })
+	Register("twitter", func(secret string) Verifier { return NewTwitterVerifier(secret) })
+	Register("shopify", func(secret string) Verifier { return NewShopifyVerifier(secret) })
+	Register("stripe", func(secret string) Verifier { return NewStripeVerifier(secret) })
+	Register("slack", func(secret string) Verifier { return NewSlackVerifier(secret) })
+	Register("paystack", func(secret string) Verifier { return NewPaystackVerifier(secret) })
+	Register("twilio", func(secret string) Verifier { return NewTwilioVerifier(secret) })
+	Register("gitlab", func(secret string) Verifier { return NewGitlabVerifier(secret) })
+}
+
+// checkTimestamp verifies that the unix timestamp ts is within tolerance of now.
+func checkTimestamp(ts string, tolerance time.Duration, now time.Time) error {
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidHeaderStructure
+	}
+
+	diff := now.Sub(time.Unix(sec, 0))
+	if diff < 0 {
+		diff = -diff
+	}
+
+	if diff > tolerance {
+		return ErrTimestampOutsideTolerance
+	}
+
+	return nil
+}
+
+func computeHmacSHA256(secret string, parts ...string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	for _, p := range parts {
+		mac.Write([]byte(p))
+	}
+
+	return mac.Sum(nil)
+}
+
+// StripeVerifier verifies the Stripe-Signature header, it holds the
+// timestamp and one or more v1 signatures of "timestamp.payload".
+type StripeVerifier struct {
+	secret    string
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+func NewStripeVerifier(secret string) *StripeVerifier {
+	return &StripeVerifier{secret: secret, tolerance: DefaultTolerance, now: time.Now}
+}
+
+func (sV *StripeVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	header := r.Header.Get("Stripe-Signature")
+	if len(strings.TrimSpace(header)) == 0 {
+		return ErrSignatureCannotBeEmpty
+	}
+
+	var timestamp string
+	var signatures []string
+
+	for _, pair := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case "v1":
+			signatures = append(signatures, kv[1])
+		}
+	}
+
+	if len(timestamp) == 0 || len(signatures) == 0 {
+		return ErrInvalidHeaderStructure
+	}
+
+	if err := checkTimestamp(timestamp, sV.tolerance, sV.now()); err != nil {
+		return err
+	}
+
+	computedMAC := computeHmacSHA256(sV.secret, timestamp, ".", string(payload))
+	for _, sig := range signatures {
+		sentMAC, err := hex.DecodeString(sig)
+		if err != nil {
+			continue
+		}
+
+		if hmac.Equal(sentMAC, computedMAC) {
+			return nil
+		}
+	}
+
+	return ErrHashDoesNotMatch
+}
+
+// SlackVerifier verifies the X-Slack-Signature header, a v0 signature
+// of the "v0:timestamp:payload" basestring.
+type SlackVerifier struct {
+	secret    string
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+func NewSlackVerifier(secret string) *SlackVerifier {
+	return &SlackVerifier{secret: secret, tolerance: DefaultTolerance, now: time.Now}
+}
+
+func (sV *SlackVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	timestamp := r.Header.Get("X-Slack-Request-Timestamp")
+	signature := r.Header.Get("X-Slack-Signature")
+
+	if len(strings.TrimSpace(signature)) == 0 {
+		return ErrSignatureCannotBeEmpty
+	}
+
+	version, sig, ok := cut(signature, "=")
+	if !ok || version != "v0" {
+		return ErrInvalidHeaderStructure
+	}
+
+	if err := checkTimestamp(timestamp, sV.tolerance, sV.now()); err != nil {
+		return err
+	}
+
+	sentMAC, err := hex.DecodeString(sig)
+	if err != nil {
+		return ErrCannotDecodeHexEncodedMACHeader
+	}
+
+	computedMAC := computeHmacSHA256(sV.secret, "v0:", timestamp, ":", string(payload))
+	if !hmac.Equal(sentMAC, computedMAC) {
+		return ErrHashDoesNotMatch
+	}
+
+	return nil
+}
+
+// PaystackVerifier verifies the x-paystack-signature header,
+// a hex encoded HMAC-SHA512 of the payload.
+type PaystackVerifier struct {
+	HmacOpts *HmacOptions
+}
+
+func NewPaystackVerifier(secret string) *PaystackVerifier {
+	return &PaystackVerifier{
+		HmacOpts: &HmacOptions{
+			Header:   "X-Paystack-Signature",
+			Hash:     "SHA512",
+			Secret:   secret,
+			Encoding: "hex",
+		},
+	}
+}
+
+func (pV *PaystackVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	v := HmacVerifier{pV.HmacOpts}
+	return v.VerifyRequest(r, payload)
+}
+
+// TwilioVerifier verifies the X-Twilio-Signature header, a base64 encoded
+// HMAC-SHA1 of the request url followed by the sorted form parameters.
+// JSON requests are signed without parameters, their payload is checked
+// against the bodySHA256 query parameter instead.
+type TwilioVerifier struct {
+	secret string
+}
+
+func NewTwilioVerifier(secret string) *TwilioVerifier {
+	return &TwilioVerifier{secret: secret}
+}
+
+func (tV *TwilioVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	signature := r.Header.Get("X-Twilio-Signature")
+	if len(strings.TrimSpace(signature)) == 0 {
+		return ErrSignatureCannotBeEmpty
+	}
+
+	sentMAC, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrCannotDecodeBase64EncodedMACHeader
+	}
+
+	var b strings.Builder
+	b.WriteString(requestURL(r))
+
+	if bodyHash := r.URL.Query().Get("bodySHA256"); len(bodyHash) != 0 {
+		sum := sha256.Sum256(payload)
+		if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(strings.ToLower(bodyHash))) != 1 {
+			return ErrHashDoesNotMatch
+		}
+	} else if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		params, err := url.ParseQuery(string(payload))
+		if err != nil {
+			return ErrInvalidHeaderStructure
+		}
+
+		keys := make([]string, 0, len(params))
+		for k := range params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			values := params[k]
+			sort.Strings(values)
+			for _, v := range values {
+				b.WriteString(k)
+				b.WriteString(v)
+			}
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(tV.secret))
+	mac.Write([]byte(b.String()))
+
+	if !hmac.Equal(sentMAC, mac.Sum(nil)) {
+		return ErrHashDoesNotMatch
+	}
+
+	return nil
+}
+
+// requestURL returns the url the request was sent to, Twilio
+// signs the url as it was before any proxy in front of convoy.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); len(proto) != 0 {
+		scheme = proto
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, r.Host, r.URL.RequestURI())
+}
+
+// GitlabVerifier verifies the X-Gitlab-Token header, GitLab
+// sends the secret token as is instead of a signature.
+type GitlabVerifier struct {
+	secret string
+}
+
+func NewGitlabVerifier(secret string) *GitlabVerifier {
+	return &GitlabVerifier{secret: secret}
+}
+
+func (gV *GitlabVerifier) VerifyRequest(r *http.Request, payload []byte) error {
+	token := r.Header.Get("X-Gitlab-Token")
+	if len(strings.TrimSpace(token)) == 0 {
+		return ErrAuthHeaderCannotBeEmpty
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(gV.secret)) != 1 {
+		return ErrAuthHeader
+	}
+
+	return nil
+}
+
+// cut is strings.Cut, which isn't available in go 1.16.
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+
+	return s, "", false
+}
@@ -0,0 +1,228 @@
+package verifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret, data string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func Test_NewProviderVerifier(t *testing.T) {
+	for _, provider := range []string{"github", "twitter", "shopify", "stripe", "slack", "paystack", "twilio", "gitlab"} {
+		v, err := NewProviderVerifier(provider, "Convoy")
+		require.NoError(t, err)
+		require.NotNil(t, v)
+	}
+
+	_, err := NewProviderVerifier("unknown", "Convoy")
+	require.ErrorIs(t, err, ErrProviderNotFound)
+
+	Register("custom", func(secret string) Verifier { return &NoopVerifier{} })
+	require.True(t, IsRegistered("custom"))
+}
+
+func Test_StripeVerifier_VerifyRequest(t *testing.T) {
+	now := time.Unix(1492774577, 0)
+	payload := `{"id":"evt_1"}`
+
+	tests := map[string]struct {
+		header        string
+		expectedError error
+	}{
+		"valid_signature": {
+			header: fmt.Sprintf("t=1492774577,v1=%s,v0=abc", sign("Convoy", "1492774577."+payload)),
+		},
+		"valid_second_signature": {
+			header: fmt.Sprintf("t=1492774577,v1=%s,v1=%s", sign("Old", "1492774577."+payload), sign("Convoy", "1492774577."+payload)),
+		},
+		"invalid_signature": {
+			header:        fmt.Sprintf("t=1492774577,v1=%s", sign("Convoy", "1492774578."+payload)),
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"expired_timestamp": {
+			header:        fmt.Sprintf("t=1492774000,v1=%s", sign("Convoy", "1492774000."+payload)),
+			expectedError: ErrTimestampOutsideTolerance,
+		},
+		"missing_timestamp": {
+			header:        fmt.Sprintf("v1=%s", sign("Convoy", payload)),
+			expectedError: ErrInvalidHeaderStructure,
+		},
+		"empty_header": {
+			expectedError: ErrSignatureCannotBeEmpty,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			req.Header.Add("Stripe-Signature", tc.header)
+
+			v := NewStripeVerifier("Convoy")
+			v.now = func() time.Time { return now }
+
+			require.ErrorIs(t, v.VerifyRequest(req, []byte(payload)), tc.expectedError)
+		})
+	}
+}
+
+func Test_SlackVerifier_VerifyRequest(t *testing.T) {
+	now := time.Unix(1531420618, 0)
+	payload := `token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J`
+
+	tests := map[string]struct {
+		timestamp     string
+		signature     string
+		expectedError error
+	}{
+		"valid_signature": {
+			timestamp: "1531420618",
+			signature: "v0=" + sign("Convoy", "v0:1531420618:"+payload),
+		},
+		"invalid_signature": {
+			timestamp:     "1531420618",
+			signature:     "v0=" + sign("Other", "v0:1531420618:"+payload),
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"unknown_version": {
+			timestamp:     "1531420618",
+			signature:     "v1=" + sign("Convoy", "v1:1531420618:"+payload),
+			expectedError: ErrInvalidHeaderStructure,
+		},
+		"expired_timestamp": {
+			timestamp:     "1531410618",
+			signature:     "v0=" + sign("Convoy", "v0:1531410618:"+payload),
+			expectedError: ErrTimestampOutsideTolerance,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			req.Header.Add("X-Slack-Request-Timestamp", tc.timestamp)
+			req.Header.Add("X-Slack-Signature", tc.signature)
+
+			v := NewSlackVerifier("Convoy")
+			v.now = func() time.Time { return now }
+
+			require.ErrorIs(t, v.VerifyRequest(req, []byte(payload)), tc.expectedError)
+		})
+	}
+}
+
+func Test_PaystackVerifier_VerifyRequest(t *testing.T) {
+	payload := []byte(`{"event":"charge.success"}`)
+
+	mac := hmac.New(sha512.New, []byte("Convoy"))
+	mac.Write(payload)
+
+	req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+	require.NoError(t, err)
+	req.Header.Add("x-paystack-signature", hex.EncodeToString(mac.Sum(nil)))
+
+	require.NoError(t, NewPaystackVerifier("Convoy").VerifyRequest(req, payload))
+	require.ErrorIs(t, NewPaystackVerifier("Other").VerifyRequest(req, payload), ErrHashDoesNotMatch)
+}
+
+func Test_TwilioVerifier_VerifyRequest(t *testing.T) {
+	twilioSign := func(data string) string {
+		mac := hmac.New(sha1.New, []byte("Convoy"))
+		mac.Write([]byte(data))
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	jsonPayload := `{"CallSid":"CA1"}`
+	bodyHash := sha256.Sum256([]byte(jsonPayload))
+	jsonURL := "https://convoy.example.com/ingest/abc?bodySHA256=" + hex.EncodeToString(bodyHash[:])
+
+	tests := map[string]struct {
+		url           string
+		contentType   string
+		payload       string
+		signature     string
+		expectedError error
+	}{
+		"valid_form_signature": {
+			url:         "https://convoy.example.com/ingest/abc",
+			contentType: "application/x-www-form-urlencoded",
+			payload:     "To=%2B1234&CallSid=CA1&From=%2B5678",
+			signature:   twilioSign("https://convoy.example.com/ingest/abcCallSidCA1From+5678To+1234"),
+		},
+		"invalid_form_signature": {
+			url:           "https://convoy.example.com/ingest/abc",
+			contentType:   "application/x-www-form-urlencoded",
+			payload:       "To=%2B1234&CallSid=CA2&From=%2B5678",
+			signature:     twilioSign("https://convoy.example.com/ingest/abcCallSidCA1From+5678To+1234"),
+			expectedError: ErrHashDoesNotMatch,
+		},
+		"valid_json_signature": {
+			url:         jsonURL,
+			contentType: "application/json",
+			payload:     jsonPayload,
+			signature:   twilioSign(jsonURL),
+		},
+		"tampered_json_payload": {
+			url:           jsonURL,
+			contentType:   "application/json",
+			payload:       `{"CallSid":"CA2"}`,
+			signature:     twilioSign(jsonURL),
+			expectedError: ErrHashDoesNotMatch,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", strings.Replace(tc.url, "https", "http", 1), strings.NewReader(``))
+			require.NoError(t, err)
+			req.Header.Add("Content-Type", tc.contentType)
+			req.Header.Add("X-Forwarded-Proto", "https")
+			req.Header.Add("X-Twilio-Signature", tc.signature)
+
+			require.ErrorIs(t, NewTwilioVerifier("Convoy").VerifyRequest(req, []byte(tc.payload)), tc.expectedError)
+		})
+	}
+}
+
+func Test_GitlabVerifier_VerifyRequest(t *testing.T) {
+	tests := map[string]struct {
+		token         string
+		expectedError error
+	}{
+		"valid_token": {
+			token: "Convoy",
+		},
+		"invalid_token": {
+			token:         "Other",
+			expectedError: ErrAuthHeader,
+		},
+		"empty_token": {
+			expectedError: ErrAuthHeaderCannotBeEmpty,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+			require.NoError(t, err)
+			req.Header.Add("X-Gitlab-Token", tc.token)
+
+			require.ErrorIs(t, NewGitlabVerifier("Convoy").VerifyRequest(req, []byte(`{}`)), tc.expectedError)
+		})
+	}
+}
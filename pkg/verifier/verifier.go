@@ -1,6 +1,7 @@
 package verifier
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"crypto/sha512"
@@ -10,6 +11,7 @@ import (
 	"hash"
 	"net/http"
 	"strings"
+	"time"
 )
 
 var ErrAlgoNotFound = errors.New("Algorithm not found")
@@ -24,6 +26,8 @@ var ErrAuthHeaderCannotBeEmpty = errors.New("Auth header cannot be empty")
 var ErrInvalidHeaderStructure = errors.New("Invalid header structure")
 var ErrInvalidAuthLength = errors.New("Invalid Basic Auth Length")
 var ErrInvalidEncoding = errors.New("Invalid header encoding")
+var ErrTimestampCannotBeEmpty = errors.New("Timestamp cannot be empty")
+var ErrReplayedRequest = errors.New("Request has already been received")
 
 type Verifier interface {
 	VerifyRequest(r *http.Request, payload []byte) error
@@ -35,6 +39,21 @@ type HmacOptions struct {
 	Hash         string
 	Secret       string
 	Encoding     string
+
+	// TimestampHeader is the header holding the request's unix timestamp,
+	// when it is set the signed content is "timestamp,payload" and the
+	// timestamp must be within Tolerance.
+	TimestampHeader string
+	Tolerance       time.Duration
+
+	// Nonces remembers the verified signatures of timestamped requests
+	// so that a request can't be replayed within the tolerance window.
+	Nonces NonceCache
+}
+
+// NonceCache is the subset of cache.Cache used to remember signatures.
+type NonceCache interface {
+	SetIfNotExists(ctx context.Context, key string, data interface{}, expiration time.Duration) (bool, error)
 }
 
 type HmacVerifier struct {
@@ -63,7 +82,22 @@ func (hV *HmacVerifier) VerifyRequest(r *http.Request, payload []byte) error {
 		return ErrSignatureCannotBeEmpty
 	}
 
+	var timestamp string
+	if len(hV.opts.TimestampHeader) != 0 {
+		timestamp = r.Header.Get(hV.opts.TimestampHeader)
+		if len(strings.TrimSpace(timestamp)) == 0 {
+			return ErrTimestampCannotBeEmpty
+		}
+
+		if err = checkTimestamp(timestamp, hV.tolerance(), time.Now()); err != nil {
+			return err
+		}
+	}
+
 	mac := hmac.New(hash, []byte(hV.opts.Secret))
+	if len(timestamp) != 0 {
+		mac.Write([]byte(timestamp + ","))
+	}
 	mac.Write(payload)
 	computedMAC := mac.Sum(nil)
 
@@ -88,6 +122,37 @@ func (hV *HmacVerifier) VerifyRequest(r *http.Request, payload []byte) error {
 		return ErrHashDoesNotMatch
 	}
 
+	if len(timestamp) != 0 && hV.opts.Nonces != nil {
+		return hV.checkNonce(r.Context(), computedMAC)
+	}
+
+	return nil
+}
+
+func (hV *HmacVerifier) tolerance() time.Duration {
+	if hV.opts.Tolerance <= 0 {
+		return DefaultTolerance
+	}
+
+	return hV.opts.Tolerance
+}
+
+// checkNonce rejects a signature that has been verified before, a signature
+// is remembered for twice the tolerance to cover timestamps in the future.
+// The signature is remembered atomically, so of concurrent replays only the
+// first request passes.
+func (hV *HmacVerifier) checkNonce(ctx context.Context, mac []byte) error {
+	key := "nonces:" + hex.EncodeToString(mac)
+
+	first, err := hV.opts.Nonces.SetIfNotExists(ctx, key, true, 2*hV.tolerance())
+	if err != nil {
+		return err
+	}
+
+	if !first {
+		return ErrReplayedRequest
+	}
+
 	return nil
 }
 
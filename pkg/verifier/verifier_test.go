@@ -1,11 +1,17 @@
 package verifier
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	mcache "github.com/frain-dev/convoy/cache/memory"
 	"github.com/stretchr/testify/require"
 )
 
@@ -454,3 +460,72 @@ func Test_TwitterVerifier_VerifyRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_HmacVerifier_VerifyTimestampedRequest(t *testing.T) {
+	payload := []byte(`Test Payload Body`)
+	unix := time.Now().Unix()
+	now := fmt.Sprint(unix)
+	old := fmt.Sprint(unix - 600)
+
+	newRequest := func(t *testing.T, timestamp string) *http.Request {
+		req, err := http.NewRequest("POST", "URL", strings.NewReader(``))
+		require.NoError(t, err)
+
+		mac := hmac.New(sha256.New, []byte("Convoy"))
+		mac.Write([]byte(timestamp + "," + string(payload)))
+
+		req.Header.Add("X-Convoy-Signature", hex.EncodeToString(mac.Sum(nil)))
+		req.Header.Add("X-Convoy-Timestamp", timestamp)
+		return req
+	}
+
+	opts := &HmacOptions{
+		Header:          "X-Convoy-Signature",
+		Hash:            "SHA256",
+		Secret:          "Convoy",
+		Encoding:        "hex",
+		TimestampHeader: "X-Convoy-Timestamp",
+		Tolerance:       5 * time.Minute,
+		Nonces:          mcache.NewMemoryCache(),
+	}
+	v := NewHmacVerifier(opts)
+
+	require.NoError(t, v.VerifyRequest(newRequest(t, now), payload))
+	require.ErrorIs(t, v.VerifyRequest(newRequest(t, now), payload), ErrReplayedRequest)
+	require.ErrorIs(t, v.VerifyRequest(newRequest(t, old), payload), ErrTimestampOutsideTolerance)
+	require.ErrorIs(t, v.VerifyRequest(newRequest(t, ""), payload), ErrTimestampCannotBeEmpty)
+
+	// the timestamp is part of the signed content.
+	req := newRequest(t, now)
+	req.Header.Set("X-Convoy-Timestamp", fmt.Sprint(unix-1))
+	require.ErrorIs(t, v.VerifyRequest(req, payload), ErrHashDoesNotMatch)
+
+	// of concurrent replays of a request only one passes.
+	replayed := fmt.Sprint(unix - 2)
+
+	reqs := make([]*http.Request, 10)
+	for i := range reqs {
+		reqs[i] = newRequest(t, replayed)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(reqs))
+	for i := range reqs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = v.VerifyRequest(reqs[i], payload)
+		}(i)
+	}
+	wg.Wait()
+
+	var passed int
+	for _, err := range errs {
+		if err == nil {
+			passed++
+			continue
+		}
+		require.ErrorIs(t, err, ErrReplayedRequest)
+	}
+	require.Equal(t, 1, passed)
+}
@@ -10,6 +10,10 @@ import (
 
 type Queuer interface {
 	Write(convoy.TaskName, convoy.QueueName, *Job) error
+	// WriteMany writes the jobs concurrently, the returned errors are in
+	// the same order as the jobs. Every job is written on its own, some of
+	// them can fail while the others are written.
+	WriteMany(convoy.TaskName, convoy.QueueName, []*Job) []error
 	Options() QueueOptions
 }
 
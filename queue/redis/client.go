@@ -2,6 +2,7 @@ package redis
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/config"
@@ -12,6 +13,10 @@ import (
 	"github.com/hibiken/asynqmon"
 )
 
+// writeConcurrency is how many jobs WriteMany enqueues at once, asynq
+// enqueues a task per round trip and has no pipelined enqueue.
+const writeConcurrency = 20
+
 type RedisQueue struct {
 	opts      queue.QueueOptions
 	client    *asynq.Client
@@ -52,6 +57,28 @@ func (q *RedisQueue) Write(taskName convoy.TaskName, queueName convoy.QueueName,
 	return err
 }
 
+func (q *RedisQueue) WriteMany(taskName convoy.TaskName, queueName convoy.QueueName, jobs []*queue.Job) []error {
+	errs := make([]error, len(jobs))
+	sem := make(chan struct{}, writeConcurrency)
+
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, job *queue.Job) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errs[i] = q.Write(taskName, queueName, job)
+		}(i, job)
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
 func (q *RedisQueue) Options() queue.QueueOptions {
 	return q.opts
 }
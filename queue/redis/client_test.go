@@ -59,6 +59,38 @@ func TestWrite(t *testing.T) {
 
 }
 
+func TestWriteMany(t *testing.T) {
+	eventQueue := initializeQueue("../testdata/convoy_redis.json", uuid.NewString(), t)
+
+	jobs := make([]*queue.Job, 0, 50)
+	for i := 0; i < 50; i++ {
+		jobs = append(jobs, &queue.Job{
+			ID:      uuid.NewString(),
+			Payload: json.RawMessage(uuid.NewString()),
+		})
+	}
+
+	// the same task id can't be enqueued twice
+	jobs = append(jobs, &queue.Job{ID: jobs[0].ID, Payload: jobs[0].Payload})
+
+	taskName := convoy.TaskName(uuid.NewString())
+	errs := eventQueue.WriteMany(taskName, convoy.CreateEventQueue, jobs)
+	if len(errs) != len(jobs) {
+		t.Fatalf("Want %d results, got %d", len(jobs), len(errs))
+	}
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+
+	if failed != 1 {
+		t.Fatalf("Want 1 failed job, got %d", failed)
+	}
+}
+
 func initializeQueue(configFile string, name string, t *testing.T) queue.Queuer {
 	err := config.LoadConfig(configFile)
 	if err != nil {
@@ -1,45 +1,83 @@
 package retrystrategies
 
 import (
+	"math"
 	"math/rand"
 	"time"
+
+	"github.com/frain-dev/convoy/datastore"
 )
 
-type JitterFn func(uint) int
+// RandFn returns a random number in [0, n).
+type RandFn func(n int64) int64
 
-// based off https://blog.gopheracademy.com/advent-2014/backoff/
+// based off https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
 type ExponentialBackoffRetryStrategy struct {
-	millis   []uint
-	jitterFn JitterFn
+	base       time.Duration
+	multiplier float64
+	max        time.Duration
+	jitter     datastore.JitterMode
+	randFn     RandFn
 }
 
 func (r *ExponentialBackoffRetryStrategy) NextDuration(attempts uint64) time.Duration {
-	if int(attempts) >= len(r.millis) {
-		attempts = uint64(len(r.millis) - 1)
+	d := r.backoff(attempts)
+
+	switch r.jitter {
+	case datastore.EqualJitter:
+		return d/2 + r.between(0, d/2)
+	case datastore.DecorrelatedJitter:
+		// the delay of the previous attempt isn't stored, so the upper
+		// bound is derived from its backoff without jitter
+		upper := r.base * 3
+		if attempts > 0 {
+			upper = r.backoff(attempts-1) * 3
+		}
+
+		return r.cap(r.between(r.base, upper))
+	default:
+		return r.between(0, d)
+	}
+}
+
+// backoff returns base * multiplier^attempts, capped at the max interval.
+func (r *ExponentialBackoffRetryStrategy) backoff(attempts uint64) time.Duration {
+	d := float64(r.base) * math.Pow(r.multiplier, float64(attempts))
+	if math.IsInf(d, 0) || math.IsNaN(d) || d >= float64(r.max) {
+		return r.max
 	}
 
-	return time.Duration(r.jitterFn(r.millis[attempts])) * time.Millisecond
+	return time.Duration(d)
 }
 
-func jitter(millis uint) int {
-	if millis == 0 {
-		return 0
+// between returns a random duration in [min, max].
+func (r *ExponentialBackoffRetryStrategy) between(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
 	}
 
-	return int(millis/2) + rand.Intn(int(millis))
+	return min + time.Duration(r.randFn(int64(max-min)+1))
 }
 
-func NewExponential(millis []uint) *ExponentialBackoffRetryStrategy {
-	return &ExponentialBackoffRetryStrategy{
-		millis:   millis,
-		jitterFn: jitter,
+func (r *ExponentialBackoffRetryStrategy) cap(d time.Duration) time.Duration {
+	if d > r.max {
+		return r.max
 	}
+
+	return d
+}
+
+func NewExponential(base time.Duration, multiplier float64, max time.Duration, jitter datastore.JitterMode) *ExponentialBackoffRetryStrategy {
+	return NewExponentialWithRand(base, multiplier, max, jitter, rand.Int63n)
 }
 
-func NewExponentialWithJitter(millis []uint, customJitter JitterFn) *ExponentialBackoffRetryStrategy {
+func NewExponentialWithRand(base time.Duration, multiplier float64, max time.Duration, jitter datastore.JitterMode, randFn RandFn) *ExponentialBackoffRetryStrategy {
 	return &ExponentialBackoffRetryStrategy{
-		millis:   millis,
-		jitterFn: customJitter,
+		base:       base,
+		multiplier: multiplier,
+		max:        max,
+		jitter:     jitter,
+		randFn:     randFn,
 	}
 }
 
@@ -3,57 +3,112 @@ package retrystrategies
 import (
 	"testing"
 	"time"
+
+	"github.com/frain-dev/convoy/datastore"
 )
 
+func maxRand(n int64) int64 {
+	return n - 1
+}
+
+func minRand(n int64) int64 {
+	return 0
+}
+
 func TestExponentialBackoffRetryStrategy(t *testing.T) {
 	tests := []struct {
 		name             string
 		expectedDuration time.Duration
 		attempts         uint64
-		jitterFn         JitterFn
-		millis           []uint
+		multiplier       float64
+		jitter           datastore.JitterMode
+		randFn           RandFn
 	}{
 		{
-			name:             "no-delay-for-initial-attempt",
-			expectedDuration: time.Duration(0) * time.Millisecond,
+			name:             "base-interval-for-initial-attempt",
+			expectedDuration: 10 * time.Second,
 			attempts:         0,
-			jitterFn: func(u uint) int {
-				return int(u)
-			},
-			millis: []uint{0, 100, 200, 1000},
+			multiplier:       2,
+			jitter:           datastore.FullJitter,
+			randFn:           maxRand,
 		},
 		{
 			name:             "duration-dependent-on-attempts",
-			expectedDuration: time.Duration(100) * time.Millisecond,
-			attempts:         1,
-			jitterFn: func(u uint) int {
-				return int(u)
-			},
-			millis: []uint{0, 100, 200, 1000},
+			expectedDuration: 40 * time.Second,
+			attempts:         2,
+			multiplier:       2,
+			jitter:           datastore.FullJitter,
+			randFn:           maxRand,
+		},
+		{
+			name:             "duration-dependent-on-multiplier",
+			expectedDuration: 90 * time.Second,
+			attempts:         2,
+			multiplier:       3,
+			jitter:           datastore.FullJitter,
+			randFn:           maxRand,
+		},
+		{
+			name:             "duration-capped-at-max-interval",
+			expectedDuration: 10 * time.Minute,
+			attempts:         200,
+			multiplier:       2,
+			jitter:           datastore.FullJitter,
+			randFn:           maxRand,
+		},
+		{
+			name:             "full-jitter-lower-bound",
+			expectedDuration: 0,
+			attempts:         2,
+			multiplier:       2,
+			jitter:           datastore.FullJitter,
+			randFn:           minRand,
+		},
+		{
+			name:             "equal-jitter-lower-bound",
+			expectedDuration: 20 * time.Second,
+			attempts:         2,
+			multiplier:       2,
+			jitter:           datastore.EqualJitter,
+			randFn:           minRand,
+		},
+		{
+			name:             "equal-jitter-upper-bound",
+			expectedDuration: 40 * time.Second,
+			attempts:         2,
+			multiplier:       2,
+			jitter:           datastore.EqualJitter,
+			randFn:           maxRand,
+		},
+		{
+			name:             "decorrelated-jitter-lower-bound",
+			expectedDuration: 10 * time.Second,
+			attempts:         2,
+			multiplier:       2,
+			jitter:           datastore.DecorrelatedJitter,
+			randFn:           minRand,
 		},
 		{
-			name:             "duration-dependent-on-attempts-wraps",
-			expectedDuration: time.Duration(5000) * time.Millisecond,
-			attempts:         7,
-			jitterFn: func(u uint) int {
-				return int(u)
-			},
-			millis: []uint{0, 100, 200, 1000, 2000, 5000},
+			name:             "decorrelated-jitter-upper-bound",
+			expectedDuration: 60 * time.Second,
+			attempts:         2,
+			multiplier:       2,
+			jitter:           datastore.DecorrelatedJitter,
+			randFn:           maxRand,
 		},
 		{
-			name:             "jitterfn-affects-duration",
-			expectedDuration: time.Duration(500) * time.Millisecond,
-			attempts:         1,
-			jitterFn: func(u uint) int {
-				return 500
-			},
-			millis: []uint{0, 100, 200, 1000},
+			name:             "decorrelated-jitter-capped-at-max-interval",
+			expectedDuration: 10 * time.Minute,
+			attempts:         10,
+			multiplier:       2,
+			jitter:           datastore.DecorrelatedJitter,
+			randFn:           maxRand,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			retry := NewExponentialWithJitter(tc.millis, tc.jitterFn)
+			retry := NewExponentialWithRand(10*time.Second, tc.multiplier, 10*time.Minute, tc.jitter, tc.randFn)
 
 			got := retry.NextDuration(tc.attempts)
 
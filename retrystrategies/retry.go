@@ -1,11 +1,21 @@
 package retrystrategies
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy/datastore"
 )
 
+const (
+	defaultBaseInterval = 10 * time.Second
+	defaultMultiplier   = 2
+	defaultMaxInterval  = 15 * time.Minute
+	defaultJitter       = datastore.FullJitter
+)
+
 type RetryStrategy interface {
 	// NextDuration is how long we should wait before next retry
 	NextDuration(attempts uint64) time.Duration
@@ -13,17 +23,94 @@ type RetryStrategy interface {
 
 func NewRetryStrategyFromMetadata(m datastore.Metadata) RetryStrategy {
 	if string(m.Strategy) == string(datastore.ExponentialStrategyProvider) {
-		// 10 seconds to 15 mins
-		return NewExponential([]uint{
-			10000,  // 10 seconds
-			30000,  // 30 seconds
-			60000,  // 1 minute
-			180000, // 3 minutes
-			300000, // 5 minutes
-			600000, // 10 minutes
-			900000, // 15 minutes
-		})
+		base := time.Duration(m.IntervalSeconds) * time.Second
+		if base == 0 {
+			base = defaultBaseInterval
+		}
+
+		multiplier := m.Multiplier
+		if multiplier <= 1 {
+			multiplier = defaultMultiplier
+		}
+
+		jitter := m.Jitter
+		if jitter == "" {
+			jitter = defaultJitter
+		}
+
+		return NewExponential(base, multiplier, MaxInterval(m), jitter)
 	}
 
 	return NewDefault(m.IntervalSeconds)
 }
+
+// MaxInterval is the longest delay between the retries of m, it is never
+// shorter than the base interval.
+func MaxInterval(m datastore.Metadata) time.Duration {
+	base := time.Duration(m.IntervalSeconds) * time.Second
+	if base == 0 {
+		base = defaultBaseInterval
+	}
+
+	max := time.Duration(m.MaxIntervalSeconds) * time.Second
+	if max == 0 {
+		max = defaultMaxInterval
+	}
+
+	if max < base {
+		max = base
+	}
+
+	return max
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, which is
+// either a number of seconds or an HTTP date. The delay is capped to max.
+func ParseRetryAfter(value string, now time.Time, max time.Duration) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		// capped before it is converted, a large value overflows the duration.
+		if seconds > int64(max/time.Second) {
+			return max, true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if !t.After(now) {
+		return 0, true
+	}
+
+	if d := t.Sub(now); d < max {
+		return d, true
+	}
+
+	return max, true
+}
+
+// WithRetryAfter returns the larger of the strategy's delay and the
+// delay the endpoint asked for in its Retry-After header, capped to max.
+func WithRetryAfter(delay time.Duration, header http.Header, now time.Time, max time.Duration) time.Duration {
+	if header == nil {
+		return delay
+	}
+
+	retryAfter, ok := ParseRetryAfter(header.Get("Retry-After"), now, max)
+	if !ok || retryAfter <= delay {
+		return delay
+	}
+
+	return retryAfter
+}
@@ -1,7 +1,9 @@
 package retrystrategies
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/stretchr/testify/assert"
@@ -40,3 +42,80 @@ func TestRetry_FallsBackToDefault(t *testing.T) {
 	_, isDefault := r.(*DefaultRetryStrategy)
 	assert.True(t, isDefault)
 }
+
+func TestRetry_CreatesExponentialFromMetadata(t *testing.T) {
+	m := datastore.Metadata{
+		Strategy:           "exponential",
+		RetryLimit:         20,
+		IntervalSeconds:    5,
+		Multiplier:         3,
+		MaxIntervalSeconds: 60,
+		Jitter:             datastore.EqualJitter,
+	}
+
+	r, isExponential := NewRetryStrategyFromMetadata(m).(*ExponentialBackoffRetryStrategy)
+	assert.True(t, isExponential)
+	assert.Equal(t, 5*time.Second, r.base)
+	assert.Equal(t, float64(3), r.multiplier)
+	assert.Equal(t, time.Minute, r.max)
+	assert.Equal(t, datastore.EqualJitter, r.jitter)
+}
+
+func TestRetry_ExponentialDefaults(t *testing.T) {
+	m := datastore.Metadata{
+		Strategy:   "exponential",
+		RetryLimit: 20,
+	}
+
+	r, isExponential := NewRetryStrategyFromMetadata(m).(*ExponentialBackoffRetryStrategy)
+	assert.True(t, isExponential)
+	assert.Equal(t, defaultBaseInterval, r.base)
+	assert.Equal(t, float64(defaultMultiplier), r.multiplier)
+	assert.Equal(t, defaultMaxInterval, r.max)
+	assert.Equal(t, datastore.FullJitter, r.jitter)
+}
+
+func TestMaxInterval(t *testing.T) {
+	assert.Equal(t, defaultMaxInterval, MaxInterval(datastore.Metadata{}))
+	assert.Equal(t, time.Minute, MaxInterval(datastore.Metadata{MaxIntervalSeconds: 60}))
+	assert.Equal(t, time.Hour, MaxInterval(datastore.Metadata{IntervalSeconds: 3600, MaxIntervalSeconds: 60}))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{name: "seconds", value: "120", want: 2 * time.Minute, wantOk: true},
+		{name: "http_date", value: now.Add(time.Hour).Format(http.TimeFormat), want: time.Hour, wantOk: true},
+		{name: "http_date_in_the_past", value: now.Add(-time.Hour).Format(http.TimeFormat), want: 0, wantOk: true},
+		{name: "empty", value: "", wantOk: false},
+		{name: "negative", value: "-5", wantOk: false},
+		{name: "invalid", value: "soon", wantOk: false},
+		{name: "seconds_above_max", value: "7200", want: time.Hour, wantOk: true},
+		{name: "huge_seconds", value: "9223372036854775807", want: time.Hour, wantOk: true},
+		{name: "http_date_above_max", value: now.Add(90 * 24 * time.Hour).Format(http.TimeFormat), want: time.Hour, wantOk: true},
+		{name: "http_date_far_in_the_past", value: "Mon, 01 Jan 1900 00:00:00 GMT", want: 0, wantOk: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ParseRetryAfter(tc.value, now, time.Hour)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, 10*time.Second, WithRetryAfter(10*time.Second, nil, now, time.Hour))
+	assert.Equal(t, 10*time.Second, WithRetryAfter(10*time.Second, http.Header{"Retry-After": []string{"5"}}, now, time.Hour))
+	assert.Equal(t, time.Minute, WithRetryAfter(10*time.Second, http.Header{"Retry-After": []string{"60"}}, now, time.Hour))
+	assert.Equal(t, time.Hour, WithRetryAfter(10*time.Second, http.Header{"Retry-After": []string{"99999999999999"}}, now, time.Hour))
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/frain-dev/convoy/datastore"
@@ -32,9 +33,11 @@ type pagedResponse struct {
 // @Security ApiKeyAuth
 // @Router /applications/{appID} [get]
 func (a *ApplicationHandler) GetApp(w http.ResponseWriter, r *http.Request) {
+	app := m.GetApplicationFromContext(r.Context())
+	a.loadCircuitBreakers(r.Context(), app.Endpoints)
 
 	_ = render.Render(w, r, util.NewServerResponse("App fetched successfully",
-		*m.GetApplicationFromContext(r.Context()), http.StatusOK))
+		*app, http.StatusOK))
 }
 
 // GetApps
@@ -201,8 +204,11 @@ func (a *ApplicationHandler) CreateAppEndpoint(w http.ResponseWriter, r *http.Re
 // @Security ApiKeyAuth
 // @Router /applications/{appID}/endpoints/{endpointID} [get]
 func (a *ApplicationHandler) GetAppEndpoint(w http.ResponseWriter, r *http.Request) {
+	app := m.GetApplicationFromContext(r.Context())
+	a.loadCircuitBreakers(r.Context(), app.Endpoints)
+
 	_ = render.Render(w, r, util.NewServerResponse("App endpoint fetched successfully",
-		*m.GetApplicationFromContext(r.Context()), http.StatusOK))
+		*app, http.StatusOK))
 }
 
 // GetAppEndpoints
@@ -221,9 +227,24 @@ func (a *ApplicationHandler) GetAppEndpoints(w http.ResponseWriter, r *http.Requ
 	app := m.GetApplicationFromContext(r.Context())
 
 	app.Endpoints = m.FilterDeletedEndpoints(app.Endpoints)
+	a.loadCircuitBreakers(r.Context(), app.Endpoints)
+
 	_ = render.Render(w, r, util.NewServerResponse("App endpoints fetched successfully", app.Endpoints, http.StatusOK))
 }
 
+// loadCircuitBreakers attaches the circuit breaker state of each endpoint.
+func (a *ApplicationHandler) loadCircuitBreakers(ctx context.Context, endpoints []datastore.Endpoint) {
+	for i := range endpoints {
+		breaker, err := a.S.CircuitBreaker.Get(ctx, endpoints[i].UID)
+		if err != nil {
+			log.WithError(err).Errorf("failed to load circuit breaker for endpoint %s", endpoints[i].UID)
+			continue
+		}
+
+		endpoints[i].CircuitBreaker = breaker
+	}
+}
+
 // UpdateAppEndpoint
 // @Summary Update an application endpoint
 // @Description This endpoint updates an application endpoint
@@ -258,6 +279,40 @@ func (a *ApplicationHandler) UpdateAppEndpoint(w http.ResponseWriter, r *http.Re
 	_ = render.Render(w, r, util.NewServerResponse("Apps endpoint updated successfully", endpoint, http.StatusAccepted))
 }
 
+// RotateAppEndpointSecret
+// @Summary Rotate an application endpoint's secret
+// @Description This endpoint replaces an application endpoint's secret, deliveries are signed with the previous secret as well until it expires
+// @Tags Application Endpoints
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param appID path string true "application id"
+// @Param endpointID path string true "endpoint id"
+// @Param secret body models.RotateSecret true "Secret Details"
+// @Success 200 {object} util.ServerResponse{data=datastore.Endpoint}
+// @Failure 400,401,500 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /applications/{appID}/endpoints/{endpointID}/rotate_secret [put]
+func (a *ApplicationHandler) RotateAppEndpointSecret(w http.ResponseWriter, r *http.Request) {
+	var s models.RotateSecret
+	err := util.ReadJSON(r, &s)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	app := m.GetApplicationFromContext(r.Context())
+	endPointId := chi.URLParam(r, "endpointID")
+
+	endpoint, err := a.S.AppService.RotateAppEndpointSecret(r.Context(), &s, endPointId, app)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("App endpoint secret rotated successfully", endpoint, http.StatusAccepted))
+}
+
 // DeleteAppEndpoint
 // @Summary Delete application endpoint
 // @Description This endpoint deletes an application endpoint
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/frain-dev/convoy/datastore"
+	m "github.com/frain-dev/convoy/internal/pkg/middleware"
+	"github.com/frain-dev/convoy/server/models"
+	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// GetDeadLettersPaged
+// @Summary Get dead letters
+// @Description This endpoint fetches event deliveries that exhausted their retries
+// @Tags DeadLetters
+// @Accept json
+// @Produce json
+// @Param groupId query string true "group id"
+// @Param appId query string false "application id"
+// @Param eventId query string false "event id"
+// @Param subscriptionId query string false "subscription id"
+// @Param startDate query string false "start date"
+// @Param endDate query string false "end date"
+// @Param perPage query string false "results per page"
+// @Param page query string false "page number"
+// @Success 200 {object} util.ServerResponse{data=pagedResponse{content=[]datastore.DeadLetter}}
+// @Failure 400,401,500 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /deadletters [get]
+func (a *ApplicationHandler) GetDeadLettersPaged(w http.ResponseWriter, r *http.Request) {
+	searchParams, err := getSearchParams(r)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	f := &datastore.DeadLetterFilter{
+		GroupID:        m.GetGroupFromContext(r.Context()).UID,
+		AppID:          r.URL.Query().Get("appId"),
+		EventID:        r.URL.Query().Get("eventId"),
+		SubscriptionID: r.URL.Query().Get("subscriptionId"),
+		SearchParams:   searchParams,
+	}
+
+	deadLetters, paginationData, err := a.S.DeadLetterService.LoadDeadLettersPaged(r.Context(), f, m.GetPageableFromContext(r.Context()))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Dead letters fetched successfully",
+		pagedResponse{Content: &deadLetters, Pagination: &paginationData}, http.StatusOK))
+}
+
+// GetDeadLetter
+// @Summary Get a dead letter
+// @Description This endpoint fetches a dead letter by its id
+// @Tags DeadLetters
+// @Accept json
+// @Produce json
+// @Param groupId query string true "group id"
+// @Param deadLetterID path string true "dead letter id"
+// @Success 200 {object} util.ServerResponse{data=datastore.DeadLetter}
+// @Failure 400,401,404,500 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /deadletters/{deadLetterID} [get]
+func (a *ApplicationHandler) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	group := m.GetGroupFromContext(r.Context())
+
+	deadLetter, err := a.S.DeadLetterService.GetDeadLetter(r.Context(), group.UID, chi.URLParam(r, "deadLetterID"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Dead letter fetched successfully", deadLetter, http.StatusOK))
+}
+
+// ReplayDeadLetters
+// @Summary Replay dead letters
+// @Description This endpoint requeues the event deliveries of multiple dead letters
+// @Tags DeadLetters
+// @Accept json
+// @Produce json
+// @Param groupId query string true "group id"
+// @Param dead letter ids body Stub{ids=[]string} true "dead letter ids"
+// @Success 200 {object} util.ServerResponse{data=Stub}
+// @Failure 400,401,500 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /deadletters/replay [post]
+func (a *ApplicationHandler) ReplayDeadLetters(w http.ResponseWriter, r *http.Request) {
+	deadLetterIDs := models.IDs{}
+	err := util.ReadJSON(r, &deadLetterIDs)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("Request is invalid", http.StatusBadRequest))
+		return
+	}
+
+	successes, failures, err := a.S.DeadLetterService.ReplayDeadLetters(r.Context(), deadLetterIDs.IDs, m.GetGroupFromContext(r.Context()))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse(fmt.Sprintf("%d successful, %d failed", successes, failures), nil, http.StatusOK))
+}
+
+// PurgeDeadLetters
+// @Summary Purge dead letters
+// @Description This endpoint permanently deletes multiple dead letters
+// @Tags DeadLetters
+// @Accept json
+// @Produce json
+// @Param groupId query string true "group id"
+// @Param dead letter ids body Stub{ids=[]string} true "dead letter ids"
+// @Success 200 {object} util.ServerResponse{data=Stub}
+// @Failure 400,401,500 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /deadletters/purge [post]
+func (a *ApplicationHandler) PurgeDeadLetters(w http.ResponseWriter, r *http.Request) {
+	deadLetterIDs := models.IDs{}
+	err := util.ReadJSON(r, &deadLetterIDs)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse("Request is invalid", http.StatusBadRequest))
+		return
+	}
+
+	purged, err := a.S.DeadLetterService.PurgeDeadLetters(r.Context(), deadLetterIDs.IDs, m.GetGroupFromContext(r.Context()))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse(fmt.Sprintf("%d dead letters purged", purged), nil, http.StatusOK))
+}
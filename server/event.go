@@ -17,6 +17,11 @@ import (
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
 )
 
+// idempotencyKeyHeader is set by clients to safely retry creating an
+// event, a duplicate request within the idempotency window returns the
+// original event instead of creating a new one.
+const idempotencyKeyHeader = "Idempotency-Key"
+
 // CreateAppEvent
 // @Summary Create app event
 // @Description This endpoint creates an app event
@@ -25,6 +30,7 @@ import (
 // @Produce  json
 // @Param groupId query string true "group id"
 // @Param event body models.Event true "Event Details"
+// @Param Idempotency-Key header string false "idempotency key"
 // @Success 200 {object} util.ServerResponse{data=datastore.Event{data=Stub}}
 // @Failure 400,401,500 {object} util.ServerResponse{data=Stub}
 // @Security ApiKeyAuth
@@ -38,6 +44,7 @@ func (a *ApplicationHandler) CreateAppEvent(w http.ResponseWriter, r *http.Reque
 	}
 
 	g := m.GetGroupFromContext(r.Context())
+	newMessage.IdempotencyKey = r.Header.Get(idempotencyKeyHeader)
 
 	event, err := a.S.EventService.CreateAppEvent(r.Context(), &newMessage, g)
 	if err != nil {
@@ -48,6 +55,46 @@ func (a *ApplicationHandler) CreateAppEvent(w http.ResponseWriter, r *http.Reque
 	_ = render.Render(w, r, util.NewServerResponse("App event created successfully", event, http.StatusCreated))
 }
 
+// BatchCreateAppEvents
+// @Summary Create a batch of app events
+// @Description This endpoint creates a batch of app events, each event is created independently and its result is returned at its index
+// @Tags Events
+// @Accept  json
+// @Produce  json
+// @Param groupId query string true "group id"
+// @Param events body models.BatchEvent true "Batch Event Details"
+// @Success 201 {object} util.ServerResponse{data=[]models.BatchEventResult}
+// @Success 207 {object} util.ServerResponse{data=[]models.BatchEventResult}
+// @Failure 400,401,500 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /events/batch [post]
+func (a *ApplicationHandler) BatchCreateAppEvents(w http.ResponseWriter, r *http.Request) {
+	var batch models.BatchEvent
+	err := util.ReadJSON(r, &batch)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	g := m.GetGroupFromContext(r.Context())
+
+	results, err := a.S.EventService.CreateAppEvents(r.Context(), &batch, g)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	// a batch with failed events is a partial success
+	for _, result := range results {
+		if result.Error != "" {
+			_ = render.Render(w, r, util.NewServerResponse("App events created with errors", results, http.StatusMultiStatus))
+			return
+		}
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("App events created successfully", results, http.StatusCreated))
+}
+
 // ReplayAppEvent
 // @Summary Replay app event
 // @Description This endpoint replays an app event
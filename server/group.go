@@ -6,6 +6,7 @@ import (
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 
 	m "github.com/frain-dev/convoy/internal/pkg/middleware"
@@ -151,3 +152,22 @@ func (a *ApplicationHandler) GetGroups(w http.ResponseWriter, r *http.Request) {
 
 	_ = render.Render(w, r, util.NewServerResponse("Groups fetched successfully", groups, http.StatusOK))
 }
+
+// GetGroupJWKS
+// @Summary Get a group's public signing keys
+// @Description This endpoint fetches the JSON Web Key Set endpoints use to verify a group's asymmetric webhook signatures
+// @Tags Group
+// @Produce  json
+// @Param groupID path string true "group id"
+// @Success 200 {object} signing.JSONWebKeySet
+// @Failure 400,404 {object} util.ServerResponse{data=Stub}
+// @Router /jwks/{groupID} [get]
+func (a *ApplicationHandler) GetGroupJWKS(w http.ResponseWriter, r *http.Request) {
+	jwks, err := a.S.GroupService.GetJSONWebKeySet(r.Context(), chi.URLParam(r, "groupID"))
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	render.JSON(w, r, jwks)
+}
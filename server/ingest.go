@@ -45,26 +45,33 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 	verifierConfig := source.Verifier
 
 	if !util.IsStringEmpty(string(source.Provider)) {
-		switch source.Provider {
-		case datastore.GithubSourceProvider:
-			v = verifier.NewGithubVerifier(verifierConfig.HMac.Secret)
-		case datastore.TwitterSourceProvider:
-			v = verifier.NewTwitterVerifier(verifierConfig.HMac.Secret)
-		case datastore.ShopifySourceProvider:
-			v = verifier.NewShopifyVerifier(verifierConfig.HMac.Secret)
-		default:
-			_ = render.Render(w, r, util.NewErrorResponse("Provider type undefined",
-				http.StatusBadRequest))
+		var secret string
+		if verifierConfig.HMac != nil {
+			secret = verifierConfig.HMac.Secret
+		}
+
+		// an empty secret would let anyone sign requests for the provider.
+		if util.IsStringEmpty(secret) {
+			_ = render.Render(w, r, util.NewErrorResponse("Provider secret is not configured", http.StatusBadRequest))
+			return
+		}
+
+		v, err = verifier.NewProviderVerifier(string(source.Provider), secret)
+		if err != nil {
+			_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
 			return
 		}
 	} else {
 		switch verifierConfig.Type {
 		case datastore.HMacVerifier:
 			opts := &verifier.HmacOptions{
-				Header:   verifierConfig.HMac.Header,
-				Hash:     verifierConfig.HMac.Hash,
-				Secret:   verifierConfig.HMac.Secret,
-				Encoding: string(verifierConfig.HMac.Encoding),
+				Header:          verifierConfig.HMac.Header,
+				Hash:            verifierConfig.HMac.Hash,
+				Secret:          verifierConfig.HMac.Secret,
+				Encoding:        string(verifierConfig.HMac.Encoding),
+				TimestampHeader: verifierConfig.HMac.TimestampHeader,
+				Tolerance:       verifierConfig.HMac.ToleranceDuration(),
+				Nonces:          a.S.Cache,
 			}
 			v = verifier.NewHmacVerifier(opts)
 
@@ -98,6 +105,28 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 	}
 
 	// 3.2 On success
+	// Skip events the source has already received within its idempotency window.
+	providerID := source.IdempotencyConfig.ProviderIDFrom(r.Header, payload)
+
+	idempotencyKey := r.Header.Get(idempotencyKeyHeader)
+	if util.IsStringEmpty(idempotencyKey) {
+		idempotencyKey = providerID
+	}
+
+	window := source.IdempotencyConfig.WindowDuration()
+	if !util.IsStringEmpty(idempotencyKey) {
+		original, err := a.S.EventService.ReserveIdempotencyKey(r.Context(), source.GroupID, source.UID, idempotencyKey)
+		if err != nil {
+			_ = render.Render(w, r, util.NewServiceErrResponse(err))
+			return
+		}
+
+		if original != nil {
+			_ = render.Render(w, r, util.NewServerResponse("Event received", nil, http.StatusOK))
+			return
+		}
+	}
+
 	// Attach Source to Event.
 	// Write Event to the Ingestion Queue.
 	event := &datastore.Event{
@@ -105,6 +134,8 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 		EventType:      datastore.EventType(maskID),
 		SourceID:       source.UID,
 		GroupID:        source.GroupID,
+		ProviderID:     providerID,
+		IdempotencyKey: idempotencyKey,
 		Data:           payload,
 		Headers:        httpheader.HTTPHeader(r.Header),
 		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
@@ -114,6 +145,7 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 
 	eventByte, err := json.Marshal(event)
 	if err != nil {
+		a.releaseIdempotencyKey(r, event)
 		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
 		return
 	}
@@ -127,12 +159,31 @@ func (a *ApplicationHandler) IngestEvent(w http.ResponseWriter, r *http.Request)
 	err = a.S.Queue.Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
 	if err != nil {
 		log.Errorf("Error occurred sending new event to the queue %s", err)
+		a.releaseIdempotencyKey(r, event)
+	} else if !util.IsStringEmpty(idempotencyKey) {
+		err = a.S.EventService.SaveIdempotentEvent(r.Context(), event, window)
+		if err != nil {
+			log.WithError(err).Error("failed to save event idempotency key")
+		}
 	}
 
 	// 4. Return 200
 	_ = render.Render(w, r, util.NewServerResponse("Event received", nil, http.StatusOK))
 }
 
+// releaseIdempotencyKey frees the idempotency key reserved for an event that
+// couldn't be queued, so that the provider's retry isn't skipped.
+func (a *ApplicationHandler) releaseIdempotencyKey(r *http.Request, event *datastore.Event) {
+	if util.IsStringEmpty(event.IdempotencyKey) {
+		return
+	}
+
+	err := a.S.EventService.ReleaseIdempotencyKey(r.Context(), event.GroupID, event.SourceID, event.IdempotencyKey)
+	if err != nil {
+		log.WithError(err).Error("failed to release event idempotency key")
+	}
+}
+
 func (a *ApplicationHandler) HandleCrcCheck(w http.ResponseWriter, r *http.Request) {
 	maskID := chi.URLParam(r, "maskID")
 
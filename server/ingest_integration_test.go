@@ -4,6 +4,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -323,6 +324,37 @@ func (i *IngestIntegrationTestSuite) Test_IngestEvent_NoopVerifier() {
 	require.Equal(i.T(), expectedStatusCode, w.Code)
 }
 
+func (i *IngestIntegrationTestSuite) Test_IngestEvent_ProviderWithoutSecret() {
+	maskID := "123456"
+	sourceID := "123456789"
+	expectedStatusCode := http.StatusBadRequest
+
+	// Just Before
+	v := &datastore.VerifierConfig{
+		Type: datastore.NoopVerifier,
+	}
+	source, _ := testdb.SeedSource(i.DB, i.DefaultGroup, sourceID, maskID, "", v)
+
+	source.Provider = datastore.StripeSourceProvider
+	require.NoError(i.T(), i.DB.SourceRepo().UpdateSource(context.Background(), i.DefaultGroup.UID, source))
+
+	bodyStr := `{ "name": "convoy" }`
+	body := serialize(bodyStr)
+
+	// Arrange Request.
+	url := fmt.Sprintf("/ingest/%s", maskID)
+	req := createRequest(http.MethodPost, url, "", body)
+	req.Header.Add("Stripe-Signature", "t=1,v1=abc")
+
+	w := httptest.NewRecorder()
+
+	// Act.
+	i.Router.ServeHTTP(w, req)
+
+	// Assert.
+	require.Equal(i.T(), expectedStatusCode, w.Code)
+}
+
 func TestIngestIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(IngestIntegrationTestSuite))
 }
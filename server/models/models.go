@@ -102,16 +102,20 @@ type PortalAPIKeyResponse struct {
 }
 
 type SourceResponse struct {
-	UID            string                    `json:"uid"`
-	MaskID         string                    `json:"mask_id"`
-	GroupID        string                    `json:"group_id"`
-	Name           string                    `json:"name"`
-	Type           datastore.SourceType      `json:"type"`
-	URL            string                    `json:"url"`
-	IsDisabled     bool                      `json:"is_disabled"`
-	Verifier       *datastore.VerifierConfig `json:"verifier"`
-	Provider       datastore.SourceProvider  `json:"provider"`
-	ProviderConfig *datastore.ProviderConfig `json:"provider_config"`
+	UID            string                        `json:"uid"`
+	MaskID         string                        `json:"mask_id"`
+	GroupID        string                        `json:"group_id"`
+	Name           string                        `json:"name"`
+	Type           datastore.SourceType          `json:"type"`
+	URL            string                        `json:"url"`
+	IsDisabled     bool                          `json:"is_disabled"`
+	Verifier       *datastore.VerifierConfig     `json:"verifier"`
+	Provider       datastore.SourceProvider      `json:"provider"`
+	ProviderConfig *datastore.ProviderConfig     `json:"provider_config"`
+	PubSub         *datastore.PubSubConfig       `json:"pub_sub,omitempty"`
+	ChangeStream   *datastore.ChangeStreamConfig `json:"change_stream,omitempty"`
+	RestApi        *datastore.RestApiConfig      `json:"rest_api,omitempty"`
+	RestApiCursor  *datastore.RestApiCursor      `json:"rest_api_cursor,omitempty"`
 
 	CreatedAt primitive.DateTime `json:"created_at,omitempty"`
 	UpdatedAt primitive.DateTime `json:"updated_at,omitempty"`
@@ -166,6 +170,9 @@ type Application struct {
 	SupportEmail    string `json:"support_email" bson:"support_email" valid:"email~please provide a valid email"`
 	IsDisabled      bool   `json:"is_disabled"`
 	SlackWebhookURL string `json:"slack_webhook_url" bson:"slack_webhook_url"`
+
+	OwnerID string   `json:"owner_id,omitempty" bson:"owner_id"`
+	Tags    []string `json:"tags,omitempty" bson:"tags"`
 }
 
 type UpdateApplication struct {
@@ -173,6 +180,9 @@ type UpdateApplication struct {
 	SupportEmail    *string `json:"support_email" bson:"support_email" valid:"email~please provide a valid email"`
 	IsDisabled      *bool   `json:"is_disabled"`
 	SlackWebhookURL *string `json:"slack_webhook_url" bson:"slack_webhook_url"`
+
+	OwnerID *string  `json:"owner_id,omitempty" bson:"owner_id"`
+	Tags    []string `json:"tags,omitempty" bson:"tags"`
 }
 
 type Source struct {
@@ -181,6 +191,11 @@ type Source struct {
 	Provider   datastore.SourceProvider `json:"provider"`
 	IsDisabled bool                     `json:"is_disabled"`
 	Verifier   datastore.VerifierConfig `json:"verifier" valid:"required~please provide a verifier"`
+
+	IdempotencyConfig *datastore.IdempotencyConfiguration `json:"idempotency_config,omitempty"`
+	PubSub            *datastore.PubSubConfig             `json:"pub_sub,omitempty"`
+	ChangeStream      *datastore.ChangeStreamConfig       `json:"change_stream,omitempty"`
+	RestApi           *datastore.RestApiConfig            `json:"rest_api,omitempty"`
 }
 
 type UpdateSource struct {
@@ -189,15 +204,44 @@ type UpdateSource struct {
 	IsDisabled     *bool                    `json:"is_disabled"`
 	ForwardHeaders []string                 `json:"forward_headers"`
 	Verifier       datastore.VerifierConfig `json:"verifier" valid:"required~please provide a verifier"`
+
+	IdempotencyConfig *datastore.IdempotencyConfiguration `json:"idempotency_config,omitempty"`
+	PubSub            *datastore.PubSubConfig             `json:"pub_sub,omitempty"`
+	ChangeStream      *datastore.ChangeStreamConfig       `json:"change_stream,omitempty"`
+	RestApi           *datastore.RestApiConfig            `json:"rest_api,omitempty"`
 }
 
 type Event struct {
-	AppID     string `json:"app_id" bson:"app_id" valid:"required~please provide an app id"`
+	AppID     string `json:"app_id" bson:"app_id"`
 	EventType string `json:"event_type" bson:"event_type" valid:"required~please provide an event type"`
 
+	// OwnerID and AppTags can be used in place of AppID to send the event
+	// to every app with the owner id or with any of the tags
+	OwnerID string   `json:"owner_id,omitempty" bson:"owner_id"`
+	AppTags []string `json:"app_tags,omitempty" bson:"app_tags"`
+
 	// Data is an arbitrary JSON value that gets sent as the body of the
 	// webhook to the endpoints
 	Data json.RawMessage `json:"data" bson:"data" valid:"required~please provide your data"`
+
+	// ProviderID is an optional id from your system, events created with
+	// the same provider id within the idempotency window are deduplicated
+	ProviderID string `json:"provider_id,omitempty" bson:"provider_id"`
+
+	// IdempotencyKey is read from the Idempotency-Key header
+	IdempotencyKey string `json:"-" bson:"-"`
+}
+
+type BatchEvent struct {
+	Events []Event `json:"events"`
+}
+
+type BatchEventResult struct {
+	// Index is the position of the event in the batch
+	Index  int              `json:"index"`
+	Status int              `json:"status"`
+	Event  *datastore.Event `json:"event,omitempty"`
+	Error  string           `json:"error,omitempty"`
 }
 
 type IDs struct {
@@ -229,6 +273,16 @@ type Endpoint struct {
 	HttpTimeout       string `json:"http_timeout" bson:"http_timeout"`
 	RateLimit         int    `json:"rate_limit" bson:"rate_limit"`
 	RateLimitDuration string `json:"rate_limit_duration" bson:"rate_limit_duration"`
+
+	TLS            *datastore.EndpointTLSConfig      `json:"tls,omitempty"`
+	Authentication *datastore.EndpointAuthentication `json:"authentication,omitempty"`
+}
+
+// RotateSecret replaces an endpoint's secret, the previous secret stays
+// valid for Expiration so consumers can switch without missing deliveries.
+type RotateSecret struct {
+	Secret     string `json:"secret"`
+	Expiration string `json:"expiration"`
 }
 
 type DashboardSummary struct {
@@ -249,9 +303,13 @@ type Subscription struct {
 	SourceID   string `json:"source_id" bson:"source_id"`
 	EndpointID string `json:"endpoint_id" bson:"endpoint_id" valid:"required~please provide a valid endpoint id"`
 
-	AlertConfig  *datastore.AlertConfiguration  `json:"alert_config,omitempty" bson:"alert_config,omitempty"`
-	RetryConfig  *datastore.RetryConfiguration  `json:"retry_config,omitempty" bson:"retry_config,omitempty"`
-	FilterConfig *datastore.FilterConfiguration `json:"filter_config,omitempty" bson:"filter_config,omitempty"`
+	DeliveryMode datastore.DeliveryMode `json:"delivery_mode,omitempty" bson:"delivery_mode,omitempty" valid:"supported_delivery_mode~please provide a valid delivery mode"`
+	OrderingKey  string                 `json:"ordering_key,omitempty" bson:"ordering_key,omitempty"`
+
+	AlertConfig     *datastore.AlertConfiguration     `json:"alert_config,omitempty" bson:"alert_config,omitempty"`
+	RetryConfig     *datastore.RetryConfiguration     `json:"retry_config,omitempty" bson:"retry_config,omitempty"`
+	FilterConfig    *datastore.FilterConfiguration    `json:"filter_config,omitempty" bson:"filter_config,omitempty"`
+	TransformConfig *datastore.TransformConfiguration `json:"transform_config,omitempty" bson:"transform_config,omitempty"`
 }
 
 type UpdateSubscription struct {
@@ -260,9 +318,30 @@ type UpdateSubscription struct {
 	SourceID   string `json:"source_id,omitempty"`
 	EndpointID string `json:"endpoint_id,omitempty"`
 
-	AlertConfig  *datastore.AlertConfiguration  `json:"alert_config,omitempty"`
-	RetryConfig  *datastore.RetryConfiguration  `json:"retry_config,omitempty"`
-	FilterConfig *datastore.FilterConfiguration `json:"filter_config,omitempty"`
+	DeliveryMode datastore.DeliveryMode `json:"delivery_mode,omitempty" valid:"supported_delivery_mode~please provide a valid delivery mode"`
+	OrderingKey  string                 `json:"ordering_key,omitempty"`
+
+	AlertConfig     *datastore.AlertConfiguration     `json:"alert_config,omitempty"`
+	RetryConfig     *datastore.RetryConfiguration     `json:"retry_config,omitempty"`
+	FilterConfig    *datastore.FilterConfiguration    `json:"filter_config,omitempty"`
+	TransformConfig *datastore.TransformConfiguration `json:"transform_config,omitempty"`
+
+	// RemoveTransform detaches the subscription's transform, events are
+	// then delivered as they were received.
+	RemoveTransform bool `json:"remove_transform,omitempty"`
+}
+
+type TestTransform struct {
+	// Payload is a sample event payload to run the transformation against.
+	Payload json.RawMessage `json:"payload" valid:"required~please provide a sample payload"`
+
+	// TransformConfig is optional, when it is provided it is used in place
+	// of the subscription's saved configuration.
+	TransformConfig *datastore.TransformConfiguration `json:"transform_config,omitempty"`
+}
+
+type TestTransformResponse struct {
+	Payload json.RawMessage `json:"payload"`
 }
 
 type UpdateUser struct {
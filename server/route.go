@@ -9,6 +9,7 @@ import (
 
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/circuitbreaker"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/pkg/metrics"
 	"github.com/frain-dev/convoy/internal/pkg/middleware"
@@ -46,6 +47,7 @@ type Repos struct {
 	UserRepo          datastore.UserRepository
 	ConfigRepo        datastore.ConfigurationRepository
 	DeviceRepo        datastore.DeviceRepository
+	DeadLetterRepo    datastore.DeadLetterRepository
 }
 
 type Services struct {
@@ -56,6 +58,8 @@ type Services struct {
 	Limiter  limiter.RateLimiter
 	Searcher searcher.Searcher
 
+	CircuitBreaker circuitbreaker.CircuitBreaker
+
 	AppService                *services.AppService
 	EventService              *services.EventService
 	GroupService              *services.GroupService
@@ -68,6 +72,7 @@ type Services struct {
 	OrganisationMemberService *services.OrganisationMemberService
 	OrganisationInviteService *services.OrganisationInviteService
 	DeviceService             *services.DeviceService
+	DeadLetterService         *services.DeadLetterService
 }
 
 //go:embed ui/build
@@ -105,6 +110,7 @@ func NewApplicationHandler(r Repos, s Services) *ApplicationHandler {
 	cs := services.NewConfigService(r.ConfigRepo)
 	ds := services.NewDeviceService(r.DeviceRepo)
 	us := services.NewUserService(r.UserRepo, s.Cache, s.Queue, cs, os)
+	dls := services.NewDeadLetterService(r.DeadLetterRepo, r.EventDeliveryRepo, es)
 
 	m := middleware.NewMiddleware(&middleware.CreateMiddleware{
 		EventRepo:         r.EventRepo,
@@ -142,6 +148,7 @@ func NewApplicationHandler(r Repos, s Services) *ApplicationHandler {
 			UserRepo:          r.UserRepo,
 			ConfigRepo:        r.ConfigRepo,
 			DeviceRepo:        r.DeviceRepo,
+			DeadLetterRepo:    r.DeadLetterRepo,
 		},
 		S: Services{
 			Queue:                     s.Queue,
@@ -150,6 +157,7 @@ func NewApplicationHandler(r Repos, s Services) *ApplicationHandler {
 			Logger:                    s.Logger,
 			Tracer:                    s.Tracer,
 			Limiter:                   s.Limiter,
+			CircuitBreaker:            s.CircuitBreaker,
 			AppService:                as,
 			EventService:              es,
 			GroupService:              gs,
@@ -162,6 +170,7 @@ func NewApplicationHandler(r Repos, s Services) *ApplicationHandler {
 			OrganisationMemberService: om,
 			OrganisationInviteService: ois,
 			DeviceService:             ds,
+			DeadLetterService:         dls,
 		},
 	}
 }
@@ -181,6 +190,11 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 		ingestRouter.Post("/{maskID}", a.IngestEvent)
 	})
 
+	// Public keys of asymmetric webhook signatures.
+	router.Route("/jwks", func(jwksRouter chi.Router) {
+		jwksRouter.Get("/{groupID}", a.GetGroupJWKS)
+	})
+
 	// Public API.
 	router.Route("/api", func(v1Router chi.Router) {
 
@@ -215,6 +229,7 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 
 							e.Get("/", a.GetAppEndpoint)
 							e.Put("/", a.UpdateAppEndpoint)
+							e.Put("/rotate_secret", a.RotateAppEndpointSecret)
 							e.Delete("/", a.DeleteAppEndpoint)
 						})
 					})
@@ -227,6 +242,7 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 				eventRouter.Use(a.M.RequirePermission(auth.RoleAdmin))
 
 				eventRouter.With(a.M.InstrumentPath("/events")).Post("/", a.CreateAppEvent)
+				eventRouter.With(a.M.InstrumentPath("/events/batch")).Post("/batch", a.BatchCreateAppEvents)
 				eventRouter.With(a.M.Pagination).Get("/", a.GetEventsPaged)
 
 				eventRouter.Route("/{eventID}", func(eventSubRouter chi.Router) {
@@ -236,6 +252,16 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 				})
 			})
 
+			r.Route("/deadletters", func(deadLetterRouter chi.Router) {
+				deadLetterRouter.Use(a.M.RequireGroup())
+				deadLetterRouter.Use(a.M.RequirePermission(auth.RoleAdmin))
+
+				deadLetterRouter.With(a.M.Pagination).Get("/", a.GetDeadLettersPaged)
+				deadLetterRouter.Post("/replay", a.ReplayDeadLetters)
+				deadLetterRouter.Post("/purge", a.PurgeDeadLetters)
+				deadLetterRouter.Get("/{deadLetterID}", a.GetDeadLetter)
+			})
+
 			r.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
 				eventDeliveryRouter.Use(a.M.RequireGroup())
 				eventDeliveryRouter.Use(a.M.RequirePermission(auth.RoleAdmin))
@@ -281,6 +307,8 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 				subscriptionRouter.Get("/{subscriptionID}", a.GetSubscription)
 				subscriptionRouter.Put("/{subscriptionID}", a.UpdateSubscription)
 				subscriptionRouter.Put("/{subscriptionID}/toggle_status", a.ToggleSubscriptionStatus)
+				subscriptionRouter.Post("/{subscriptionID}/transform/test", a.TestSubscriptionTransform)
+				subscriptionRouter.Get("/{subscriptionID}/ordering", a.GetSubscriptionOrderingQueues)
 			})
 
 			r.Route("/sources", func(sourceRouter chi.Router) {
@@ -418,6 +446,7 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 
 										e.Get("/", a.GetAppEndpoint)
 										e.Put("/", a.UpdateAppEndpoint)
+										e.Put("/rotate_secret", a.RotateAppEndpointSecret)
 										e.Delete("/", a.DeleteAppEndpoint)
 									})
 								})
@@ -441,6 +470,15 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 							})
 						})
 
+						groupSubRouter.Route("/deadletters", func(deadLetterRouter chi.Router) {
+							deadLetterRouter.Use(a.M.RequireOrganisationMemberRole(auth.RoleSuperUser))
+
+							deadLetterRouter.With(a.M.Pagination).Get("/", a.GetDeadLettersPaged)
+							deadLetterRouter.Post("/replay", a.ReplayDeadLetters)
+							deadLetterRouter.Post("/purge", a.PurgeDeadLetters)
+							deadLetterRouter.Get("/{deadLetterID}", a.GetDeadLetter)
+						})
+
 						groupSubRouter.Route("/eventdeliveries", func(eventDeliveryRouter chi.Router) {
 							eventDeliveryRouter.Use(a.M.RequireOrganisationMemberRole(auth.RoleSuperUser))
 
@@ -472,6 +510,8 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 							subscriptionRouter.Delete("/{subscriptionID}", a.DeleteSubscription)
 							subscriptionRouter.Get("/{subscriptionID}", a.GetSubscription)
 							subscriptionRouter.Put("/{subscriptionID}", a.UpdateSubscription)
+							subscriptionRouter.Post("/{subscriptionID}/transform/test", a.TestSubscriptionTransform)
+							subscriptionRouter.Get("/{subscriptionID}/ordering", a.GetSubscriptionOrderingQueues)
 						})
 
 						groupSubRouter.Route("/sources", func(sourceRouter chi.Router) {
@@ -525,6 +565,7 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 
 					e.Get("/", a.GetAppEndpoint)
 					e.Put("/", a.UpdateAppEndpoint)
+					e.Put("/rotate_secret", a.RotateAppEndpointSecret)
 				})
 			})
 
@@ -585,6 +626,7 @@ func (a *ApplicationHandler) BuildRoutes() http.Handler {
 	router.HandleFunc("/*", reactRootHandler)
 
 	metrics.RegisterQueueMetrics(a.S.Queue)
+	metrics.RegisterDispatcherMetrics()
 	metrics.RegisterDBMetrics(a.R.EventDeliveryRepo)
 	prometheus.MustRegister(metrics.RequestDuration())
 
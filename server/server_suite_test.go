@@ -24,6 +24,7 @@ import (
 	"github.com/frain-dev/convoy/auth/realm_chain"
 	"github.com/frain-dev/convoy/cache"
 	ncache "github.com/frain-dev/convoy/cache/noop"
+	"github.com/frain-dev/convoy/circuitbreaker"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	convoyMongo "github.com/frain-dev/convoy/datastore/mongo"
@@ -134,6 +135,7 @@ func buildServer() *ApplicationHandler {
 			UserRepo:          userRepo,
 			ConfigRepo:        configRepo,
 			DeviceRepo:        deviceRepo,
+			DeadLetterRepo:    db.DeadLetterRepo(),
 		}, Services{
 			Queue:    queue,
 			Logger:   logger,
@@ -141,6 +143,8 @@ func buildServer() *ApplicationHandler {
 			Cache:    cache,
 			Limiter:  limiter,
 			Searcher: searcher,
+
+			CircuitBreaker: circuitbreaker.NewNoopCircuitBreaker(),
 		})
 }
 
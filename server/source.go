@@ -188,6 +188,10 @@ func sourceResponse(s *datastore.Source, baseUrl string) *models.SourceResponse
 		Type:           s.Type,
 		Provider:       s.Provider,
 		ProviderConfig: s.ProviderConfig,
+		PubSub:         s.PubSub,
+		ChangeStream:   s.ChangeStream,
+		RestApi:        s.RestApi,
+		RestApiCursor:  s.RestApiCursor,
 		URL:            fmt.Sprintf("%s/ingest/%s", baseUrl, s.MaskID),
 		IsDisabled:     s.IsDisabled,
 		Verifier:       s.Verifier,
@@ -189,3 +189,62 @@ func (a *ApplicationHandler) ToggleSubscriptionStatus(w http.ResponseWriter, r *
 
 	_ = render.Render(w, r, util.NewServerResponse("Subscription status updated successfully", sub, http.StatusAccepted))
 }
+
+// TestSubscriptionTransform
+// @Summary Preview a subscription's payload transformation
+// @Description This endpoint runs a subscription's payload transformation against a sample payload
+// @Tags Subscription
+// @Accept json
+// @Produce json
+// @Param groupId query string true "group id"
+// @Param subscriptionID path string true "subscription id"
+// @Param transform body models.TestTransform true "Sample Payload"
+// @Success 200 {object} util.ServerResponse{data=models.TestTransformResponse}
+// @Failure 400,401,500 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /subscriptions/{subscriptionID}/transform/test [post]
+func (a *ApplicationHandler) TestSubscriptionTransform(w http.ResponseWriter, r *http.Request) {
+	var test models.TestTransform
+	err := util.ReadJSON(r, &test)
+	if err != nil {
+		_ = render.Render(w, r, util.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		return
+	}
+
+	g := m.GetGroupFromContext(r.Context())
+	subscription := chi.URLParam(r, "subscriptionID")
+
+	payload, err := a.S.SubService.TestSubscriptionTransform(r.Context(), g.UID, subscription, &test)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Subscription transform tested successfully",
+		models.TestTransformResponse{Payload: payload}, http.StatusOK))
+}
+
+// GetSubscriptionOrderingQueues
+// @Summary Get a subscription's ordering queues
+// @Description This endpoint shows the head of line blocking of an ordered subscription's deliveries
+// @Tags Subscriptions
+// @Accept json
+// @Produce json
+// @Param groupId query string true "group id"
+// @Param subscriptionID path string true "subscription id"
+// @Success 200 {object} util.ServerResponse{data=[]datastore.OrderingQueue}
+// @Failure 400,401,500 {object} util.ServerResponse{data=Stub}
+// @Security ApiKeyAuth
+// @Router /subscriptions/{subscriptionID}/ordering [get]
+func (a *ApplicationHandler) GetSubscriptionOrderingQueues(w http.ResponseWriter, r *http.Request) {
+	g := m.GetGroupFromContext(r.Context())
+	subscription := chi.URLParam(r, "subscriptionID")
+
+	queues, err := a.S.EventService.LoadOrderingQueues(r.Context(), g, subscription)
+	if err != nil {
+		_ = render.Render(w, r, util.NewServiceErrResponse(err))
+		return
+	}
+
+	_ = render.Render(w, r, util.NewServerResponse("Ordering queues fetched successfully", queues, http.StatusOK))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	convoyMongo "github.com/frain-dev/convoy/datastore/mongo"
+	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/server/testdb"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -608,6 +609,49 @@ func (s *SubscriptionIntegrationTestSuite) Test_ToggleSubscriptionStatus_Unknown
 	require.Equal(s.T(), http.StatusBadRequest, w.Code)
 }
 
+func (s *SubscriptionIntegrationTestSuite) Test_TestSubscriptionTransform() {
+	subscriptionId := "123456789"
+
+	// Just Before
+	app, _ := testdb.SeedApplication(s.DB, s.DefaultGroup, uuid.NewString(), "", false)
+	endpoint, _ := testdb.SeedEndpoint(s.DB, app, s.DefaultGroup.UID)
+	source, _ := testdb.SeedSource(s.DB, s.DefaultGroup, uuid.NewString(), "", "", nil)
+	_, _ = testdb.SeedSubscription(s.DB, app, s.DefaultGroup, subscriptionId, datastore.OutgoingGroup, source, endpoint, &datastore.RetryConfiguration{}, &datastore.AlertConfiguration{}, &datastore.FilterConfiguration{}, datastore.ActiveSubscriptionStatus)
+
+	// Arrange Request
+	url := fmt.Sprintf("/api/v1/subscriptions/%s/transform/test", subscriptionId)
+	bodyStr := `{
+		"payload": {
+			"event": "invoice.paid",
+			"data": {
+				"amount": 2000
+			}
+		},
+		"transform_config": {
+			"type": "mapping",
+			"mapping": [
+				{ "from": "event", "to": "type" },
+				{ "from": "data.amount", "to": "total" }
+			]
+		}
+	}`
+
+	body := serialize(bodyStr)
+	req := createRequest(http.MethodPost, url, s.APIKey, body)
+	w := httptest.NewRecorder()
+
+	// Act
+	s.Router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(s.T(), http.StatusOK, w.Code)
+
+	// Deep Asset
+	var resp models.TestTransformResponse
+	parseResponse(s.T(), w.Result(), &resp)
+	require.JSONEq(s.T(), `{"type": "invoice.paid", "total": 2000}`, string(resp.Payload))
+}
+
 func TestSubscriptionIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, new(SubscriptionIntegrationTestSuite))
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/net"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
@@ -41,6 +42,8 @@ func (a *AppService) CreateApp(ctx context.Context, newApp *models.Application,
 		SupportEmail:    newApp.SupportEmail,
 		SlackWebhookURL: newApp.SlackWebhookURL,
 		IsDisabled:      newApp.IsDisabled,
+		OwnerID:         newApp.OwnerID,
+		Tags:            newApp.Tags,
 		CreatedAt:       primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt:       primitive.NewDateTimeFromTime(time.Now()),
 		Endpoints:       []datastore.Endpoint{},
@@ -99,6 +102,14 @@ func (a *AppService) UpdateApplication(ctx context.Context, appUpdate *models.Up
 		app.SupportEmail = *appUpdate.SupportEmail
 	}
 
+	if appUpdate.OwnerID != nil {
+		app.OwnerID = *appUpdate.OwnerID
+	}
+
+	if appUpdate.Tags != nil {
+		app.Tags = appUpdate.Tags
+	}
+
 	err := a.appRepo.UpdateApplication(ctx, app, app.GroupID)
 	if err != nil {
 		msg := "an error occurred while updating app"
@@ -156,6 +167,16 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 		return nil, util.NewServiceError(http.StatusBadRequest, fmt.Errorf("an error occurred parsing the rate limit duration: %v", err))
 	}
 
+	_, err = net.NewTLSConfig(e.TLS)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	err = validateEndpointAuthentication(e.Authentication)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
 	endpoint := &datastore.Endpoint{
 		UID:               uuid.New().String(),
 		TargetURL:         e.URL,
@@ -164,6 +185,8 @@ func (a *AppService) CreateAppEndpoint(ctx context.Context, e models.Endpoint, a
 		RateLimit:         e.RateLimit,
 		HttpTimeout:       e.HttpTimeout,
 		RateLimitDuration: duration.String(),
+		TLS:               e.TLS,
+		Authentication:    e.Authentication,
 		CreatedAt:         primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt:         primitive.NewDateTimeFromTime(time.Now()),
 		DocumentStatus:    datastore.ActiveDocumentStatus,
@@ -219,6 +242,72 @@ func (a *AppService) UpdateAppEndpoint(ctx context.Context, e models.Endpoint, e
 	return endpoint, nil
 }
 
+// RotateAppEndpointSecret sets a new secret on the endpoint, deliveries are
+// signed with the previous secret as well until it expires.
+func (a *AppService) RotateAppEndpointSecret(ctx context.Context, s *models.RotateSecret, endPointId string, app *datastore.Application) (*datastore.Endpoint, error) {
+	expiration := s.Expiration
+	if util.IsStringEmpty(expiration) {
+		expiration = convoy.SECRET_EXPIRATION
+	}
+
+	duration, err := time.ParseDuration(expiration)
+	if err != nil || duration < 0 {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("please provide a valid secret expiration"))
+	}
+
+	secret := s.Secret
+	if util.IsStringEmpty(secret) {
+		secret, err = util.GenerateSecret()
+		if err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, fmt.Errorf("could not generate secret...%v", err.Error()))
+		}
+	}
+
+	var endpoint *datastore.Endpoint
+	for i := range app.Endpoints {
+		if app.Endpoints[i].UID == endPointId && app.Endpoints[i].DeletedAt == 0 {
+			endpoint = &app.Endpoints[i]
+			break
+		}
+	}
+
+	if endpoint == nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, datastore.ErrEndpointNotFound)
+	}
+
+	if secret == endpoint.Secret {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("the new secret must differ from the current secret"))
+	}
+
+	now := time.Now()
+
+	// expired secrets are dropped as the endpoint no longer receives their signatures.
+	rotated := []datastore.RotatedSecret{{Secret: endpoint.Secret, ExpiresAt: primitive.NewDateTimeFromTime(now.Add(duration))}}
+	for _, rs := range endpoint.RotatedSecrets {
+		if rs.ExpiresAt.Time().After(now) && rs.Secret != secret {
+			rotated = append(rotated, rs)
+		}
+	}
+
+	endpoint.Secret = secret
+	endpoint.RotatedSecrets = rotated
+	endpoint.UpdatedAt = primitive.NewDateTimeFromTime(now)
+
+	err = a.appRepo.UpdateApplication(ctx, app, app.GroupID)
+	if err != nil {
+		log.WithError(err).Error("failed to rotate app endpoint secret")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("an error occurred while rotating endpoint secret"))
+	}
+
+	appCacheKey := convoy.ApplicationsCacheKey.Get(app.UID).String()
+	err = a.cache.Set(ctx, appCacheKey, &app, time.Minute*5)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to update application cache"))
+	}
+
+	return endpoint, nil
+}
+
 func (a *AppService) DeleteAppEndpoint(ctx context.Context, e *datastore.Endpoint, app *datastore.Application) error {
 
 	for i, endpoint := range app.Endpoints {
@@ -280,6 +369,26 @@ func updateEndpointIfFound(endpoints *[]datastore.Endpoint, id string, e models.
 				endpoint.Secret = e.Secret
 			}
 
+			if e.TLS != nil {
+				if e.TLS.ClientKey == datastore.MaskedSecret && endpoint.TLS != nil {
+					e.TLS.ClientKey = endpoint.TLS.ClientKey
+				}
+
+				if _, err := net.NewTLSConfig(e.TLS); err != nil {
+					return nil, nil, err
+				}
+				endpoint.TLS = e.TLS
+			}
+
+			if e.Authentication != nil {
+				keepMaskedCredentials(e.Authentication, endpoint.Authentication)
+
+				if err := validateEndpointAuthentication(e.Authentication); err != nil {
+					return nil, nil, err
+				}
+				endpoint.Authentication = e.Authentication
+			}
+
 			endpoint.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 			(*endpoints)[i] = endpoint
 			return endpoints, &endpoint, nil
@@ -287,3 +396,51 @@ func updateEndpointIfFound(endpoints *[]datastore.Endpoint, id string, e models.
 	}
 	return endpoints, nil, datastore.ErrEndpointNotFound
 }
+
+func validateEndpointAuthentication(auth *datastore.EndpointAuthentication) error {
+	if auth == nil {
+		return nil
+	}
+
+	err := util.Validate(auth)
+	if err != nil {
+		return err
+	}
+
+	switch auth.Type {
+	case datastore.BearerEndpointAuthentication:
+		if util.IsStringEmpty(auth.Token) {
+			return errors.New("please provide a bearer token")
+		}
+	case datastore.BasicEndpointAuthentication:
+		if auth.BasicAuth == nil {
+			return errors.New("please provide basic auth credentials")
+		}
+	case datastore.OAuth2EndpointAuthentication:
+		if auth.OAuth2 == nil {
+			return errors.New("please provide an oauth2 config")
+		}
+	}
+
+	return nil
+}
+
+// keepMaskedCredentials replaces masked credentials sent back in an update
+// with the stored ones.
+func keepMaskedCredentials(auth, stored *datastore.EndpointAuthentication) {
+	if stored == nil {
+		return
+	}
+
+	if auth.Token == datastore.MaskedSecret {
+		auth.Token = stored.Token
+	}
+
+	if auth.BasicAuth != nil && stored.BasicAuth != nil && auth.BasicAuth.Password == datastore.MaskedSecret {
+		auth.BasicAuth.Password = stored.BasicAuth.Password
+	}
+
+	if auth.OAuth2 != nil && stored.OAuth2 != nil && auth.OAuth2.ClientSecret == datastore.MaskedSecret {
+		auth.OAuth2.ClientSecret = stored.OAuth2.ClientSecret
+	}
+}
@@ -2,9 +2,17 @@ package services
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"math/big"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/mocks"
@@ -12,6 +20,7 @@ import (
 	"github.com/frain-dev/convoy/util"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func provideAppService(ctrl *gomock.Controller) *AppService {
@@ -707,6 +716,38 @@ func TestAppService_CreateAppEndpoint(t *testing.T) {
 			wantErrCode: http.StatusBadRequest,
 			wantErrMsg:  `an error occurred parsing the rate limit duration: time: invalid duration "m"`,
 		},
+		{
+			name: "should_error_for_invalid_tls_config",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					Secret:      "1234",
+					URL:         "https://google.com",
+					Description: "test_endpoint",
+					TLS:         &datastore.EndpointTLSConfig{MinVersion: "1.4"},
+				},
+				app: &datastore.Application{UID: "abc"},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "invalid tls min version",
+		},
+		{
+			name: "should_error_for_invalid_authentication",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					Secret:         "1234",
+					URL:            "https://google.com",
+					Description:    "test_endpoint",
+					Authentication: &datastore.EndpointAuthentication{Type: datastore.BearerEndpointAuthentication},
+				},
+				app: &datastore.Application{UID: "abc"},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "please provide a bearer token",
+		},
 		{
 			name: "should_fail_to_create_app_endpoint",
 			args: args{
@@ -884,6 +925,175 @@ func TestAppService_UpdateAppEndpoint(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should_keep_masked_tls_client_key",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					URL: "https://fb.com",
+					TLS: &datastore.EndpointTLSConfig{
+						ClientCert: testCertPEM,
+						ClientKey:  datastore.MaskedSecret,
+						MinVersion: "1.3",
+					},
+				},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID: "1234",
+					Endpoints: []datastore.Endpoint{
+						{
+							UID:       "endpoint1",
+							TargetURL: "https://google.com",
+							TLS:       &datastore.EndpointTLSConfig{ClientCert: testCertPEM, ClientKey: testKeyPEM},
+						},
+					},
+				},
+			},
+			wantApp: &datastore.Application{
+				UID: "1234",
+				Endpoints: []datastore.Endpoint{
+					{
+						UID:       "endpoint1",
+						TargetURL: "https://fb.com",
+						TLS:       &datastore.EndpointTLSConfig{ClientCert: testCertPEM, ClientKey: testKeyPEM, MinVersion: "1.3"},
+					},
+				},
+			},
+			wantEndpoint: &datastore.Endpoint{
+				UID:       "endpoint1",
+				TargetURL: "https://fb.com",
+				TLS:       &datastore.EndpointTLSConfig{ClientCert: testCertPEM, ClientKey: testKeyPEM, MinVersion: "1.3"},
+			},
+			dbFn: func(as *AppService) {
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(nil)
+
+				c, _ := as.cache.(*mocks.MockCache)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name: "should_error_for_invalid_tls_config",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					URL: "https://fb.com",
+					TLS: &datastore.EndpointTLSConfig{ClientCert: testCertPEM, ClientKey: datastore.MaskedSecret},
+				},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID: "1234",
+					Endpoints: []datastore.Endpoint{
+						{
+							UID:       "endpoint1",
+							TargetURL: "https://google.com",
+						},
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "invalid tls client certificate or key",
+		},
+		{
+			name: "should_keep_masked_oauth2_client_secret",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					URL: "https://fb.com",
+					Authentication: &datastore.EndpointAuthentication{
+						Type: datastore.OAuth2EndpointAuthentication,
+						OAuth2: &datastore.OAuth2Config{
+							TokenURL:     "https://auth.convoy.test/token",
+							ClientID:     "client",
+							ClientSecret: datastore.MaskedSecret,
+							Scopes:       []string{"webhooks"},
+						},
+					},
+				},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID: "1234",
+					Endpoints: []datastore.Endpoint{
+						{
+							UID:       "endpoint1",
+							TargetURL: "https://google.com",
+							Authentication: &datastore.EndpointAuthentication{
+								Type: datastore.OAuth2EndpointAuthentication,
+								OAuth2: &datastore.OAuth2Config{
+									TokenURL:     "https://auth.convoy.test/token",
+									ClientID:     "client",
+									ClientSecret: "secret",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantApp: &datastore.Application{
+				UID: "1234",
+				Endpoints: []datastore.Endpoint{
+					{
+						UID:       "endpoint1",
+						TargetURL: "https://fb.com",
+						Authentication: &datastore.EndpointAuthentication{
+							Type: datastore.OAuth2EndpointAuthentication,
+							OAuth2: &datastore.OAuth2Config{
+								TokenURL:     "https://auth.convoy.test/token",
+								ClientID:     "client",
+								ClientSecret: "secret",
+								Scopes:       []string{"webhooks"},
+							},
+						},
+					},
+				},
+			},
+			wantEndpoint: &datastore.Endpoint{
+				UID:       "endpoint1",
+				TargetURL: "https://fb.com",
+				Authentication: &datastore.EndpointAuthentication{
+					Type: datastore.OAuth2EndpointAuthentication,
+					OAuth2: &datastore.OAuth2Config{
+						TokenURL:     "https://auth.convoy.test/token",
+						ClientID:     "client",
+						ClientSecret: "secret",
+						Scopes:       []string{"webhooks"},
+					},
+				},
+			},
+			dbFn: func(as *AppService) {
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(nil)
+
+				c, _ := as.cache.(*mocks.MockCache)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name: "should_error_for_invalid_authentication",
+			args: args{
+				ctx: ctx,
+				e: models.Endpoint{
+					URL:            "https://fb.com",
+					Authentication: &datastore.EndpointAuthentication{Type: "digest"},
+				},
+				endPointId: "endpoint1",
+				app: &datastore.Application{
+					UID: "1234",
+					Endpoints: []datastore.Endpoint{
+						{
+							UID:       "endpoint1",
+							TargetURL: "https://google.com",
+						},
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "type:unsupported authentication type",
+		},
 		{
 			name: "should_error_for_invalid_rate_limit_duration",
 			args: args{
@@ -1000,6 +1210,124 @@ func TestAppService_UpdateAppEndpoint(t *testing.T) {
 	}
 }
 
+func TestAppService_RotateAppEndpointSecret(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		rotate      *models.RotateSecret
+		endpoint    datastore.Endpoint
+		dbFn        func(as *AppService)
+		wantSecret  string
+		wantRotated []string
+		wantErr     bool
+		wantErrMsg  string
+	}{
+		{
+			name:   "should_rotate_secret",
+			rotate: &models.RotateSecret{Secret: "new-secret", Expiration: "1h"},
+			endpoint: datastore.Endpoint{
+				UID:    "endpoint1",
+				Secret: "old-secret",
+				RotatedSecrets: []datastore.RotatedSecret{
+					{Secret: "older-secret", ExpiresAt: primitive.NewDateTimeFromTime(now.Add(time.Minute))},
+					{Secret: "expired-secret", ExpiresAt: primitive.NewDateTimeFromTime(now.Add(-time.Minute))},
+				},
+			},
+			dbFn: func(as *AppService) {
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := as.cache.(*mocks.MockCache)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			wantSecret:  "new-secret",
+			wantRotated: []string{"old-secret", "older-secret"},
+		},
+		{
+			name:     "should_generate_secret",
+			rotate:   &models.RotateSecret{},
+			endpoint: datastore.Endpoint{UID: "endpoint1", Secret: "old-secret"},
+			dbFn: func(as *AppService) {
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				c, _ := as.cache.(*mocks.MockCache)
+				c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			wantRotated: []string{"old-secret"},
+		},
+		{
+			name:       "should_error_for_invalid_expiration",
+			rotate:     &models.RotateSecret{Expiration: "-1h"},
+			endpoint:   datastore.Endpoint{UID: "endpoint1", Secret: "old-secret"},
+			wantErr:    true,
+			wantErrMsg: "please provide a valid secret expiration",
+		},
+		{
+			name:       "should_error_for_current_secret",
+			rotate:     &models.RotateSecret{Secret: "old-secret"},
+			endpoint:   datastore.Endpoint{UID: "endpoint1", Secret: "old-secret"},
+			wantErr:    true,
+			wantErrMsg: "the new secret must differ from the current secret",
+		},
+		{
+			name:       "should_error_for_endpoint_not_found",
+			rotate:     &models.RotateSecret{},
+			endpoint:   datastore.Endpoint{UID: "endpoint2", Secret: "old-secret"},
+			wantErr:    true,
+			wantErrMsg: "endpoint not found",
+		},
+		{
+			name:     "should_fail_to_update_application",
+			rotate:   &models.RotateSecret{},
+			endpoint: datastore.Endpoint{UID: "endpoint1", Secret: "old-secret"},
+			dbFn: func(as *AppService) {
+				a, _ := as.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().UpdateApplication(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:    true,
+			wantErrMsg: "an error occurred while rotating endpoint secret",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			as := provideAppService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(as)
+			}
+
+			app := &datastore.Application{UID: "1234", Endpoints: []datastore.Endpoint{tc.endpoint}}
+
+			endpoint, err := as.RotateAppEndpointSecret(ctx, tc.rotate, "endpoint1", app)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, http.StatusBadRequest, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, &app.Endpoints[0], endpoint)
+			require.NotEmpty(t, endpoint.Secret)
+			if tc.wantSecret != "" {
+				require.Equal(t, tc.wantSecret, endpoint.Secret)
+			}
+
+			var rotated []string
+			for _, rs := range endpoint.RotatedSecrets {
+				require.True(t, rs.ExpiresAt.Time().After(now))
+				rotated = append(rotated, rs.Secret)
+			}
+			require.Equal(t, tc.wantRotated, rotated)
+		})
+	}
+}
+
 func TestAppService_DeleteAppEndpoint(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -1117,3 +1445,33 @@ func TestAppService_DeleteAppEndpoint(t *testing.T) {
 		})
 	}
 }
+
+var testCertPEM, testKeyPEM = generateTestKeyPair()
+
+// generateTestKeyPair returns a self signed certificate and its key.
+func generateTestKeyPair() (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "convoy"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
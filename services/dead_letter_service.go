@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/util"
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrEmptyDeadLetterIDs = errors.New("please provide the dead letter ids")
+
+type DeadLetterService struct {
+	deadLetterRepo    datastore.DeadLetterRepository
+	eventDeliveryRepo datastore.EventDeliveryRepository
+	eventService      *EventService
+}
+
+func NewDeadLetterService(deadLetterRepo datastore.DeadLetterRepository, eventDeliveryRepo datastore.EventDeliveryRepository, eventService *EventService) *DeadLetterService {
+	return &DeadLetterService{deadLetterRepo: deadLetterRepo, eventDeliveryRepo: eventDeliveryRepo, eventService: eventService}
+}
+
+func (d *DeadLetterService) LoadDeadLettersPaged(ctx context.Context, filter *datastore.DeadLetterFilter, pageable datastore.Pageable) ([]datastore.DeadLetter, datastore.PaginationData, error) {
+	deadLetters, paginationData, err := d.deadLetterRepo.LoadDeadLettersPaged(ctx, filter, pageable)
+	if err != nil {
+		log.WithError(err).Error("failed to load dead letters")
+		return nil, datastore.PaginationData{}, util.NewServiceError(http.StatusInternalServerError, errors.New("an error occurred while fetching dead letters"))
+	}
+
+	return deadLetters, paginationData, nil
+}
+
+func (d *DeadLetterService) GetDeadLetter(ctx context.Context, groupID string, id string) (*datastore.DeadLetter, error) {
+	deadLetter, err := d.deadLetterRepo.FindDeadLetterByID(ctx, groupID, id)
+	if err != nil {
+		if errors.Is(err, datastore.ErrDeadLetterNotFound) {
+			return nil, util.NewServiceError(http.StatusNotFound, err)
+		}
+
+		log.WithError(err).Error("failed to find dead letter")
+		return nil, util.NewServiceError(http.StatusInternalServerError, errors.New("failed to find dead letter"))
+	}
+
+	return deadLetter, nil
+}
+
+// ReplayDeadLetters requeues the event deliveries of the dead letters, the
+// dead letters that are requeued successfully are removed from the queue.
+func (d *DeadLetterService) ReplayDeadLetters(ctx context.Context, ids []string, g *datastore.Group) (int, int, error) {
+	deadLetters, err := d.findDeadLetters(ctx, ids, g)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	failures := 0
+	replayed := make([]string, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		delivery, err := d.eventDeliveryRepo.FindEventDeliveryByID(ctx, deadLetter.EventDeliveryID)
+		if err != nil {
+			failures++
+			log.WithError(err).Error("failed to find dead letter event delivery")
+			continue
+		}
+
+		err = d.eventService.RetryEventDelivery(ctx, delivery, g)
+		if err != nil {
+			failures++
+			log.WithError(err).Error("an item in the dead letter replay batch failed")
+			continue
+		}
+
+		replayed = append(replayed, deadLetter.UID)
+	}
+
+	if len(replayed) > 0 {
+		err = d.deadLetterRepo.DeleteDeadLetters(ctx, g.UID, replayed)
+		if err != nil {
+			log.WithError(err).Error("failed to remove replayed dead letters")
+		}
+	}
+
+	return len(replayed), failures, nil
+}
+
+func (d *DeadLetterService) PurgeDeadLetters(ctx context.Context, ids []string, g *datastore.Group) (int, error) {
+	deadLetters, err := d.findDeadLetters(ctx, ids, g)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(deadLetters) == 0 {
+		return 0, nil
+	}
+
+	uids := make([]string, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		uids = append(uids, deadLetter.UID)
+	}
+
+	err = d.deadLetterRepo.DeleteDeadLetters(ctx, g.UID, uids)
+	if err != nil {
+		log.WithError(err).Error("failed to purge dead letters")
+		return 0, util.NewServiceError(http.StatusInternalServerError, errors.New("failed to purge dead letters"))
+	}
+
+	return len(uids), nil
+}
+
+func (d *DeadLetterService) findDeadLetters(ctx context.Context, ids []string, g *datastore.Group) ([]datastore.DeadLetter, error) {
+	if len(ids) == 0 {
+		return nil, util.NewServiceError(http.StatusBadRequest, ErrEmptyDeadLetterIDs)
+	}
+
+	deadLetters, err := d.deadLetterRepo.FindDeadLettersByIDs(ctx, g.UID, ids)
+	if err != nil {
+		log.WithError(err).Error("failed to fetch dead letters by ids")
+		return nil, util.NewServiceError(http.StatusInternalServerError, errors.New("failed to fetch dead letters"))
+	}
+
+	return deadLetters, nil
+}
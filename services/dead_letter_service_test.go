@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func provideDeadLetterService(ctrl *gomock.Controller) *DeadLetterService {
+	deadLetterRepo := mocks.NewMockDeadLetterRepository(ctrl)
+	es := provideEventService(ctrl)
+	return NewDeadLetterService(deadLetterRepo, es.eventDeliveryRepo, es)
+}
+
+func TestDeadLetterService_ReplayDeadLetters(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx context.Context
+		ids []string
+		g   *datastore.Group
+	}
+	tests := []struct {
+		name          string
+		args          args
+		dbFn          func(ds *DeadLetterService)
+		wantSuccesses int
+		wantFailures  int
+		wantErr       bool
+		wantErrCode   int
+		wantErrMsg    string
+	}{
+		{
+			name: "should_replay_dead_letters",
+			args: args{
+				ctx: ctx,
+				ids: []string{"dl-1", "dl-2"},
+				g:   &datastore.Group{UID: "group-1"},
+			},
+			dbFn: func(ds *DeadLetterService) {
+				dl, _ := ds.deadLetterRepo.(*mocks.MockDeadLetterRepository)
+				dl.EXPECT().FindDeadLettersByIDs(gomock.Any(), "group-1", []string{"dl-1", "dl-2"}).Times(1).Return([]datastore.DeadLetter{
+					{UID: "dl-1", EventDeliveryID: "ed-1"},
+					{UID: "dl-2", EventDeliveryID: "ed-2"},
+				}, nil)
+
+				ed, _ := ds.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "ed-1").Times(1).Return(&datastore.EventDelivery{
+					UID:            "ed-1",
+					SubscriptionID: "sub-1",
+					Status:         datastore.FailureEventStatus,
+				}, nil)
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "ed-2").Times(1).Return(&datastore.EventDelivery{
+					UID:            "ed-2",
+					SubscriptionID: "sub-1",
+					Status:         datastore.SuccessEventStatus,
+				}, nil)
+
+				s, _ := ds.eventService.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "group-1", "sub-1").Times(1).Return(&datastore.Subscription{
+					UID:    "sub-1",
+					Status: datastore.ActiveSubscriptionStatus,
+				}, nil)
+
+				ed.EXPECT().UpdateStatusOfEventDelivery(gomock.Any(), gomock.Any(), datastore.ScheduledEventStatus).Times(1).Return(nil)
+
+				q, _ := ds.eventService.queue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(1).Return(nil)
+
+				dl.EXPECT().DeleteDeadLetters(gomock.Any(), "group-1", []string{"dl-1"}).Times(1).Return(nil)
+			},
+			wantSuccesses: 1,
+			wantFailures:  1,
+		},
+		{
+			name: "should_error_for_empty_ids",
+			args: args{
+				ctx: ctx,
+				g:   &datastore.Group{UID: "group-1"},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "please provide the dead letter ids",
+		},
+		{
+			name: "should_fail_to_fetch_dead_letters",
+			args: args{
+				ctx: ctx,
+				ids: []string{"dl-1"},
+				g:   &datastore.Group{UID: "group-1"},
+			},
+			dbFn: func(ds *DeadLetterService) {
+				dl, _ := ds.deadLetterRepo.(*mocks.MockDeadLetterRepository)
+				dl.EXPECT().FindDeadLettersByIDs(gomock.Any(), "group-1", []string{"dl-1"}).Times(1).Return(nil, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusInternalServerError,
+			wantErrMsg:  "failed to fetch dead letters",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ds := provideDeadLetterService(ctrl)
+
+			if tt.dbFn != nil {
+				tt.dbFn(ds)
+			}
+
+			successes, failures, err := ds.ReplayDeadLetters(tt.args.ctx, tt.args.ids, tt.args.g)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tt.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tt.wantSuccesses, successes)
+			require.Equal(t, tt.wantFailures, failures)
+		})
+	}
+}
+
+func TestDeadLetterService_PurgeDeadLetters(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx context.Context
+		ids []string
+		g   *datastore.Group
+	}
+	tests := []struct {
+		name        string
+		args        args
+		dbFn        func(ds *DeadLetterService)
+		want        int
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_purge_dead_letters",
+			args: args{
+				ctx: ctx,
+				ids: []string{"dl-1", "dl-2", "dl-3"},
+				g:   &datastore.Group{UID: "group-1"},
+			},
+			dbFn: func(ds *DeadLetterService) {
+				dl, _ := ds.deadLetterRepo.(*mocks.MockDeadLetterRepository)
+				dl.EXPECT().FindDeadLettersByIDs(gomock.Any(), "group-1", []string{"dl-1", "dl-2", "dl-3"}).Times(1).Return([]datastore.DeadLetter{
+					{UID: "dl-1"},
+					{UID: "dl-2"},
+				}, nil)
+				dl.EXPECT().DeleteDeadLetters(gomock.Any(), "group-1", []string{"dl-1", "dl-2"}).Times(1).Return(nil)
+			},
+			want: 2,
+		},
+		{
+			name: "should_fail_to_purge_dead_letters",
+			args: args{
+				ctx: ctx,
+				ids: []string{"dl-1"},
+				g:   &datastore.Group{UID: "group-1"},
+			},
+			dbFn: func(ds *DeadLetterService) {
+				dl, _ := ds.deadLetterRepo.(*mocks.MockDeadLetterRepository)
+				dl.EXPECT().FindDeadLettersByIDs(gomock.Any(), "group-1", []string{"dl-1"}).Times(1).Return([]datastore.DeadLetter{
+					{UID: "dl-1"},
+				}, nil)
+				dl.EXPECT().DeleteDeadLetters(gomock.Any(), "group-1", []string{"dl-1"}).Times(1).Return(errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusInternalServerError,
+			wantErrMsg:  "failed to purge dead letters",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ds := provideDeadLetterService(ctrl)
+
+			if tt.dbFn != nil {
+				tt.dbFn(ds)
+			}
+
+			purged, err := ds.PurgeDeadLetters(tt.args.ctx, tt.args.ids, tt.args.g)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tt.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tt.want, purged)
+		})
+	}
+}
@@ -21,6 +21,12 @@ import (
 )
 
 var ErrInvalidEventDeliveryStatus = errors.New("only successful events can be force resent")
+var ErrNoEventTarget = errors.New("please provide an app id, owner id or app tags")
+var ErrMultipleEventTargets = errors.New("please provide only one of app id, owner id or app tags")
+var ErrIdempotencyKeyInProgress = errors.New("an event with the same idempotency key is being created, retry the request")
+
+// MaxBatchEventSize is the most events that can be created in one batch.
+const MaxBatchEventSize = 1000
 
 type EventService struct {
 	appRepo           datastore.ApplicationRepository
@@ -48,6 +54,166 @@ func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Ev
 		return nil, util.NewServiceError(http.StatusBadRequest, err)
 	}
 
+	if err := validateEventTarget(newMessage); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	idempotencyKey := newMessage.IdempotencyKey
+	if util.IsStringEmpty(idempotencyKey) {
+		idempotencyKey = newMessage.ProviderID
+	}
+
+	window := idempotencyWindow(g)
+	if !util.IsStringEmpty(idempotencyKey) {
+		original, err := e.ReserveIdempotencyKey(ctx, g.UID, "", idempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if original != nil {
+			return original, nil
+		}
+	}
+
+	event, err := e.newAppEvent(ctx, newMessage, idempotencyKey, g)
+	if err != nil {
+		e.releaseIdempotencyKey(ctx, g.UID, "", idempotencyKey)
+		return nil, err
+	}
+
+	job, err := newEventJob(event)
+	if err != nil {
+		e.releaseIdempotencyKey(ctx, g.UID, "", idempotencyKey)
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	err = e.queue.Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, job)
+	if err != nil {
+		log.Errorf("Error occurred sending new event to the queue %s", err)
+		e.releaseIdempotencyKey(ctx, g.UID, "", idempotencyKey)
+		return event, nil
+	}
+
+	if !util.IsStringEmpty(idempotencyKey) {
+		err = e.SaveIdempotentEvent(ctx, event, window)
+		if err != nil {
+			log.WithError(err).Error("failed to save event idempotency key")
+		}
+	}
+
+	return event, nil
+}
+
+// CreateAppEvents creates a batch of events, each event is validated on its own
+// and the result of every event is returned in the order the events were sent.
+func (e *EventService) CreateAppEvents(ctx context.Context, batch *models.BatchEvent, g *datastore.Group) ([]models.BatchEventResult, error) {
+	if g == nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("an error occurred while creating events - invalid group"))
+	}
+
+	if len(batch.Events) == 0 {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("please provide at least one event"))
+	}
+
+	if len(batch.Events) > MaxBatchEventSize {
+		return nil, util.NewServiceError(http.StatusBadRequest, fmt.Errorf("a batch can't have more than %d events", MaxBatchEventSize))
+	}
+
+	results := make([]models.BatchEventResult, len(batch.Events))
+	events := make([]*datastore.Event, 0, len(batch.Events))
+	jobs := make([]*queue.Job, 0, len(batch.Events))
+	indexes := make([]int, 0, len(batch.Events))
+
+	// events in the batch with the same provider id are deduplicated too
+	created := map[string]*datastore.Event{}
+	window := idempotencyWindow(g)
+
+	for i := range batch.Events {
+		newMessage := &batch.Events[i]
+		results[i] = models.BatchEventResult{Index: i}
+
+		if err := util.Validate(newMessage); err != nil {
+			results[i].Status, results[i].Error = http.StatusBadRequest, err.Error()
+			continue
+		}
+
+		if err := validateEventTarget(newMessage); err != nil {
+			results[i].Status, results[i].Error = http.StatusBadRequest, err.Error()
+			continue
+		}
+
+		idempotencyKey := newMessage.ProviderID
+		if !util.IsStringEmpty(idempotencyKey) {
+			original := created[idempotencyKey]
+			if original == nil {
+				var err error
+				original, err = e.ReserveIdempotencyKey(ctx, g.UID, "", idempotencyKey)
+				if err != nil {
+					results[i].Status, results[i].Error = serviceErrorStatus(err), err.Error()
+					continue
+				}
+			}
+
+			if original != nil {
+				results[i].Status, results[i].Event = http.StatusOK, original
+				continue
+			}
+		}
+
+		event, err := e.newAppEvent(ctx, newMessage, idempotencyKey, g)
+		if err != nil {
+			e.releaseIdempotencyKey(ctx, g.UID, "", idempotencyKey)
+			results[i].Status, results[i].Error = serviceErrorStatus(err), err.Error()
+			continue
+		}
+
+		job, err := newEventJob(event)
+		if err != nil {
+			e.releaseIdempotencyKey(ctx, g.UID, "", idempotencyKey)
+			results[i].Status, results[i].Error = http.StatusBadRequest, err.Error()
+			continue
+		}
+
+		if !util.IsStringEmpty(idempotencyKey) {
+			created[idempotencyKey] = event
+		}
+
+		events = append(events, event)
+		jobs = append(jobs, job)
+		indexes = append(indexes, i)
+	}
+
+	if len(jobs) == 0 {
+		return results, nil
+	}
+
+	errs := e.queue.WriteMany(convoy.CreateEventProcessor, convoy.CreateEventQueue, jobs)
+	for j, i := range indexes {
+		if errs[j] != nil {
+			log.WithError(errs[j]).Error("failed to write event to the queue")
+			e.releaseIdempotencyKey(ctx, g.UID, "", events[j].IdempotencyKey)
+			results[i].Status, results[i].Error = http.StatusInternalServerError, "failed to write event to queue"
+			continue
+		}
+
+		results[i].Status, results[i].Event = http.StatusCreated, events[j]
+
+		if !util.IsStringEmpty(events[j].IdempotencyKey) {
+			err := e.SaveIdempotentEvent(ctx, events[j], window)
+			if err != nil {
+				log.WithError(err).Error("failed to save event idempotency key")
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (e *EventService) newAppEvent(ctx context.Context, newMessage *models.Event, idempotencyKey string, g *datastore.Group) (*datastore.Event, error) {
+	if util.IsStringEmpty(newMessage.AppID) {
+		return newFanOutEvent(newMessage, idempotencyKey, g)
+	}
+
 	var app *datastore.Application
 	appCacheKey := convoy.ApplicationsCacheKey.Get(newMessage.AppID).String()
 
@@ -90,33 +256,175 @@ func (e *EventService) CreateAppEvent(ctx context.Context, newMessage *models.Ev
 		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
 		AppID:          app.UID,
 		GroupID:        app.GroupID,
+		ProviderID:     newMessage.ProviderID,
+		IdempotencyKey: idempotencyKey,
 		DocumentStatus: datastore.ActiveDocumentStatus,
 	}
 
-	if (g.Config == nil || g.Config.Strategy == nil) ||
-		(g.Config.Strategy != nil && g.Config.Strategy.Type != datastore.LinearStrategyProvider && g.Config.Strategy.Type != datastore.ExponentialStrategyProvider) {
+	if !isValidGroupStrategy(g) {
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("retry strategy not defined in configuration"))
 	}
 
-	taskName := convoy.CreateEventProcessor
+	return event, nil
+}
+
+// newFanOutEvent creates an event addressed to an owner or app tags,
+// it is expanded to the matching apps when the event is processed.
+func newFanOutEvent(newMessage *models.Event, idempotencyKey string, g *datastore.Group) (*datastore.Event, error) {
+	if !isValidGroupStrategy(g) {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("retry strategy not defined in configuration"))
+	}
+
+	return &datastore.Event{
+		UID:            uuid.New().String(),
+		EventType:      datastore.EventType(newMessage.EventType),
+		Data:           newMessage.Data,
+		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+		GroupID:        g.UID,
+		OwnerID:        newMessage.OwnerID,
+		AppTags:        newMessage.AppTags,
+		ProviderID:     newMessage.ProviderID,
+		IdempotencyKey: idempotencyKey,
+		DocumentStatus: datastore.ActiveDocumentStatus,
+	}, nil
+}
+
+// validateEventTarget checks that the event is addressed to exactly one of an app, owner or app tags.
+func validateEventTarget(newMessage *models.Event) error {
+	targets := 0
+	if !util.IsStringEmpty(newMessage.AppID) {
+		targets++
+	}
+
+	if !util.IsStringEmpty(newMessage.OwnerID) {
+		targets++
+	}
+
+	if len(newMessage.AppTags) > 0 {
+		targets++
+	}
+
+	switch targets {
+	case 0:
+		return ErrNoEventTarget
+	case 1:
+		return nil
+	default:
+		return ErrMultipleEventTargets
+	}
+}
+
+func isValidGroupStrategy(g *datastore.Group) bool {
+	if g.Config == nil || g.Config.Strategy == nil {
+		return false
+	}
+
+	return g.Config.Strategy.Type == datastore.LinearStrategyProvider || g.Config.Strategy.Type == datastore.ExponentialStrategyProvider
+}
+
+func newEventJob(event *datastore.Event) (*queue.Job, error) {
 	eventByte, err := json.Marshal(event)
 	if err != nil {
-		return nil, util.NewServiceError(http.StatusBadRequest, err)
+		return nil, err
 	}
 
-	payload := json.RawMessage(eventByte)
-
-	job := &queue.Job{
+	return &queue.Job{
 		ID:      event.UID,
-		Payload: payload,
+		Payload: json.RawMessage(eventByte),
 		Delay:   0,
+	}, nil
+}
+
+// idempotencyReservationTTL bounds how long a key stays reserved while its
+// event is being created, a reservation left behind by a crashed server or a
+// cancelled request expires after it instead of the whole window.
+const idempotencyReservationTTL = time.Minute
+
+// idempotentEvent is cached under an idempotency key, Event is nil while
+// the request that reserved the key hasn't enqueued its event yet.
+type idempotentEvent struct {
+	Event *datastore.Event
+}
+
+// ReserveIdempotencyKey reserves the idempotency key of the group, or of the
+// group's source when sourceID isn't empty, until its event is saved. It
+// returns nil when the key is reserved, the event created with the key when
+// it's taken, and ErrIdempotencyKeyInProgress when its event is still being
+// created.
+func (e *EventService) ReserveIdempotencyKey(ctx context.Context, groupID, sourceID, key string) (*datastore.Event, error) {
+	cacheKey := idempotencyCacheKey(groupID, sourceID, key)
+
+	reserved, err := e.cache.SetIfNotExists(ctx, cacheKey, &idempotentEvent{}, idempotencyReservationTTL)
+	if err != nil {
+		log.WithError(err).Error("failed to reserve event idempotency key")
+		return nil, nil
 	}
-	err = e.queue.Write(taskName, convoy.CreateEventQueue, job)
+
+	if reserved {
+		return nil, nil
+	}
+
+	var entry *idempotentEvent
+	err = e.cache.Get(ctx, cacheKey, &entry)
 	if err != nil {
-		log.Errorf("Error occurred sending new event to the queue %s", err)
+		log.WithError(err).Error("failed to check event idempotency key")
 	}
 
-	return event, nil
+	if entry == nil || entry.Event == nil {
+		return nil, util.NewServiceError(http.StatusConflict, ErrIdempotencyKeyInProgress)
+	}
+
+	return entry.Event, nil
+}
+
+// SaveIdempotentEvent remembers the event by its reserved idempotency key,
+// the reservation is extended to the window.
+func (e *EventService) SaveIdempotentEvent(ctx context.Context, event *datastore.Event, window time.Duration) error {
+	cacheKey := idempotencyCacheKey(event.GroupID, event.SourceID, event.IdempotencyKey)
+	return e.cache.Set(ctx, cacheKey, &idempotentEvent{Event: event}, window)
+}
+
+// ReleaseIdempotencyKey frees a reserved idempotency key whose event couldn't
+// be created, so that the request can be retried.
+func (e *EventService) ReleaseIdempotencyKey(ctx context.Context, groupID, sourceID, key string) error {
+	return e.cache.Delete(ctx, idempotencyCacheKey(groupID, sourceID, key))
+}
+
+func (e *EventService) releaseIdempotencyKey(ctx context.Context, groupID, sourceID, key string) {
+	if util.IsStringEmpty(key) {
+		return
+	}
+
+	if err := e.ReleaseIdempotencyKey(ctx, groupID, sourceID, key); err != nil {
+		log.WithError(err).Error("failed to release event idempotency key")
+	}
+}
+
+func idempotencyCacheKey(groupID, sourceID, key string) string {
+	cacheKey := convoy.IdempotencyCacheKey.Get(groupID)
+	if !util.IsStringEmpty(sourceID) {
+		cacheKey = cacheKey.Get(sourceID)
+	}
+
+	return cacheKey.Get(key).String()
+}
+
+func idempotencyWindow(g *datastore.Group) time.Duration {
+	var cfg *datastore.IdempotencyConfiguration
+	if g.Config != nil {
+		cfg = g.Config.Idempotency
+	}
+
+	return cfg.WindowDuration()
+}
+
+func serviceErrorStatus(err error) int {
+	if serr, ok := err.(*util.ServiceError); ok {
+		return serr.ErrCode()
+	}
+
+	return http.StatusBadRequest
 }
 
 func (e *EventService) ReplayAppEvent(ctx context.Context, event *datastore.Event, g *datastore.Group) error {
@@ -368,13 +676,33 @@ func (e *EventService) ResendEventDelivery(ctx context.Context, eventDelivery *d
 	return nil
 }
 
+// LoadOrderingQueues returns the pending ordering queues of a subscription, with the
+// delivery at the head of each queue and how many deliveries it is blocking.
+func (e *EventService) LoadOrderingQueues(ctx context.Context, g *datastore.Group, subscriptionID string) ([]datastore.OrderingQueue, error) {
+	_, err := e.subRepo.FindSubscriptionByID(ctx, g.UID, subscriptionID)
+	if err != nil {
+		log.WithError(err).Error(ErrSubscriptionNotFound.Error())
+		return nil, util.NewServiceError(http.StatusNotFound, ErrSubscriptionNotFound)
+	}
+
+	queues, err := e.eventDeliveryRepo.LoadOrderingQueues(ctx, subscriptionID)
+	if err != nil {
+		log.WithError(err).Error("failed to load ordering queues")
+		return nil, util.NewServiceError(http.StatusInternalServerError, errors.New("failed to load ordering queues"))
+	}
+
+	return queues, nil
+}
+
 func (e *EventService) RetryEventDelivery(ctx context.Context, eventDelivery *datastore.EventDelivery, g *datastore.Group) error {
 	switch eventDelivery.Status {
 	case datastore.SuccessEventStatus:
 		return errors.New("event already sent")
 	case datastore.ScheduledEventStatus,
 		datastore.ProcessingEventStatus,
-		datastore.RetryEventStatus:
+		datastore.RetryEventStatus,
+		datastore.CircuitOpenEventStatus,
+		datastore.BlockedEventStatus:
 		return errors.New("cannot resend event that did not fail previously")
 	}
 
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/datastore"
@@ -14,6 +15,7 @@ import (
 	"github.com/frain-dev/convoy/util"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func provideEventService(ctrl *gomock.Controller) *EventService {
@@ -104,6 +106,166 @@ func TestEventService_CreateAppEvent(t *testing.T) {
 				DocumentStatus:   datastore.ActiveDocumentStatus,
 			},
 		},
+		{
+			name: "should_create_event_with_idempotency_key",
+			dbFn: func(es *EventService) {
+				c, _ := es.cache.(*mocks.MockCache)
+				c.EXPECT().SetIfNotExists(gomock.Any(), "idempotency:abc:key-1", gomock.Any(), idempotencyReservationTTL).Times(1).Return(true, nil)
+				c.EXPECT().Get(gomock.Any(), "applications:123", gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), "applications:123", gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().Set(gomock.Any(), "idempotency:abc:key-1", gomock.Any(), 24*time.Hour).Times(1).Return(nil)
+
+				a, _ := es.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "123").
+					Times(1).Return(&datastore.Application{
+					UID:       "123",
+					GroupID:   "abc",
+					Endpoints: []datastore.Endpoint{{UID: "ref"}},
+				}, nil)
+
+				eq, _ := es.queue.(*mocks.MockQueuer)
+				eq.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).
+					Times(1).Return(nil)
+			},
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:          "123",
+					EventType:      "payment.created",
+					Data:           bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+					IdempotencyKey: "key-1",
+				},
+				g: &datastore.Group{
+					UID: "abc",
+					Config: &datastore.GroupConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       "linear",
+							Duration:   1000,
+							RetryCount: 10,
+						},
+					},
+				},
+			},
+			wantEvent: &datastore.Event{
+				EventType:      datastore.EventType("payment.created"),
+				Data:           bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+				AppID:          "123",
+				GroupID:        "abc",
+				IdempotencyKey: "key-1",
+				DocumentStatus: datastore.ActiveDocumentStatus,
+			},
+		},
+		{
+			name: "should_return_original_event_for_duplicate_provider_id",
+			dbFn: func(es *EventService) {
+				c, _ := es.cache.(*mocks.MockCache)
+				c.EXPECT().SetIfNotExists(gomock.Any(), "idempotency:abc:evt_1", gomock.Any(), idempotencyReservationTTL).Times(1).Return(false, nil)
+				c.EXPECT().Get(gomock.Any(), "idempotency:abc:evt_1", gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**idempotentEvent) = &idempotentEvent{Event: &datastore.Event{
+							UID:            "original",
+							EventType:      datastore.EventType("payment.created"),
+							AppID:          "123",
+							GroupID:        "abc",
+							ProviderID:     "evt_1",
+							IdempotencyKey: "evt_1",
+							CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+							UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
+						}}
+						return nil
+					})
+			},
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:      "123",
+					EventType:  "payment.created",
+					Data:       bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+					ProviderID: "evt_1",
+				},
+				g: &datastore.Group{UID: "abc"},
+			},
+			wantEvent: &datastore.Event{
+				EventType:      datastore.EventType("payment.created"),
+				AppID:          "123",
+				GroupID:        "abc",
+				ProviderID:     "evt_1",
+				IdempotencyKey: "evt_1",
+			},
+		},
+		{
+			name: "should_error_for_idempotency_key_in_progress",
+			dbFn: func(es *EventService) {
+				c, _ := es.cache.(*mocks.MockCache)
+				c.EXPECT().SetIfNotExists(gomock.Any(), "idempotency:abc:evt_1", gomock.Any(), idempotencyReservationTTL).Times(1).Return(false, nil)
+				c.EXPECT().Get(gomock.Any(), "idempotency:abc:evt_1", gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**idempotentEvent) = &idempotentEvent{}
+						return nil
+					})
+			},
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:      "123",
+					EventType:  "payment.created",
+					Data:       bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+					ProviderID: "evt_1",
+				},
+				g: &datastore.Group{UID: "abc"},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusConflict,
+			wantErrMsg:  ErrIdempotencyKeyInProgress.Error(),
+		},
+		{
+			name: "should_release_idempotency_key_when_queue_write_fails",
+			dbFn: func(es *EventService) {
+				c, _ := es.cache.(*mocks.MockCache)
+				c.EXPECT().SetIfNotExists(gomock.Any(), "idempotency:abc:key-1", gomock.Any(), idempotencyReservationTTL).Times(1).Return(true, nil)
+				c.EXPECT().Get(gomock.Any(), "applications:123", gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**datastore.Application) = &datastore.Application{
+							UID:       "123",
+							GroupID:   "abc",
+							Endpoints: []datastore.Endpoint{{UID: "ref"}},
+						}
+						return nil
+					})
+				c.EXPECT().Delete(gomock.Any(), "idempotency:abc:key-1").Times(1).Return(nil)
+
+				eq, _ := es.queue.(*mocks.MockQueuer)
+				eq.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).
+					Times(1).Return(errors.New("failed"))
+			},
+			args: args{
+				ctx: ctx,
+				newMessage: &models.Event{
+					AppID:          "123",
+					EventType:      "payment.created",
+					Data:           bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+					IdempotencyKey: "key-1",
+				},
+				g: &datastore.Group{
+					UID: "abc",
+					Config: &datastore.GroupConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       "linear",
+							Duration:   1000,
+							RetryCount: 10,
+						},
+					},
+				},
+			},
+			wantEvent: &datastore.Event{
+				EventType:      datastore.EventType("payment.created"),
+				Data:           bytes.NewBufferString(`{"name":"convoy"}`).Bytes(),
+				AppID:          "123",
+				GroupID:        "abc",
+				IdempotencyKey: "key-1",
+				DocumentStatus: datastore.ActiveDocumentStatus,
+			},
+		},
 		{
 			name: "should_create_event_with_exponential_backoff_strategy",
 			dbFn: func(es *EventService) {
@@ -275,7 +437,7 @@ func TestEventService_CreateAppEvent(t *testing.T) {
 			},
 			wantErr:     true,
 			wantErrCode: http.StatusBadRequest,
-			wantErrMsg:  "app_id:please provide an app id",
+			wantErrMsg:  "please provide an app id, owner id or app tags",
 		},
 		{
 			name: "should_error_for_application_not_found",
@@ -385,6 +547,156 @@ func TestEventService_CreateAppEvent(t *testing.T) {
 	}
 }
 
+func TestEventService_CreateAppEvents(t *testing.T) {
+	ctx := context.Background()
+
+	group := &datastore.Group{
+		UID: "abc",
+		Config: &datastore.GroupConfig{
+			Strategy: &datastore.StrategyConfiguration{
+				Type:       "linear",
+				Duration:   1000,
+				RetryCount: 10,
+			},
+		},
+	}
+
+	app := &datastore.Application{
+		UID:       "123",
+		GroupID:   "abc",
+		Endpoints: []datastore.Endpoint{{UID: "ref"}},
+	}
+
+	type args struct {
+		ctx   context.Context
+		batch *models.BatchEvent
+		g     *datastore.Group
+	}
+	tests := []struct {
+		name        string
+		dbFn        func(es *EventService)
+		args        args
+		wantStatus  []int
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_create_events_with_partial_failures",
+			dbFn: func(es *EventService) {
+				c, _ := es.cache.(*mocks.MockCache)
+				c.EXPECT().Get(gomock.Any(), "applications:123", gomock.Any()).Times(3).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**datastore.Application) = app
+						return nil
+					})
+				c.EXPECT().Get(gomock.Any(), "applications:456", gomock.Any()).Times(1).Return(nil)
+				c.EXPECT().SetIfNotExists(gomock.Any(), "idempotency:abc:evt_1", gomock.Any(), idempotencyReservationTTL).Times(1).Return(true, nil)
+				c.EXPECT().SetIfNotExists(gomock.Any(), "idempotency:abc:evt_2", gomock.Any(), idempotencyReservationTTL).Times(1).Return(false, nil)
+				c.EXPECT().Get(gomock.Any(), "idempotency:abc:evt_2", gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**idempotentEvent) = &idempotentEvent{Event: &datastore.Event{UID: "original", ProviderID: "evt_2"}}
+						return nil
+					})
+				c.EXPECT().SetIfNotExists(gomock.Any(), "idempotency:abc:evt_3", gomock.Any(), idempotencyReservationTTL).Times(1).Return(true, nil)
+				c.EXPECT().Set(gomock.Any(), "idempotency:abc:evt_1", gomock.Any(), 24*time.Hour).Times(1).Return(nil)
+				c.EXPECT().Delete(gomock.Any(), "idempotency:abc:evt_3").Times(1).Return(nil)
+
+				a, _ := es.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "456").
+					Times(1).Return(nil, datastore.ErrApplicationNotFound)
+
+				eq, _ := es.queue.(*mocks.MockQueuer)
+				eq.EXPECT().WriteMany(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Len(3)).
+					Times(1).Return([]error{nil, nil, errors.New("failed")})
+			},
+			args: args{
+				ctx: ctx,
+				batch: &models.BatchEvent{
+					Events: []models.Event{
+						{AppID: "123", EventType: "payment.created", Data: []byte(`{"id":1}`), ProviderID: "evt_1"},
+						{AppID: "123", EventType: "payment.created"},
+						{AppID: "456", EventType: "payment.created", Data: []byte(`{"id":2}`)},
+						{AppID: "123", EventType: "payment.created", Data: []byte(`{"id":1}`), ProviderID: "evt_1"},
+						{AppID: "123", EventType: "payment.created", Data: []byte(`{"id":3}`), ProviderID: "evt_2"},
+						{AppID: "123", EventType: "payment.created", Data: []byte(`{"id":4}`)},
+						{AppID: "123", EventType: "payment.created", Data: []byte(`{"id":5}`), ProviderID: "evt_3"},
+					},
+				},
+				g: group,
+			},
+			wantStatus: []int{
+				http.StatusCreated,
+				http.StatusBadRequest,
+				http.StatusNotFound,
+				http.StatusOK,
+				http.StatusOK,
+				http.StatusCreated,
+				http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "should_error_for_empty_batch",
+			args: args{
+				ctx:   ctx,
+				batch: &models.BatchEvent{},
+				g:     group,
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "please provide at least one event",
+		},
+		{
+			name: "should_error_for_batch_too_large",
+			args: args{
+				ctx:   ctx,
+				batch: &models.BatchEvent{Events: make([]models.Event, MaxBatchEventSize+1)},
+				g:     group,
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "a batch can't have more than 1000 events",
+		},
+		{
+			name: "should_error_for_nil_group",
+			args: args{
+				ctx:   ctx,
+				batch: &models.BatchEvent{Events: make([]models.Event, 1)},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "an error occurred while creating events - invalid group",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			results, err := es.CreateAppEvents(tc.args.ctx, tc.args.batch, tc.args.g)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Len(t, results, len(tc.wantStatus))
+
+			for i, result := range results {
+				require.Equal(t, i, result.Index)
+				require.Equal(t, tc.wantStatus[i], result.Status, result.Error)
+			}
+		})
+	}
+}
+
 func TestEventService_GetAppEvent(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -590,6 +902,106 @@ func TestEventService_GetEventDelivery(t *testing.T) {
 	}
 }
 
+func TestEventService_LoadOrderingQueues(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx            context.Context
+		g              *datastore.Group
+		subscriptionID string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		dbFn        func(es *EventService)
+		wantQueues  []datastore.OrderingQueue
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_load_ordering_queues",
+			args: args{
+				ctx:            ctx,
+				g:              &datastore.Group{UID: "abc"},
+				subscriptionID: "sub-1",
+			},
+			dbFn: func(es *EventService) {
+				s, _ := es.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "abc", "sub-1").
+					Times(1).Return(&datastore.Subscription{UID: "sub-1"}, nil)
+
+				e, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				e.EXPECT().LoadOrderingQueues(gomock.Any(), "sub-1").
+					Times(1).Return([]datastore.OrderingQueue{
+					{OrderingKey: "sub-1:cus_1", HeadID: "ed-1", HeadStatus: datastore.RetryEventStatus, Blocked: 2},
+				}, nil)
+			},
+			wantQueues: []datastore.OrderingQueue{
+				{OrderingKey: "sub-1:cus_1", HeadID: "ed-1", HeadStatus: datastore.RetryEventStatus, Blocked: 2},
+			},
+		},
+		{
+			name: "should_fail_to_find_subscription",
+			args: args{
+				ctx:            ctx,
+				g:              &datastore.Group{UID: "abc"},
+				subscriptionID: "sub-1",
+			},
+			dbFn: func(es *EventService) {
+				s, _ := es.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "abc", "sub-1").
+					Times(1).Return(nil, datastore.ErrSubscriptionNotFound)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusNotFound,
+			wantErrMsg:  ErrSubscriptionNotFound.Error(),
+		},
+		{
+			name: "should_fail_to_load_ordering_queues",
+			args: args{
+				ctx:            ctx,
+				g:              &datastore.Group{UID: "abc"},
+				subscriptionID: "sub-1",
+			},
+			dbFn: func(es *EventService) {
+				s, _ := es.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "abc", "sub-1").
+					Times(1).Return(&datastore.Subscription{UID: "sub-1"}, nil)
+
+				e, _ := es.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				e.EXPECT().LoadOrderingQueues(gomock.Any(), "sub-1").
+					Times(1).Return(nil, errors.New("failed"))
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusInternalServerError,
+			wantErrMsg:  "failed to load ordering queues",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			es := provideEventService(ctrl)
+
+			if tc.dbFn != nil {
+				tc.dbFn(es)
+			}
+
+			queues, err := es.LoadOrderingQueues(tc.args.ctx, tc.args.g, tc.args.subscriptionID)
+			if tc.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tc.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tc.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.Equal(t, tc.wantQueues, queues)
+		})
+	}
+}
+
 func TestEventService_BatchRetryEventDelivery(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -12,6 +12,7 @@ import (
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/signing"
 	"github.com/frain-dev/convoy/limiter"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
@@ -92,6 +93,12 @@ func (gs *GroupService) CreateGroup(ctx context.Context, newGroup *models.Group,
 		DocumentStatus:    datastore.ActiveDocumentStatus,
 	}
 
+	err = addSigningKey(group)
+	if err != nil {
+		log.WithError(err).Error("failed to generate group signing key")
+		return nil, nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to generate signing key"))
+	}
+
 	err = gs.groupRepo.CreateGroup(ctx, group)
 	if err != nil {
 		log.WithError(err).Error("failed to create group")
@@ -145,9 +152,16 @@ func (gs *GroupService) UpdateGroup(ctx context.Context, group *datastore.Group,
 	}
 
 	if update.Config != nil {
+		keepMaskedProxyPassword(update.Config, group.Config)
 		group.Config = update.Config
 	}
 
+	err = addSigningKey(group)
+	if err != nil {
+		log.WithError(err).Error("failed to generate group signing key")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to generate signing key"))
+	}
+
 	if !util.IsStringEmpty(update.LogoURL) {
 		group.LogoURL = update.LogoURL
 	}
@@ -167,6 +181,62 @@ func (gs *GroupService) UpdateGroup(ctx context.Context, group *datastore.Group,
 	return group, nil
 }
 
+// keepMaskedProxyPassword replaces a masked proxy password sent back in an
+// update with the stored one.
+func keepMaskedProxyPassword(cfg, stored *datastore.GroupConfig) {
+	if cfg.Proxy == nil || stored == nil || stored.Proxy == nil {
+		return
+	}
+
+	if cfg.Proxy.Password == datastore.MaskedSecret {
+		cfg.Proxy.Password = stored.Proxy.Password
+	}
+}
+
+// addSigningKey generates a key pair when the group signs webhooks with an
+// algorithm it has no key for, keys of other algorithms are kept so
+// endpoints can still find them in the group's jwks.
+func addSigningKey(group *datastore.Group) error {
+	if group.Config == nil || group.Config.Signature == nil || group.Config.Signature.Asymmetric == nil {
+		return nil
+	}
+
+	alg := group.Config.Signature.Asymmetric.Algorithm
+	if group.SigningKey(alg) != nil {
+		return nil
+	}
+
+	key, err := signing.GenerateKey(alg)
+	if err != nil {
+		return err
+	}
+
+	group.SigningKeys = append(group.SigningKeys, *key)
+	return nil
+}
+
+// GetJSONWebKeySet returns the public keys endpoints use
+// to verify the group's asymmetric webhook signatures.
+func (gs *GroupService) GetJSONWebKeySet(ctx context.Context, groupID string) (*signing.JSONWebKeySet, error) {
+	group, err := gs.groupRepo.FetchGroupByID(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, datastore.ErrGroupNotFound) {
+			return nil, util.NewServiceError(http.StatusNotFound, err)
+		}
+
+		log.WithError(err).Error("failed to fetch group")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to fetch group"))
+	}
+
+	jwks, err := signing.NewJSONWebKeySet(group.SigningKeys)
+	if err != nil {
+		log.WithError(err).Error("failed to build group jwks")
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("failed to fetch group keys"))
+	}
+
+	return jwks, nil
+}
+
 func (gs *GroupService) GetGroups(ctx context.Context, filter *datastore.GroupFilter) ([]*datastore.Group, error) {
 	groups, err := gs.groupRepo.LoadGroups(ctx, filter.WithNamesTrimmed())
 	if err != nil {
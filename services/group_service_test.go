@@ -9,6 +9,7 @@ import (
 
 	"github.com/frain-dev/convoy/auth"
 	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/signing"
 	nooplimiter "github.com/frain-dev/convoy/limiter/noop"
 	"github.com/frain-dev/convoy/mocks"
 	"github.com/frain-dev/convoy/server/models"
@@ -546,6 +547,114 @@ func TestGroupService_UpdateGroup(t *testing.T) {
 			wantErrCode: http.StatusBadRequest,
 			wantErrMsg:  "name:please provide a valid name",
 		},
+		{
+			name: "should_error_for_unsupported_jitter",
+			args: args{
+				ctx:   ctx,
+				group: &datastore.Group{UID: "12345"},
+				update: &models.UpdateGroup{
+					Name:    "test_group",
+					LogoURL: "https://google.com",
+					Config: &datastore.GroupConfig{
+						Signature: &datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+						},
+						Strategy: &datastore.StrategyConfiguration{
+							Type:        "exponential",
+							Duration:    20,
+							RetryCount:  4,
+							Multiplier:  2,
+							MaxInterval: 600,
+							Jitter:      "random",
+						},
+						DisableEndpoint: true,
+						ReplayAttacks:   true,
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "jitter:unsupported jitter mode",
+		},
+		{
+			name: "should_error_for_unsupported_signature_scheme",
+			args: args{
+				ctx:   ctx,
+				group: &datastore.Group{UID: "12345"},
+				update: &models.UpdateGroup{
+					Name:    "test_group",
+					LogoURL: "https://google.com",
+					Config: &datastore.GroupConfig{
+						Signature: &datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+							Scheme: "svix",
+						},
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       "linear",
+							Duration:   20,
+							RetryCount: 4,
+						},
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "scheme:unsupported signature scheme",
+		},
+		{
+			name: "should_error_for_unsupported_proxy_url",
+			args: args{
+				ctx:   ctx,
+				group: &datastore.Group{UID: "12345"},
+				update: &models.UpdateGroup{
+					Name:    "test_group",
+					LogoURL: "https://google.com",
+					Config: &datastore.GroupConfig{
+						Signature: &datastore.SignatureConfiguration{
+							Header: "X-Convoy-Signature",
+							Hash:   "SHA256",
+						},
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       "linear",
+							Duration:   20,
+							RetryCount: 4,
+						},
+						Proxy: &datastore.ProxyConfiguration{URL: "ftp://proxy.convoy.test:21"},
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "url:unsupported proxy url scheme",
+		},
+		{
+			name: "should_error_for_unsupported_signing_algorithm",
+			args: args{
+				ctx:   ctx,
+				group: &datastore.Group{UID: "12345"},
+				update: &models.UpdateGroup{
+					Name:    "test_group",
+					LogoURL: "https://google.com",
+					Config: &datastore.GroupConfig{
+						Signature: &datastore.SignatureConfiguration{
+							Header:     "X-Convoy-Signature",
+							Hash:       "SHA256",
+							Asymmetric: &datastore.AsymmetricSignatureConfiguration{Algorithm: "HS256"},
+						},
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       "linear",
+							Duration:   20,
+							RetryCount: 4,
+						},
+					},
+				},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "algorithm:unsupported signing algorithm",
+		},
 		{
 			name: "should_fail_to_update_group",
 			args: args{
@@ -609,6 +718,111 @@ func TestGroupService_UpdateGroup(t *testing.T) {
 	}
 }
 
+func TestGroupService_UpdateGroup_GeneratesSigningKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gs := provideGroupService(ctrl)
+
+	a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+	a.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	c, _ := gs.cache.(*mocks.MockCache)
+	c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+
+	group := &datastore.Group{UID: "12345", Name: "test_group"}
+	update := &models.UpdateGroup{
+		Name: "test_group",
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.SignatureConfiguration{
+				Header:     "X-Convoy-Signature",
+				Hash:       "SHA256",
+				Asymmetric: &datastore.AsymmetricSignatureConfiguration{Algorithm: datastore.Ed25519SigningAlgorithm},
+			},
+			Strategy: &datastore.StrategyConfiguration{
+				Type:       "linear",
+				Duration:   20,
+				RetryCount: 4,
+			},
+		},
+	}
+
+	group, err := gs.UpdateGroup(context.Background(), group, update)
+	require.NoError(t, err)
+	require.Len(t, group.SigningKeys, 1)
+
+	key := group.SigningKey(datastore.Ed25519SigningAlgorithm)
+	require.NotNil(t, key)
+	require.NotEmpty(t, key.PrivateKey)
+
+	// updating the group again keeps its key
+	group, err = gs.UpdateGroup(context.Background(), group, update)
+	require.NoError(t, err)
+	require.Len(t, group.SigningKeys, 1)
+	require.Equal(t, key.UID, group.SigningKeys[0].UID)
+}
+
+func TestGroupService_UpdateGroup_KeepsMaskedProxyPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gs := provideGroupService(ctrl)
+
+	a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+	a.EXPECT().UpdateGroup(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+	c, _ := gs.cache.(*mocks.MockCache)
+	c.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1)
+
+	group := &datastore.Group{
+		UID:  "12345",
+		Name: "test_group",
+		Config: &datastore.GroupConfig{
+			Proxy: &datastore.ProxyConfiguration{URL: "http://proxy.convoy.test:3128", Username: "convoy", Password: "password"},
+		},
+	}
+	update := &models.UpdateGroup{
+		Name: "test_group",
+		Config: &datastore.GroupConfig{
+			Signature: &datastore.SignatureConfiguration{
+				Header: "X-Convoy-Signature",
+				Hash:   "SHA256",
+			},
+			Strategy: &datastore.StrategyConfiguration{
+				Type:       "linear",
+				Duration:   20,
+				RetryCount: 4,
+			},
+			Proxy: &datastore.ProxyConfiguration{URL: "socks5://proxy.convoy.test:1080", Username: "convoy", Password: datastore.MaskedSecret},
+		},
+	}
+
+	group, err := gs.UpdateGroup(context.Background(), group, update)
+	require.NoError(t, err)
+	require.Equal(t, &datastore.ProxyConfiguration{URL: "socks5://proxy.convoy.test:1080", Username: "convoy", Password: "password"}, group.Config.Proxy)
+}
+
+func TestGroupService_GetJSONWebKeySet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gs := provideGroupService(ctrl)
+
+	key, err := signing.GenerateKey(datastore.Ed25519SigningAlgorithm)
+	require.NoError(t, err)
+
+	a, _ := gs.groupRepo.(*mocks.MockGroupRepository)
+	a.EXPECT().FetchGroupByID(gomock.Any(), "12345").Times(1).
+		Return(&datastore.Group{UID: "12345", SigningKeys: []datastore.SigningKey{*key}}, nil)
+	a.EXPECT().FetchGroupByID(gomock.Any(), "abc").Times(1).Return(nil, datastore.ErrGroupNotFound)
+
+	jwks, err := gs.GetJSONWebKeySet(context.Background(), "12345")
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, 1)
+	require.Equal(t, key.UID, jwks.Keys[0].KeyID)
+
+	_, err = gs.GetJSONWebKeySet(context.Background(), "abc")
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, err.(*util.ServiceError).ErrCode())
+}
+
 func TestGroupService_GetGroups(t *testing.T) {
 	ctx := context.Background()
 	type args struct {
@@ -8,7 +8,10 @@ import (
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
+	convoyNet "github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/verifier"
 	"github.com/frain-dev/convoy/server/models"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
@@ -17,6 +20,8 @@ import (
 	"github.com/dchest/uniuri"
 )
 
+var ErrProviderSecretRequired = errors.New("Invalid verifier config, provider sources need an hmac secret")
+
 type SourceService struct {
 	sourceRepo datastore.SourceRepository
 	cache      cache.Cache
@@ -31,6 +36,14 @@ func (s *SourceService) CreateSource(ctx context.Context, newSource *models.Sour
 		return nil, util.NewServiceError(http.StatusBadRequest, err)
 	}
 
+	if !util.IsStringEmpty(string(newSource.Provider)) && !verifier.IsRegistered(string(newSource.Provider)) {
+		return nil, util.NewServiceError(http.StatusBadRequest, verifier.ErrProviderNotFound)
+	}
+
+	if err := validateProviderSecret(datastore.SourceProvider(newSource.Provider), &newSource.Verifier); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
 	if newSource.Verifier.Type == datastore.HMacVerifier && newSource.Verifier.HMac == nil {
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("Invalid verifier config for hmac"))
 	}
@@ -43,17 +56,37 @@ func (s *SourceService) CreateSource(ctx context.Context, newSource *models.Sour
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("Invalid verifier config for basic auth"))
 	}
 
+	if newSource.Type == datastore.PubSubSource {
+		if err := validatePubSubConfig(newSource.PubSub); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
+	if newSource.Type == datastore.DBChangeStream && newSource.ChangeStream == nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("Invalid change stream config"))
+	}
+
+	if newSource.Type == datastore.RestApiSource {
+		if err := validateRestApiConfig(ctx, newSource.RestApi); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
 	source := &datastore.Source{
-		UID:            uuid.New().String(),
-		GroupID:        g.UID,
-		MaskID:         uniuri.NewLen(16),
-		Name:           newSource.Name,
-		Type:           newSource.Type,
-		Provider:       datastore.SourceProvider(newSource.Provider),
-		Verifier:       &newSource.Verifier,
-		CreatedAt:      primitive.NewDateTimeFromTime(time.Now()),
-		UpdatedAt:      primitive.NewDateTimeFromTime(time.Now()),
-		DocumentStatus: datastore.ActiveDocumentStatus,
+		UID:               uuid.New().String(),
+		GroupID:           g.UID,
+		MaskID:            uniuri.NewLen(16),
+		Name:              newSource.Name,
+		Type:              newSource.Type,
+		Provider:          datastore.SourceProvider(newSource.Provider),
+		Verifier:          &newSource.Verifier,
+		IdempotencyConfig: newSource.IdempotencyConfig,
+		PubSub:            newSource.PubSub,
+		ChangeStream:      newSource.ChangeStream,
+		RestApi:           newSource.RestApi,
+		CreatedAt:         primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:         primitive.NewDateTimeFromTime(time.Now()),
+		DocumentStatus:    datastore.ActiveDocumentStatus,
 	}
 
 	if source.Provider == datastore.TwitterSourceProvider {
@@ -93,10 +126,56 @@ func (s *SourceService) UpdateSource(ctx context.Context, g *datastore.Group, so
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("Invalid verifier config for basic auth"))
 	}
 
+	if err := validateProviderSecret(source.Provider, &sourceUpdate.Verifier); err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
 	if sourceUpdate.ForwardHeaders != nil {
 		source.ForwardHeaders = sourceUpdate.ForwardHeaders
 	}
 
+	if sourceUpdate.IdempotencyConfig != nil {
+		source.IdempotencyConfig = sourceUpdate.IdempotencyConfig
+	}
+
+	keepMaskedSourceSecrets(sourceUpdate, source)
+
+	if sourceUpdate.PubSub != nil {
+		source.PubSub = sourceUpdate.PubSub
+	}
+
+	if source.Type == datastore.PubSubSource {
+		if err := validatePubSubConfig(source.PubSub); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
+	if sourceUpdate.ChangeStream != nil {
+		// the resume token is a position in the old stream.
+		if source.ChangeStream == nil || *source.ChangeStream != *sourceUpdate.ChangeStream {
+			source.ResumeToken = ""
+		}
+		source.ChangeStream = sourceUpdate.ChangeStream
+	}
+
+	if source.Type == datastore.DBChangeStream && source.ChangeStream == nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("Invalid change stream config"))
+	}
+
+	if sourceUpdate.RestApi != nil {
+		// the cursor is a position in the old api.
+		if source.RestApi == nil || source.RestApi.URL != sourceUpdate.RestApi.URL {
+			source.RestApiCursor = nil
+		}
+		source.RestApi = sourceUpdate.RestApi
+	}
+
+	if source.Type == datastore.RestApiSource {
+		if err := validateRestApiConfig(ctx, source.RestApi); err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
 	err := s.sourceRepo.UpdateSource(ctx, g.UID, source)
 	if err != nil {
 		return nil, util.NewServiceError(http.StatusBadRequest, errors.New("an error occurred while updating source"))
@@ -114,6 +193,46 @@ func (s *SourceService) UpdateSource(ctx context.Context, g *datastore.Group, so
 	return source, nil
 }
 
+// keepMaskedSourceSecrets replaces masked dsns and rest api headers sent
+// back in an update with the stored ones.
+func keepMaskedSourceSecrets(update *models.UpdateSource, stored *datastore.Source) {
+	if update.PubSub != nil && stored.PubSub != nil {
+		if update.PubSub.RedisStreams != nil && stored.PubSub.RedisStreams != nil && update.PubSub.RedisStreams.DSN == datastore.MaskedSecret {
+			update.PubSub.RedisStreams.DSN = stored.PubSub.RedisStreams.DSN
+		}
+
+		if update.PubSub.AMQP != nil && stored.PubSub.AMQP != nil && update.PubSub.AMQP.DSN == datastore.MaskedSecret {
+			update.PubSub.AMQP.DSN = stored.PubSub.AMQP.DSN
+		}
+	}
+
+	if update.ChangeStream != nil && stored.ChangeStream != nil && update.ChangeStream.DSN == datastore.MaskedSecret {
+		update.ChangeStream.DSN = stored.ChangeStream.DSN
+	}
+
+	if update.RestApi != nil && stored.RestApi != nil {
+		for k, v := range update.RestApi.Headers {
+			if v == datastore.MaskedSecret {
+				update.RestApi.Headers[k] = stored.RestApi.Headers[k]
+			}
+		}
+	}
+}
+
+// validateProviderSecret checks that a provider source has the secret its
+// requests are verified with, without it anyone could sign a request.
+func validateProviderSecret(provider datastore.SourceProvider, v *datastore.VerifierConfig) error {
+	if util.IsStringEmpty(string(provider)) {
+		return nil
+	}
+
+	if v.HMac == nil || util.IsStringEmpty(v.HMac.Secret) {
+		return ErrProviderSecretRequired
+	}
+
+	return nil
+}
+
 func (s *SourceService) FindSourceByID(ctx context.Context, g *datastore.Group, id string) (*datastore.Source, error) {
 	source, err := s.sourceRepo.FindSourceByID(ctx, g.UID, id)
 
@@ -168,3 +287,43 @@ func (s *SourceService) DeleteSource(ctx context.Context, g *datastore.Group, so
 
 	return nil
 }
+
+func validatePubSubConfig(cfg *datastore.PubSubConfig) error {
+	if cfg == nil {
+		return errors.New("Invalid pub sub config")
+	}
+
+	if cfg.Type == datastore.RedisStreamsPubSub && cfg.RedisStreams == nil {
+		return errors.New("Invalid pub sub config for redis streams")
+	}
+
+	if cfg.Type == datastore.AMQPPubSub && cfg.AMQP == nil {
+		return errors.New("Invalid pub sub config for amqp")
+	}
+
+	return nil
+}
+
+func validateRestApiConfig(ctx context.Context, cfg *datastore.RestApiConfig) error {
+	if cfg == nil {
+		return errors.New("Invalid rest api config")
+	}
+
+	if _, err := cfg.NextPollAt(time.Now()); err != nil {
+		return errors.New("Invalid rest api schedule")
+	}
+
+	// the api is polled by the workers, it is held to the egress policy
+	// deliveries are.
+	c, err := config.Get()
+	if err != nil {
+		return err
+	}
+
+	policy, err := convoyNet.NewEgressPolicy(c.Egress)
+	if err != nil {
+		return err
+	}
+
+	return policy.CheckURL(ctx, cfg.URL)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -22,6 +23,7 @@ var (
 	ErrDeletedSubscriptionError     = errors.New("failed to delete subscription")
 	ErrValidateSubscriptionError    = errors.New("failed to validate group update")
 	ErrCannotFetchSubcriptionsError = errors.New("an error occurred while fetching subscriptions")
+	ErrTransformConfigAndRemove     = errors.New("transform_config can't be set with remove_transform")
 )
 
 type SubcriptionService struct {
@@ -56,6 +58,29 @@ func (s *SubcriptionService) CreateSubscription(ctx context.Context, group *data
 		return nil, util.NewServiceError(http.StatusBadRequest, err)
 	}
 
+	if newSubscription.FilterConfig != nil {
+		_, _, err = newSubscription.FilterConfig.Filter.Filters()
+		if err != nil {
+			log.WithError(err).Error("failed to validate filter config")
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
+	if newSubscription.RetryConfig != nil {
+		err = newSubscription.RetryConfig.ValidateStatusCodes()
+		if err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
+	if newSubscription.TransformConfig != nil {
+		_, err = newSubscription.TransformConfig.Transformer()
+		if err != nil {
+			log.WithError(err).Error("failed to validate transform config")
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+	}
+
 	if group.Type == datastore.IncomingGroup {
 		_, err = s.sourceRepo.FindSourceByID(ctx, group.UID, newSubscription.SourceID)
 		if err != nil {
@@ -73,9 +98,13 @@ func (s *SubcriptionService) CreateSubscription(ctx context.Context, group *data
 		SourceID:   newSubscription.SourceID,
 		EndpointID: newSubscription.EndpointID,
 
-		RetryConfig:  newSubscription.RetryConfig,
-		AlertConfig:  newSubscription.AlertConfig,
-		FilterConfig: newSubscription.FilterConfig,
+		DeliveryMode: newSubscription.DeliveryMode,
+		OrderingKey:  newSubscription.OrderingKey,
+
+		RetryConfig:     newSubscription.RetryConfig,
+		AlertConfig:     newSubscription.AlertConfig,
+		FilterConfig:    newSubscription.FilterConfig,
+		TransformConfig: newSubscription.TransformConfig,
 
 		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
 		UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
@@ -84,18 +113,16 @@ func (s *SubcriptionService) CreateSubscription(ctx context.Context, group *data
 		DocumentStatus: datastore.ActiveDocumentStatus,
 	}
 
-	if subscription.FilterConfig == nil ||
-		subscription.FilterConfig.EventTypes == nil ||
-		len(subscription.FilterConfig.EventTypes) == 0 {
-		subscription.FilterConfig = &datastore.FilterConfiguration{EventTypes: []string{"*"}}
+	if subscription.FilterConfig == nil {
+		subscription.FilterConfig = &datastore.FilterConfiguration{}
 	}
 
-	if subscription.AlertConfig == nil {
-		subscription.AlertConfig = &datastore.DefaultAlertConfig
+	if len(subscription.FilterConfig.EventTypes) == 0 {
+		subscription.FilterConfig.EventTypes = []string{"*"}
 	}
 
-	if subscription.RetryConfig == nil {
-		subscription.RetryConfig = &datastore.DefaultRetryConfig
+	if subscription.AlertConfig == nil {
+		subscription.AlertConfig = &datastore.DefaultAlertConfig
 	}
 
 	err = s.subRepo.CreateSubscription(ctx, group.UID, subscription)
@@ -140,6 +167,14 @@ func (s *SubcriptionService) UpdateSubscription(ctx context.Context, groupId str
 		subscription.EndpointID = update.EndpointID
 	}
 
+	if !util.IsStringEmpty(string(update.DeliveryMode)) {
+		subscription.DeliveryMode = update.DeliveryMode
+	}
+
+	if !util.IsStringEmpty(update.OrderingKey) {
+		subscription.OrderingKey = update.OrderingKey
+	}
+
 	if update.AlertConfig != nil && update.AlertConfig.Count > 0 {
 		subscription.AlertConfig.Count = update.AlertConfig.Count
 	}
@@ -148,6 +183,10 @@ func (s *SubcriptionService) UpdateSubscription(ctx context.Context, groupId str
 		subscription.AlertConfig.Threshold = update.AlertConfig.Threshold
 	}
 
+	if update.RetryConfig != nil && subscription.RetryConfig == nil {
+		subscription.RetryConfig = &datastore.RetryConfiguration{}
+	}
+
 	if update.RetryConfig != nil && !util.IsStringEmpty(string(update.RetryConfig.Type)) {
 		subscription.RetryConfig.Type = update.RetryConfig.Type
 	}
@@ -160,10 +199,65 @@ func (s *SubcriptionService) UpdateSubscription(ctx context.Context, groupId str
 		subscription.RetryConfig.RetryCount = update.RetryConfig.RetryCount
 	}
 
+	if update.RetryConfig != nil && update.RetryConfig.Multiplier > 0 {
+		subscription.RetryConfig.Multiplier = update.RetryConfig.Multiplier
+	}
+
+	if update.RetryConfig != nil && !util.IsStringEmpty(update.RetryConfig.MaxInterval) {
+		subscription.RetryConfig.MaxInterval = update.RetryConfig.MaxInterval
+	}
+
+	if update.RetryConfig != nil && !util.IsStringEmpty(string(update.RetryConfig.Jitter)) {
+		subscription.RetryConfig.Jitter = update.RetryConfig.Jitter
+	}
+
+	if update.RetryConfig != nil && update.RetryConfig.NonRetryableStatusCodes != nil {
+		err = update.RetryConfig.ValidateStatusCodes()
+		if err != nil {
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+
+		subscription.RetryConfig.NonRetryableStatusCodes = update.RetryConfig.NonRetryableStatusCodes
+	}
+
 	if update.FilterConfig != nil && len(update.FilterConfig.EventTypes) > 0 {
 		subscription.FilterConfig.EventTypes = update.FilterConfig.EventTypes
 	}
 
+	if update.FilterConfig != nil {
+		_, _, err = update.FilterConfig.Filter.Filters()
+		if err != nil {
+			log.WithError(err).Error("failed to validate filter config")
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+
+		if update.FilterConfig.Filter.Headers != nil {
+			subscription.FilterConfig.Filter.Headers = update.FilterConfig.Filter.Headers
+		}
+
+		if update.FilterConfig.Filter.Body != nil {
+			subscription.FilterConfig.Filter.Body = update.FilterConfig.Filter.Body
+		}
+	}
+
+	if update.RemoveTransform && update.TransformConfig != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, ErrTransformConfigAndRemove)
+	}
+
+	if update.RemoveTransform {
+		subscription.TransformConfig = nil
+	}
+
+	if update.TransformConfig != nil {
+		_, err = update.TransformConfig.Transformer()
+		if err != nil {
+			log.WithError(err).Error("failed to validate transform config")
+			return nil, util.NewServiceError(http.StatusBadRequest, err)
+		}
+
+		subscription.TransformConfig = update.TransformConfig
+	}
+
 	err = s.subRepo.UpdateSubscription(ctx, groupId, subscription)
 	if err != nil {
 		log.WithError(err).Error(ErrUpateSubscriptionError.Error())
@@ -173,6 +267,41 @@ func (s *SubcriptionService) UpdateSubscription(ctx context.Context, groupId str
 	return subscription, nil
 }
 
+func (s *SubcriptionService) TestSubscriptionTransform(ctx context.Context, groupId string, subscriptionId string, test *models.TestTransform) (json.RawMessage, error) {
+	if err := util.Validate(test); err != nil {
+		log.WithError(err).Error("failed to validate transform test")
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	subscription, err := s.subRepo.FindSubscriptionByID(ctx, groupId, subscriptionId)
+	if err != nil {
+		log.WithError(err).Error(ErrSubscriptionNotFound.Error())
+		return nil, util.NewServiceError(http.StatusNotFound, ErrSubscriptionNotFound)
+	}
+
+	transformConfig := subscription.TransformConfig
+	if test.TransformConfig != nil {
+		transformConfig = test.TransformConfig
+	}
+
+	// subscriptions without a transformation send the payload as is
+	if transformConfig == nil {
+		return test.Payload, nil
+	}
+
+	transformer, err := transformConfig.Transformer()
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	payload, err := transformer.Transform(test.Payload)
+	if err != nil {
+		return nil, util.NewServiceError(http.StatusBadRequest, err)
+	}
+
+	return payload, nil
+}
+
 func (s *SubcriptionService) ToggleSubscriptionStatus(ctx context.Context, groupId string, subscriptionId string) (*datastore.Subscription, error) {
 	subscription, err := s.subRepo.FindSubscriptionByID(ctx, groupId, subscriptionId)
 	if err != nil {
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"testing"
@@ -77,6 +78,93 @@ func TestSubscription_CreateSubscription(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "should fail to create subscription with invalid filter",
+			args: args{
+				ctx: ctx,
+				newSubscription: &models.Subscription{
+					Name:       "sub 1",
+					AppID:      "app-id-1",
+					SourceID:   "source-id-1",
+					EndpointID: "endpoint-id-1",
+					FilterConfig: &datastore.FilterConfiguration{
+						EventTypes: []string{"invoice.*"},
+						Filter: datastore.FilterSchema{
+							Body: map[string]interface{}{"data.amount": map[string]interface{}{"$in": 1000}},
+						},
+					},
+				},
+				group: &datastore.Group{UID: "12345", Type: datastore.OutgoingGroup},
+			},
+			dbFn: func(ss *SubcriptionService) {
+				a, _ := ss.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-id-1").
+					Times(1).Return(
+					&datastore.Application{
+						GroupID: "12345",
+						Endpoints: []datastore.Endpoint{
+							{UID: "endpoint-id-1"},
+						},
+					},
+					nil,
+				)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "invalid filter: $in on data.amount must be an array",
+		},
+		{
+			name: "should fail to create subscription with invalid non-retryable status codes",
+			args: args{
+				ctx: ctx,
+				newSubscription: &models.Subscription{
+					Name:       "sub 1",
+					AppID:      "app-id-1",
+					SourceID:   "source-id-1",
+					EndpointID: "endpoint-id-1",
+					RetryConfig: &datastore.RetryConfiguration{
+						Type:                    "linear",
+						Duration:                "10s",
+						RetryCount:              3,
+						NonRetryableStatusCodes: []int{400, 200},
+					},
+				},
+				group: &datastore.Group{UID: "12345", Type: datastore.OutgoingGroup},
+			},
+			dbFn: func(ss *SubcriptionService) {
+				a, _ := ss.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-id-1").
+					Times(1).Return(
+					&datastore.Application{
+						GroupID: "12345",
+						Endpoints: []datastore.Endpoint{
+							{UID: "endpoint-id-1"},
+						},
+					},
+					nil,
+				)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "please provide valid non-retryable status codes",
+		},
+		{
+			name: "should_error_for_unsupported_delivery_mode",
+			args: args{
+				ctx: ctx,
+				newSubscription: &models.Subscription{
+					Name:         "sub 1",
+					AppID:        "app-id-1",
+					SourceID:     "source-id-1",
+					EndpointID:   "endpoint-id-1",
+					DeliveryMode: "fifo",
+				},
+				group: &datastore.Group{UID: "12345", Type: datastore.OutgoingGroup},
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "delivery_mode:please provide a valid delivery mode",
+		},
 		{
 			name: "should create subscription for incoming group",
 			args: args{
@@ -427,6 +515,57 @@ func TestSubscription_UpdateSubscription(t *testing.T) {
 					Return(nil)
 			},
 		},
+		{
+			name: "should remove subscription transform",
+			args: args{
+				ctx:    ctx,
+				update: &models.UpdateSubscription{RemoveTransform: true},
+				group:  &datastore.Group{UID: "12345"},
+			},
+			wantSubscription: &datastore.Subscription{
+				Name: "sub 1",
+				Type: datastore.SubscriptionTypeAPI,
+			},
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{
+					UID:  "sub-uid-1",
+					Name: "sub 1",
+					Type: datastore.SubscriptionTypeAPI,
+					TransformConfig: &datastore.TransformConfiguration{
+						Type:     datastore.TemplateTransform,
+						Template: `{"type": {{ json .event }}}`,
+					},
+				}, nil)
+
+				s.EXPECT().UpdateSubscription(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+		},
+		{
+			name: "should fail to set and remove subscription transform",
+			args: args{
+				ctx: ctx,
+				update: &models.UpdateSubscription{
+					RemoveTransform: true,
+					TransformConfig: &datastore.TransformConfiguration{
+						Type:     datastore.TemplateTransform,
+						Template: `{"type": {{ json .event }}}`,
+					},
+				},
+				group: &datastore.Group{UID: "12345"},
+			},
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(1).Return(&datastore.Subscription{UID: "sub-uid-1"}, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  ErrTransformConfigAndRemove.Error(),
+		},
 		{
 			name: "should fail to update subscription",
 			args: args{
@@ -479,6 +618,7 @@ func TestSubscription_UpdateSubscription(t *testing.T) {
 
 			require.Equal(t, subscription.Name, tc.wantSubscription.Name)
 			require.Equal(t, subscription.Type, tc.wantSubscription.Type)
+			require.Equal(t, subscription.TransformConfig, tc.wantSubscription.TransformConfig)
 		})
 	}
 }
@@ -940,3 +1080,150 @@ func TestSubcriptionService_ToggleSubscriptionStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestSubcriptionService_TestSubscriptionTransform(t *testing.T) {
+	ctx := context.Background()
+
+	type args struct {
+		ctx            context.Context
+		groupId        string
+		subscriptionId string
+		test           *models.TestTransform
+	}
+	tests := []struct {
+		name        string
+		args        args
+		dbFn        func(ss *SubcriptionService)
+		want        string
+		wantErr     bool
+		wantErrCode int
+		wantErrMsg  string
+	}{
+		{
+			name: "should_transform_payload_with_saved_config",
+			args: args{
+				ctx:            ctx,
+				groupId:        "1234",
+				subscriptionId: "abc",
+				test: &models.TestTransform{
+					Payload: json.RawMessage(`{"event": "invoice.paid", "data": {"amount": 2000}}`),
+				},
+			},
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(&datastore.Subscription{
+					UID: "abc",
+					TransformConfig: &datastore.TransformConfiguration{
+						Type:    datastore.MappingTransform,
+						Mapping: []datastore.FieldMapping{{From: "data.amount", To: "total"}},
+					},
+				}, nil)
+			},
+			want: `{"total": 2000}`,
+		},
+		{
+			name: "should_transform_payload_with_config_override",
+			args: args{
+				ctx:            ctx,
+				groupId:        "1234",
+				subscriptionId: "abc",
+				test: &models.TestTransform{
+					Payload: json.RawMessage(`{"event": "invoice.paid"}`),
+					TransformConfig: &datastore.TransformConfiguration{
+						Type:     datastore.TemplateTransform,
+						Template: `{"text": "{{ .event }}"}`,
+					},
+				},
+			},
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(&datastore.Subscription{UID: "abc"}, nil)
+			},
+			want: `{"text": "invoice.paid"}`,
+		},
+		{
+			name: "should_return_payload_without_transform_config",
+			args: args{
+				ctx:            ctx,
+				groupId:        "1234",
+				subscriptionId: "abc",
+				test: &models.TestTransform{
+					Payload: json.RawMessage(`{"event": "invoice.paid"}`),
+				},
+			},
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(&datastore.Subscription{UID: "abc"}, nil)
+			},
+			want: `{"event": "invoice.paid"}`,
+		},
+		{
+			name: "should_error_for_invalid_template_output",
+			args: args{
+				ctx:            ctx,
+				groupId:        "1234",
+				subscriptionId: "abc",
+				test: &models.TestTransform{
+					Payload: json.RawMessage(`{"event": "invoice.paid"}`),
+					TransformConfig: &datastore.TransformConfiguration{
+						Type:     datastore.TemplateTransform,
+						Template: `{"text": {{ .event }}}`,
+					},
+				},
+			},
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(&datastore.Subscription{UID: "abc"}, nil)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusBadRequest,
+			wantErrMsg:  "transformed payload is not valid JSON",
+		},
+		{
+			name: "should_fail_to_find_subscription",
+			args: args{
+				ctx:            ctx,
+				groupId:        "1234",
+				subscriptionId: "abc",
+				test: &models.TestTransform{
+					Payload: json.RawMessage(`{"event": "invoice.paid"}`),
+				},
+			},
+			dbFn: func(ss *SubcriptionService) {
+				s, _ := ss.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionByID(gomock.Any(), "1234", "abc").
+					Times(1).Return(nil, datastore.ErrSubscriptionNotFound)
+			},
+			wantErr:     true,
+			wantErrCode: http.StatusNotFound,
+			wantErrMsg:  "subscription not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ss := provideSubsctiptionService(ctrl)
+
+			if tt.dbFn != nil {
+				tt.dbFn(ss)
+			}
+
+			got, err := ss.TestSubscriptionTransform(tt.args.ctx, tt.args.groupId, tt.args.subscriptionId, tt.args.test)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Equal(t, tt.wantErrCode, err.(*util.ServiceError).ErrCode())
+				require.Equal(t, tt.wantErrMsg, err.(*util.ServiceError).Error())
+				return
+			}
+
+			require.Nil(t, err)
+			require.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
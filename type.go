@@ -74,12 +74,16 @@ const (
 	IndexDocument         TaskName = "index document"
 	DailyAnalytics        TaskName = "daily analytics"
 	MonitorTwitterSources TaskName = "monitor twitter sources"
+	PollRestApiSources    TaskName = "poll rest api sources"
 	RetentionPolicies     TaskName = "retention_policies"
 	EmailProcessor        TaskName = "EmailProcessor"
 	ApplicationsCacheKey  CacheKey = "applications"
 	GroupsCacheKey        CacheKey = "groups"
 	TokenCacheKey         CacheKey = "tokens"
 	SourceCacheKey        CacheKey = "sources"
+	IdempotencyCacheKey   CacheKey = "idempotency"
+	OAuth2TokenCacheKey   CacheKey = "oauth2_tokens"
+	SourceLeasesCacheKey  CacheKey = "source_leases"
 )
 
 // queues
@@ -7,6 +7,7 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -18,6 +19,7 @@ import (
 	"github.com/dchest/uniuri"
 
 	"github.com/frain-dev/convoy/config/algo"
+	"github.com/frain-dev/convoy/datastore"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -49,12 +51,19 @@ func ComputeJSONHmac(hash, data, secret string, order bool) (string, error) {
 }
 
 type Signature struct {
+	ID          string
 	Timestamp   string
 	Hmac        string
 	EncodedData []byte
 }
 
-func GenerateSignatureHeader(replayAttacks bool, hash string, secret string, data json.RawMessage) (*Signature, error) {
+const standardWebhooksSecretPrefix = "whsec_"
+
+// GenerateSignatureHeader signs data with each of the secrets in the group's
+// signature scheme. The convoy scheme's header is the hmac itself for a single
+// secret and comma separated v1= entries while an endpoint's rotated secrets
+// are still valid, Standard Webhooks space separates its v1, entries.
+func GenerateSignatureHeader(cfg *datastore.GroupConfig, msgID string, secrets []string, data json.RawMessage) (*Signature, error) {
 	buf := bytes.NewBuffer([]byte{})
 	encoder := json.NewEncoder(buf)
 	encoder.SetEscapeHTML(false)
@@ -66,27 +75,98 @@ func GenerateSignatureHeader(replayAttacks bool, hash string, secret string, dat
 
 	trimmedBuff := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
 
+	if len(secrets) == 0 {
+		return nil, errors.New("a secret is required to sign data")
+	}
+
+	if cfg.Signature.Scheme == datastore.StandardWebhooksSignatureScheme {
+		return generateStandardWebhooksSignature(msgID, secrets, trimmedBuff)
+	}
+
 	var signedPayload strings.Builder
 	var timestamp string
-	if replayAttacks {
+	if cfg.ReplayAttacks {
 		timestamp = fmt.Sprint(time.Now().Unix())
 		signedPayload.WriteString(timestamp)
 		signedPayload.WriteString(",")
 	}
 	signedPayload.WriteString(string(trimmedBuff))
 
-	hmacStr, err := ComputeJSONHmac(hash, signedPayload.String(), secret, false)
-	if err != nil {
-		return nil, fmt.Errorf("error occurred while generating hmac: %v", err)
+	hmacs := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		hmacStr, err := ComputeJSONHmac(cfg.Signature.Hash, signedPayload.String(), secret, false)
+		if err != nil {
+			return nil, fmt.Errorf("error occurred while generating hmac: %v", err)
+		}
+
+		hmacs = append(hmacs, hmacStr)
 	}
 
-	return &Signature{
+	sig := &Signature{
+		ID:          msgID,
 		Timestamp:   timestamp,
-		Hmac:        hmacStr,
+		Hmac:        hmacs[0],
 		EncodedData: trimmedBuff,
+	}
+
+	if len(hmacs) > 1 {
+		sig.Hmac = "v1=" + strings.Join(hmacs, ",v1=")
+	}
+
+	return sig, nil
+}
+
+// generateStandardWebhooksSignature signs "id.timestamp.payload" with
+// HMAC-SHA256 as the Standard Webhooks spec requires.
+func generateStandardWebhooksSignature(msgID string, secrets []string, data []byte) (*Signature, error) {
+	if IsStringEmpty(msgID) {
+		return nil, errors.New("a message id is required to sign data")
+	}
+
+	timestamp := fmt.Sprint(time.Now().Unix())
+
+	signatures := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		key, err := standardWebhooksKey(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		signatures = append(signatures, "v1,"+signStandardWebhooks(key, msgID, timestamp, data))
+	}
+
+	return &Signature{
+		ID:          msgID,
+		Timestamp:   timestamp,
+		Hmac:        strings.Join(signatures, " "),
+		EncodedData: data,
 	}, nil
 }
 
+func signStandardWebhooks(key []byte, msgID, timestamp string, data []byte) string {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(msgID + "." + timestamp + "."))
+	h.Write(data)
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// standardWebhooksKey returns the hmac key of a secret. whsec_ secrets hold a
+// base64 encoded key, other secrets are used as is so consumers configure
+// their libraries with "whsec_" followed by the base64 encoded secret.
+func standardWebhooksKey(secret string) ([]byte, error) {
+	if !strings.HasPrefix(secret, standardWebhooksSecretPrefix) {
+		return []byte(secret), nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, standardWebhooksSecretPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid standard webhooks secret: %v", err)
+	}
+
+	return key, nil
+}
+
 func getHashFunction(algorithm string) (func() hash.Hash, error) {
 	switch algorithm {
 	case algo.MD5:
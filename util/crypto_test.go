@@ -1,6 +1,13 @@
 package util
 
-import "testing"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/stretchr/testify/require"
+)
 
 func Test_computeJSONHmac(t *testing.T) {
 	type args struct {
@@ -268,3 +275,66 @@ func Test_computeJSONHmac_DifferentHashes(t *testing.T) {
 		})
 	}
 }
+
+func Test_GenerateSignatureHeader(t *testing.T) {
+	data := json.RawMessage(`{"event":"payment.created"}`)
+	cfg := &datastore.GroupConfig{Signature: &datastore.SignatureConfiguration{Hash: "SHA256"}}
+
+	sig, err := GenerateSignatureHeader(cfg, "msg-1", []string{"new-secret"}, data)
+	require.NoError(t, err)
+
+	newHmac, err := ComputeJSONHmac("SHA256", string(data), "new-secret", false)
+	require.NoError(t, err)
+	require.Equal(t, newHmac, sig.Hmac)
+	require.Empty(t, sig.Timestamp)
+
+	oldHmac, err := ComputeJSONHmac("SHA256", string(data), "old-secret", false)
+	require.NoError(t, err)
+
+	sig, err = GenerateSignatureHeader(cfg, "msg-1", []string{"new-secret", "old-secret"}, data)
+	require.NoError(t, err)
+	require.Equal(t, "v1="+newHmac+",v1="+oldHmac, sig.Hmac)
+
+	cfg.ReplayAttacks = true
+	sig, err = GenerateSignatureHeader(cfg, "msg-1", []string{"new-secret"}, data)
+	require.NoError(t, err)
+
+	timestamped, err := ComputeJSONHmac("SHA256", sig.Timestamp+","+string(data), "new-secret", false)
+	require.NoError(t, err)
+	require.Equal(t, timestamped, sig.Hmac)
+
+	_, err = GenerateSignatureHeader(cfg, "msg-1", nil, data)
+	require.Error(t, err)
+}
+
+func Test_GenerateSignatureHeader_StandardWebhooks(t *testing.T) {
+	data := json.RawMessage(`{"event":"payment.created"}`)
+	cfg := &datastore.GroupConfig{Signature: &datastore.SignatureConfiguration{Scheme: datastore.StandardWebhooksSignatureScheme}}
+
+	sig, err := GenerateSignatureHeader(cfg, "msg-1", []string{"new-secret", "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"}, data)
+	require.NoError(t, err)
+	require.Equal(t, "msg-1", sig.ID)
+	require.NotEmpty(t, sig.Timestamp)
+
+	key, err := base64.StdEncoding.DecodeString("MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw")
+	require.NoError(t, err)
+
+	want := "v1," + signStandardWebhooks([]byte("new-secret"), "msg-1", sig.Timestamp, data) +
+		" v1," + signStandardWebhooks(key, "msg-1", sig.Timestamp, data)
+	require.Equal(t, want, sig.Hmac)
+
+	_, err = GenerateSignatureHeader(cfg, "", []string{"new-secret"}, data)
+	require.Error(t, err)
+
+	_, err = GenerateSignatureHeader(cfg, "msg-1", []string{"whsec_%%%"}, data)
+	require.Error(t, err)
+}
+
+func Test_signStandardWebhooks(t *testing.T) {
+	// test vector of the Standard Webhooks spec.
+	key, err := base64.StdEncoding.DecodeString("MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw")
+	require.NoError(t, err)
+
+	sig := signStandardWebhooks(key, "msg_p5jXN8AQM9LWM0D4loKWxJek", "1614265330", []byte(`{"test": 2432232314}`))
+	require.Equal(t, "g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=", sig)
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -37,6 +38,60 @@ func init() {
 		return true
 	})
 
+	govalidator.TagMap["supported_endpoint_authentication"] = govalidator.Validator(func(authType string) bool {
+		types := map[string]bool{
+			string(datastore.BearerEndpointAuthentication): true,
+			string(datastore.BasicEndpointAuthentication):  true,
+			string(datastore.OAuth2EndpointAuthentication): true,
+		}
+
+		if _, ok := types[authType]; !ok {
+			return false
+		}
+
+		return true
+	})
+
+	govalidator.TagMap["supported_proxy_url"] = govalidator.Validator(func(proxyURL string) bool {
+		u, err := url.Parse(proxyURL)
+		if err != nil || IsStringEmpty(u.Host) {
+			return false
+		}
+
+		switch u.Scheme {
+		case "http", "https", "socks5":
+			return true
+		default:
+			return false
+		}
+	})
+
+	govalidator.TagMap["supported_signature_scheme"] = govalidator.Validator(func(scheme string) bool {
+		schemes := map[string]bool{
+			string(datastore.ConvoySignatureScheme):           true,
+			string(datastore.StandardWebhooksSignatureScheme): true,
+		}
+
+		if _, ok := schemes[scheme]; !ok {
+			return false
+		}
+
+		return true
+	})
+
+	govalidator.TagMap["supported_signing_algorithm"] = govalidator.Validator(func(alg string) bool {
+		algs := map[string]bool{
+			string(datastore.Ed25519SigningAlgorithm): true,
+			string(datastore.RSASigningAlgorithm):     true,
+		}
+
+		if _, ok := algs[alg]; !ok {
+			return false
+		}
+
+		return true
+	})
+
 	govalidator.TagMap["supported_source"] = govalidator.Validator(func(source string) bool {
 		sources := map[string]bool{
 			string(datastore.HTTPSource):     true,
@@ -52,6 +107,31 @@ func init() {
 		return true
 	})
 
+	govalidator.TagMap["supported_pub_sub"] = govalidator.Validator(func(pubSub string) bool {
+		pubSubs := map[string]bool{
+			string(datastore.RedisStreamsPubSub): true,
+			string(datastore.AMQPPubSub):         true,
+		}
+
+		if _, ok := pubSubs[pubSub]; !ok {
+			return false
+		}
+
+		return true
+	})
+
+	govalidator.TagMap["supported_change_stream"] = govalidator.Validator(func(changeStream string) bool {
+		changeStreams := map[string]bool{
+			string(datastore.MongoChangeStream): true,
+		}
+
+		if _, ok := changeStreams[changeStream]; !ok {
+			return false
+		}
+
+		return true
+	})
+
 	govalidator.TagMap["supported_verifier"] = govalidator.Validator(func(verifier string) bool {
 		verifiers := map[string]bool{
 			string(datastore.NoopVerifier):      true,
@@ -93,6 +173,46 @@ func init() {
 		return true
 	})
 
+	govalidator.TagMap["supported_delivery_mode"] = govalidator.Validator(func(mode string) bool {
+		modes := map[string]bool{
+			string(datastore.UnorderedDeliveryMode): true,
+			string(datastore.OrderedDeliveryMode):   true,
+		}
+
+		if _, ok := modes[mode]; !ok {
+			return false
+		}
+
+		return true
+	})
+
+	govalidator.TagMap["supported_jitter"] = govalidator.Validator(func(jitter string) bool {
+		jitters := map[string]bool{
+			string(datastore.FullJitter):         true,
+			string(datastore.EqualJitter):        true,
+			string(datastore.DecorrelatedJitter): true,
+		}
+
+		if _, ok := jitters[jitter]; !ok {
+			return false
+		}
+
+		return true
+	})
+
+	govalidator.TagMap["supported_transform"] = govalidator.Validator(func(transform string) bool {
+		transforms := map[string]bool{
+			string(datastore.MappingTransform):  true,
+			string(datastore.TemplateTransform): true,
+		}
+
+		if _, ok := transforms[transform]; !ok {
+			return false
+		}
+
+		return true
+	})
+
 	govalidator.TagMap["supported_storage"] = govalidator.Validator(func(encoder string) bool {
 		encoders := map[string]bool{
 			string(datastore.S3):     true,
@@ -26,6 +26,12 @@ func NewConsumer(q queue.Queuer) (*Consumer, error) {
 				if _, ok := err.(*task.RateLimitError); ok {
 					return false
 				}
+				if _, ok := err.(*task.CircuitBreakerError); ok {
+					return false
+				}
+				if _, ok := err.(*task.BlockedError); ok {
+					return false
+				}
 				return true
 			},
 			RetryDelayFunc: task.GetRetryDelay,
@@ -0,0 +1,257 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/internal/pkg/pubsub"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/pkg/transform"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/frain-dev/convoy/util"
+	"github.com/hibiken/asynq"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// maxSeenIDs is the number of record ids a rest_api source remembers.
+	maxSeenIDs      = 1000
+	defaultMaxPages = 10
+)
+
+var ErrRecordsNotArray = errors.New("records path doesn't point to an array")
+
+// PollRestApiSources polls every rest_api source whose schedule is due, it is
+// scheduled every minute so schedules run at most once a minute. The apis
+// are polled through the dispatchers' egress policy.
+func PollRestApiSources(sourceRepo datastore.SourceRepository, q queue.Queuer, dispatchers *net.DispatcherPool) func(context.Context, *asynq.Task) error {
+	handler := pubsub.NewEventHandler(q)
+
+	return func(ctx context.Context, t *asynq.Task) error {
+		client, err := dispatchers.Client(30 * time.Second)
+		if err != nil {
+			log.WithError(err).Error("failed to create rest api client")
+			return err
+		}
+
+		f := &datastore.SourceFilter{Type: string(datastore.RestApiSource)}
+
+		var sources []datastore.Source
+		for page := 1; ; page++ {
+			s, _, err := sourceRepo.LoadSourcesPaged(ctx, "", f, datastore.Pageable{Page: page, PerPage: 100})
+			if err != nil {
+				log.WithError(err).Error("failed to load rest api sources")
+				return err
+			}
+
+			sources = append(sources, s...)
+			if len(s) < 100 {
+				break
+			}
+		}
+
+		now := time.Now()
+		for i := range sources {
+			source := &sources[i]
+			if source.IsDisabled || source.RestApi == nil {
+				continue
+			}
+
+			var polledAt time.Time
+			if source.RestApiCursor != nil {
+				polledAt = source.RestApiCursor.PolledAt.Time()
+			}
+
+			next, err := source.RestApi.NextPollAt(polledAt)
+			if err != nil {
+				log.WithError(err).Errorf("invalid schedule for source %s", source.UID)
+				continue
+			}
+
+			if now.Before(next) {
+				continue
+			}
+
+			err = pollRestApiSource(ctx, client, sourceRepo, handler, source, now)
+			if err != nil {
+				log.WithError(err).Errorf("failed to poll source %s", source.UID)
+			}
+		}
+
+		return nil
+	}
+}
+
+// pollRestApiSource emits the records of the source's api that it hasn't
+// emitted before, it starts from the page the previous poll stopped at.
+func pollRestApiSource(ctx context.Context, client *http.Client, sourceRepo datastore.SourceRepository, handler pubsub.Handler, source *datastore.Source, now time.Time) error {
+	cfg := source.RestApi
+
+	cursor := source.RestApiCursor
+	if cursor == nil {
+		cursor = &datastore.RestApiCursor{}
+	}
+
+	seen := make(map[string]bool, len(cursor.SeenIDs))
+	for _, id := range cursor.SeenIDs {
+		seen[id] = true
+	}
+
+	maxPages := 1
+	if cfg.Pagination != nil {
+		maxPages = defaultMaxPages
+		if cfg.Pagination.MaxPages > 0 {
+			maxPages = cfg.Pagination.MaxPages
+		}
+	}
+
+	page := cursor.Cursor
+	err := func() error {
+		for i := 0; i < maxPages; i++ {
+			records, next, err := fetchRecords(ctx, client, cfg, page)
+			if err != nil {
+				return err
+			}
+
+			for _, record := range records {
+				v, ok := transform.Lookup(record, cfg.IDField)
+				if !ok || v == nil {
+					log.Errorf("record of source %s has no %s field", source.UID, cfg.IDField)
+					continue
+				}
+
+				id := fmt.Sprint(v)
+				if seen[id] {
+					continue
+				}
+
+				data, err := json.Marshal(record)
+				if err != nil {
+					return err
+				}
+
+				msg := &pubsub.Message{ID: id, EventType: cfg.EventType, Data: data, Headers: http.Header{}}
+				if err = handler(ctx, source, msg); err != nil {
+					return err
+				}
+
+				seen[id] = true
+				cursor.SeenIDs = append(cursor.SeenIDs, id)
+			}
+
+			if util.IsStringEmpty(next) {
+				return nil
+			}
+			page = next
+		}
+
+		return nil
+	}()
+
+	if len(cursor.SeenIDs) > maxSeenIDs {
+		cursor.SeenIDs = cursor.SeenIDs[len(cursor.SeenIDs)-maxSeenIDs:]
+	}
+
+	// a failed poll is retried on the next run.
+	if err == nil {
+		cursor.Cursor = page
+		cursor.PolledAt = primitive.NewDateTimeFromTime(now)
+	}
+
+	if uErr := sourceRepo.UpdateSourceRestApiCursor(ctx, source.GroupID, source.UID, cursor); uErr != nil {
+		return uErr
+	}
+
+	return err
+}
+
+// fetchRecords returns the records of a page and the cursor of the next page.
+func fetchRecords(ctx context.Context, client *http.Client, cfg *datastore.RestApiConfig, page string) ([]interface{}, string, error) {
+	u, err := pageURL(cfg, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	for k, v := range cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, config.MaxRequestSize))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	// numbers are kept as json.Number so large ids aren't formatted as floats.
+	var data interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err = decoder.Decode(&data); err != nil {
+		return nil, "", err
+	}
+
+	v, _ := transform.Lookup(data, cfg.RecordsPath)
+	records, ok := v.([]interface{})
+	if !ok {
+		return nil, "", ErrRecordsNotArray
+	}
+
+	var next string
+	if cfg.Pagination != nil {
+		if v, ok := transform.Lookup(data, cfg.Pagination.NextPath); ok && v != nil {
+			next = fmt.Sprint(v)
+		}
+	}
+
+	return records, next, nil
+}
+
+func pageURL(cfg *datastore.RestApiConfig, page string) (string, error) {
+	if util.IsStringEmpty(page) || cfg.Pagination == nil {
+		return cfg.URL, nil
+	}
+
+	base, err := url.Parse(cfg.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if util.IsStringEmpty(cfg.Pagination.CursorParam) {
+		next, err := base.Parse(page)
+		if err != nil {
+			return "", err
+		}
+		return next.String(), nil
+	}
+
+	query := base.Query()
+	query.Set(cfg.Pagination.CursorParam, page)
+	base.RawQuery = query.Encode()
+
+	return base.String(), nil
+}
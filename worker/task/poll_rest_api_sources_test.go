@@ -0,0 +1,180 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/config"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/frain-dev/convoy/net"
+	"github.com/frain-dev/convoy/queue"
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPollRestApiSources(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":1},{"id":2}],"next":"page-2"}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{"data":[{"id":3}],"next":null}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	restApi := &datastore.RestApiConfig{
+		URL:         srv.URL,
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		Schedule:    "@every 5m",
+		RecordsPath: "$.data",
+		IDField:     "id",
+		EventType:   "record.created",
+		Pagination:  &datastore.RestApiPagination{NextPath: "$.next", CursorParam: "cursor"},
+	}
+
+	tests := []struct {
+		name       string
+		source     datastore.Source
+		wantEvents []string
+		wantCursor *datastore.RestApiCursor
+	}{
+		{
+			name:       "should_emit_records_of_every_page",
+			source:     datastore.Source{UID: "source-1", GroupID: "group-1", Type: datastore.RestApiSource, RestApi: restApi},
+			wantEvents: []string{`{"id":1}`, `{"id":2}`, `{"id":3}`},
+			wantCursor: &datastore.RestApiCursor{Cursor: "page-2", SeenIDs: []string{"1", "2", "3"}},
+		},
+		{
+			name: "should_skip_seen_records",
+			source: datastore.Source{
+				UID: "source-1", GroupID: "group-1", Type: datastore.RestApiSource, RestApi: restApi,
+				RestApiCursor: &datastore.RestApiCursor{Cursor: "page-2", SeenIDs: []string{"1", "2"}},
+			},
+			wantEvents: []string{`{"id":3}`},
+			wantCursor: &datastore.RestApiCursor{Cursor: "page-2", SeenIDs: []string{"1", "2", "3"}},
+		},
+		{
+			name: "should_skip_source_that_is_not_due",
+			source: datastore.Source{
+				UID: "source-1", GroupID: "group-1", Type: datastore.RestApiSource, RestApi: restApi,
+				RestApiCursor: &datastore.RestApiCursor{PolledAt: primitive.NewDateTimeFromTime(time.Now())},
+			},
+		},
+		{
+			name:   "should_skip_disabled_source",
+			source: datastore.Source{UID: "source-1", GroupID: "group-1", Type: datastore.RestApiSource, RestApi: restApi, IsDisabled: true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sourceRepo := mocks.NewMockSourceRepository(ctrl)
+			q := mocks.NewMockQueuer(ctrl)
+
+			sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", &datastore.SourceFilter{Type: string(datastore.RestApiSource)}, gomock.Any()).
+				Return([]datastore.Source{tc.source}, datastore.PaginationData{}, nil)
+
+			var events []string
+			q.EXPECT().Write(convoy.CreateEventProcessor, convoy.CreateEventQueue, gomock.Any()).
+				Times(len(tc.wantEvents)).
+				DoAndReturn(func(_ convoy.TaskName, _ convoy.QueueName, job *queue.Job) error {
+					event := &datastore.Event{}
+					require.NoError(t, json.Unmarshal(job.Payload, event))
+					require.Equal(t, datastore.EventType("record.created"), event.EventType)
+					require.Equal(t, "source-1", event.SourceID)
+
+					events = append(events, string(event.Data))
+					return nil
+				})
+
+			if tc.wantCursor != nil {
+				sourceRepo.EXPECT().UpdateSourceRestApiCursor(gomock.Any(), "group-1", "source-1", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ string, cursor *datastore.RestApiCursor) error {
+						require.Equal(t, tc.wantCursor.Cursor, cursor.Cursor)
+						require.Equal(t, tc.wantCursor.SeenIDs, cursor.SeenIDs)
+						require.NotZero(t, cursor.PolledAt)
+						return nil
+					})
+			}
+
+			dispatchers, err := net.NewDispatcherPool(config.Configuration{})
+			require.NoError(t, err)
+
+			fn := PollRestApiSources(sourceRepo, q, dispatchers)
+			require.NoError(t, fn(context.Background(), asynq.NewTask(string(convoy.PollRestApiSources), nil)))
+			require.Equal(t, tc.wantEvents, events)
+		})
+	}
+}
+
+func TestPollRestApiSources_BlockedDestination(t *testing.T) {
+	var requested bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		_, _ = w.Write([]byte(`{"data":[{"id":1}]}`))
+	}))
+	defer srv.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sourceRepo := mocks.NewMockSourceRepository(ctrl)
+	q := mocks.NewMockQueuer(ctrl)
+
+	source := datastore.Source{
+		UID: "source-1", GroupID: "group-1", Type: datastore.RestApiSource,
+		RestApi: &datastore.RestApiConfig{URL: srv.URL, Schedule: "@every 5m", RecordsPath: "$.data", IDField: "id"},
+	}
+
+	sourceRepo.EXPECT().LoadSourcesPaged(gomock.Any(), "", gomock.Any(), gomock.Any()).
+		Return([]datastore.Source{source}, datastore.PaginationData{}, nil)
+
+	// the failed poll isn't recorded, so it is retried on the next run.
+	sourceRepo.EXPECT().UpdateSourceRestApiCursor(gomock.Any(), "group-1", "source-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, cursor *datastore.RestApiCursor) error {
+			require.Zero(t, cursor.PolledAt)
+			return nil
+		})
+
+	dispatchers, err := net.NewDispatcherPool(config.Configuration{
+		Egress: config.EgressConfiguration{Enabled: true, BlockedCIDRs: []string{"127.0.0.0/8"}},
+	})
+	require.NoError(t, err)
+
+	fn := PollRestApiSources(sourceRepo, q, dispatchers)
+	require.NoError(t, fn(context.Background(), asynq.NewTask(string(convoy.PollRestApiSources), nil)))
+	require.False(t, requested)
+}
+
+func Test_pageURL(t *testing.T) {
+	cfg := &datastore.RestApiConfig{URL: "https://api.example.com/records?limit=10"}
+	u, err := pageURL(cfg, "page-2")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/records?limit=10", u)
+
+	cfg.Pagination = &datastore.RestApiPagination{CursorParam: "after"}
+	u, err = pageURL(cfg, "page-2")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/records?after=page-2&limit=10", u)
+
+	cfg.Pagination = &datastore.RestApiPagination{}
+	u, err = pageURL(cfg, "/records?page=2")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/records?page=2", u)
+}
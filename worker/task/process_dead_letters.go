@@ -1,7 +1,71 @@
 package task
 
-import "github.com/frain-dev/convoy/queue"
+import (
+	"context"
+	"errors"
+	"time"
 
-func ProcessDeadLetters(job *queue.Job) {
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	log "github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
+// ProcessDeadLetters moves a failed event delivery to the dead letter
+// collection along with its final delivery attempt.
+func ProcessDeadLetters(eventDeliveryRepo datastore.EventDeliveryRepository, deadLetterRepo datastore.DeadLetterRepository) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		Id := string(t.Payload())
+
+		ed, err := eventDeliveryRepo.FindEventDeliveryByID(ctx, Id)
+		if err != nil {
+			log.WithError(err).Errorf("failed to load event delivery - %s", Id)
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		if ed.Status != datastore.FailureEventStatus {
+			return nil
+		}
+
+		_, err = deadLetterRepo.FindDeadLetterByEventDeliveryID(ctx, ed.UID)
+		if err == nil {
+			// this event delivery is already in the dead letter queue
+			return nil
+		}
+
+		if !errors.Is(err, datastore.ErrDeadLetterNotFound) {
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		deadLetter := &datastore.DeadLetter{
+			UID:             uuid.NewString(),
+			GroupID:         ed.GroupID,
+			AppID:           ed.AppID,
+			EventID:         ed.EventID,
+			EventDeliveryID: ed.UID,
+			SubscriptionID:  ed.SubscriptionID,
+			EndpointID:      ed.EndpointID,
+			Reason:          ed.Description,
+			CreatedAt:       primitive.NewDateTimeFromTime(time.Now()),
+			UpdatedAt:       primitive.NewDateTimeFromTime(time.Now()),
+			DocumentStatus:  datastore.ActiveDocumentStatus,
+		}
+
+		if ed.Metadata != nil {
+			deadLetter.NumTrials = ed.Metadata.NumTrials
+		}
+
+		if n := len(ed.DeliveryAttempts); n > 0 {
+			deadLetter.LastAttempt = &ed.DeliveryAttempts[n-1]
+		}
+
+		err = deadLetterRepo.CreateDeadLetter(ctx, deadLetter)
+		if err != nil {
+			log.WithError(err).Errorf("failed to create dead letter for event delivery - %s", ed.UID)
+			return &EndpointError{Err: err, delay: 10 * time.Second}
+		}
+
+		return nil
+	}
 }
@@ -0,0 +1,109 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/datastore"
+	"github.com/frain-dev/convoy/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessDeadLetters(t *testing.T) {
+	tests := []struct {
+		name    string
+		dbFn    func(ed *mocks.MockEventDeliveryRepository, dl *mocks.MockDeadLetterRepository)
+		wantErr bool
+	}{
+		{
+			name: "should_create_dead_letter_with_last_attempt",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, dl *mocks.MockDeadLetterRepository) {
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "ed-1").Times(1).Return(&datastore.EventDelivery{
+					UID:            "ed-1",
+					GroupID:        "group-1",
+					AppID:          "app-1",
+					EventID:        "event-1",
+					SubscriptionID: "sub-1",
+					EndpointID:     "endpoint-1",
+					Status:         datastore.FailureEventStatus,
+					Description:    "Retry limit exceeded",
+					Metadata:       &datastore.Metadata{NumTrials: 3, RetryLimit: 3},
+					DeliveryAttempts: []datastore.DeliveryAttempt{
+						{UID: "attempt-1"},
+						{UID: "attempt-2"},
+					},
+				}, nil)
+
+				dl.EXPECT().FindDeadLetterByEventDeliveryID(gomock.Any(), "ed-1").Times(1).Return(nil, datastore.ErrDeadLetterNotFound)
+				dl.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_ context.Context, d *datastore.DeadLetter) error {
+					require.Equal(t, "ed-1", d.EventDeliveryID)
+					require.Equal(t, "group-1", d.GroupID)
+					require.Equal(t, "Retry limit exceeded", d.Reason)
+					require.Equal(t, uint64(3), d.NumTrials)
+					require.Equal(t, "attempt-2", d.LastAttempt.UID)
+					return nil
+				})
+			},
+		},
+		{
+			name: "should_skip_event_delivery_that_did_not_fail",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, dl *mocks.MockDeadLetterRepository) {
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "ed-1").Times(1).Return(&datastore.EventDelivery{
+					UID:    "ed-1",
+					Status: datastore.SuccessEventStatus,
+				}, nil)
+			},
+		},
+		{
+			name: "should_skip_event_delivery_already_in_dead_letter_queue",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, dl *mocks.MockDeadLetterRepository) {
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "ed-1").Times(1).Return(&datastore.EventDelivery{
+					UID:    "ed-1",
+					Status: datastore.FailureEventStatus,
+				}, nil)
+
+				dl.EXPECT().FindDeadLetterByEventDeliveryID(gomock.Any(), "ed-1").Times(1).Return(&datastore.DeadLetter{UID: "dl-1"}, nil)
+			},
+		},
+		{
+			name: "should_error_for_failed_dead_letter_creation",
+			dbFn: func(ed *mocks.MockEventDeliveryRepository, dl *mocks.MockDeadLetterRepository) {
+				ed.EXPECT().FindEventDeliveryByID(gomock.Any(), "ed-1").Times(1).Return(&datastore.EventDelivery{
+					UID:    "ed-1",
+					Status: datastore.FailureEventStatus,
+				}, nil)
+
+				dl.EXPECT().FindDeadLetterByEventDeliveryID(gomock.Any(), "ed-1").Times(1).Return(nil, datastore.ErrDeadLetterNotFound)
+				dl.EXPECT().CreateDeadLetter(gomock.Any(), gomock.Any()).Times(1).Return(errors.New("failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			eventDeliveryRepo := mocks.NewMockEventDeliveryRepository(ctrl)
+			deadLetterRepo := mocks.NewMockDeadLetterRepository(ctrl)
+
+			if tt.dbFn != nil {
+				tt.dbFn(eventDeliveryRepo, deadLetterRepo)
+			}
+
+			task := asynq.NewTask(string(convoy.DeadLetterProcessor), []byte("ed-1"))
+			err := ProcessDeadLetters(eventDeliveryRepo, deadLetterRepo)(context.Background(), task)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
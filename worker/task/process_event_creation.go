@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/frain-dev/convoy"
 	"github.com/frain-dev/convoy/cache"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/pkg/searcher"
+	"github.com/frain-dev/convoy/pkg/httpheader"
 	"github.com/frain-dev/convoy/queue"
 	"github.com/frain-dev/convoy/util"
 	"github.com/google/uuid"
@@ -50,30 +53,19 @@ func ProcessEventCreation(appRepo datastore.ApplicationRepository, eventRepo dat
 		}
 
 		if group.Type == datastore.OutgoingGroup {
-			var app *datastore.Application
-
-			appCacheKey := convoy.ApplicationsCacheKey.Get(event.AppID).String()
-			err = cache.Get(ctx, appCacheKey, &app)
+			apps, err := findEventApplications(ctx, appRepo, cache, group, &event)
 			if err != nil {
 				return &EndpointError{Err: err, delay: 10 * time.Second}
 			}
 
-			// cache miss, load from db
-			if app == nil {
-				app, err = appRepo.FindApplicationByID(ctx, event.AppID)
+			var subs []datastore.Subscription
+			for _, app := range apps {
+				appSubs, err := subRepo.FindSubscriptionsByAppID(ctx, group.UID, app.UID)
 				if err != nil {
-					return &EndpointError{Err: err, delay: 10 * time.Second}
+					return &EndpointError{Err: errors.New("error fetching subscriptions for event type"), delay: 10 * time.Second}
 				}
 
-				err = cache.Set(ctx, appCacheKey, app, 10*time.Minute)
-				if err != nil {
-					return &EndpointError{Err: err, delay: 10 * time.Second}
-				}
-			}
-
-			subs, err := subRepo.FindSubscriptionsByAppID(ctx, group.UID, app.UID)
-			if err != nil {
-				return &EndpointError{Err: errors.New("error fetching subscriptions for event type"), delay: 10 * time.Second}
+				subs = append(subs, appSubs...)
 			}
 
 			subscriptions = matchSubscriptions(string(event.EventType), subs)
@@ -85,15 +77,14 @@ func ProcessEventCreation(appRepo datastore.ApplicationRepository, eventRepo dat
 			}
 		}
 
+		subscriptions = matchSubscriptionsUsingFilter(&event, subscriptions)
+
 		event.MatchedEndpoints = len(subscriptions)
 		err = eventRepo.CreateEvent(ctx, &event)
 		if err != nil {
 			return &EndpointError{Err: err, delay: 10 * time.Second}
 		}
 
-		intervalSeconds := group.Config.Strategy.Duration
-		retryLimit := group.Config.Strategy.RetryCount
-
 		for _, s := range subscriptions {
 			app, err := appRepo.FindApplicationByID(ctx, s.AppID)
 			if err != nil {
@@ -111,14 +102,9 @@ func ProcessEventCreation(appRepo datastore.ApplicationRepository, eventRepo dat
 				s.Endpoint = endpoint
 			}
 
-			metadata := &datastore.Metadata{
-				NumTrials:       0,
-				RetryLimit:      retryLimit,
-				Data:            event.Data,
-				IntervalSeconds: intervalSeconds,
-				Strategy:        group.Config.Strategy.Type,
-				NextSendTime:    primitive.NewDateTimeFromTime(time.Now()),
-			}
+			metadata := retryMetadata(group, &s)
+			metadata.Data = event.Data
+			metadata.NextSendTime = primitive.NewDateTimeFromTime(time.Now())
 
 			eventDelivery := &datastore.EventDelivery{UID: uuid.New().String(),
 				SubscriptionID: s.UID,
@@ -129,6 +115,7 @@ func ProcessEventCreation(appRepo datastore.ApplicationRepository, eventRepo dat
 				EndpointID:     s.EndpointID,
 				DeviceID:       s.DeviceID,
 				Headers:        event.Headers,
+				OrderingKey:    s.DeliveryOrderingKey(event.Data),
 
 				Status:           getEventDeliveryStatus(ctx, &s, app, deviceRepo),
 				DeliveryAttempts: []datastore.DeliveryAttempt{},
@@ -183,12 +170,86 @@ func ProcessEventCreation(appRepo datastore.ApplicationRepository, eventRepo dat
 	}
 }
 
+// retryMetadata returns the retry settings of the subscription's deliveries,
+// the subscription's retry config overrides the group's strategy when it
+// sets a strategy type.
+func retryMetadata(group *datastore.Group, s *datastore.Subscription) *datastore.Metadata {
+	metadata := &datastore.Metadata{
+		Strategy:           group.Config.Strategy.Type,
+		IntervalSeconds:    group.Config.Strategy.Duration,
+		RetryLimit:         group.Config.Strategy.RetryCount,
+		Multiplier:         group.Config.Strategy.Multiplier,
+		MaxIntervalSeconds: group.Config.Strategy.MaxInterval,
+		Jitter:             group.Config.Strategy.Jitter,
+	}
+
+	rc := s.RetryConfig
+	if rc == nil || util.IsStringEmpty(string(rc.Type)) {
+		return metadata
+	}
+
+	// a retry config that only changes the strategy keeps the group's retry count.
+	metadata.Strategy = datastore.StrategyProvider(rc.Type)
+	if rc.RetryCount > 0 {
+		metadata.RetryLimit = uint64(rc.RetryCount)
+	}
+	metadata.Multiplier = rc.Multiplier
+	metadata.Jitter = rc.Jitter
+	metadata.MaxIntervalSeconds = 0
+
+	if duration, err := time.ParseDuration(rc.Duration); err == nil {
+		metadata.IntervalSeconds = uint64(duration.Seconds())
+	}
+
+	if maxInterval, err := time.ParseDuration(rc.MaxInterval); err == nil {
+		metadata.MaxIntervalSeconds = uint64(maxInterval.Seconds())
+	}
+
+	return metadata
+}
+
+// findEventApplications returns the application the event was sent to, or
+// every application with the event's owner id or any of its app tags.
+func findEventApplications(ctx context.Context, appRepo datastore.ApplicationRepository, cache cache.Cache, group *datastore.Group, event *datastore.Event) ([]datastore.Application, error) {
+	if !util.IsStringEmpty(event.OwnerID) {
+		return appRepo.FindApplicationsByOwnerID(ctx, group.UID, event.OwnerID)
+	}
+
+	if len(event.AppTags) > 0 {
+		return appRepo.FindApplicationsByTags(ctx, group.UID, event.AppTags)
+	}
+
+	var app *datastore.Application
+
+	appCacheKey := convoy.ApplicationsCacheKey.Get(event.AppID).String()
+	err := cache.Get(ctx, appCacheKey, &app)
+	if err != nil {
+		return nil, err
+	}
+
+	// cache miss, load from db
+	if app == nil {
+		app, err = appRepo.FindApplicationByID(ctx, event.AppID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = cache.Set(ctx, appCacheKey, app, 10*time.Minute)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return []datastore.Application{*app}, nil
+}
+
 func matchSubscriptions(eventType string, subscriptions []datastore.Subscription) []datastore.Subscription {
 	var matched []datastore.Subscription
 	for _, sub := range subscriptions {
 		for _, ev := range sub.FilterConfig.EventTypes {
-			if ev == eventType || ev == "*" { // if this event type matches, or is *, add the subscription to matched
+			if matchEventType(ev, eventType) {
 				matched = append(matched, sub)
+				break
 			}
 		}
 	}
@@ -196,6 +257,76 @@ func matchSubscriptions(eventType string, subscriptions []datastore.Subscription
 	return matched
 }
 
+// matchEventType reports whether eventType matches the subscription's event type
+// pattern, which is either the exact event type, * or a prefix wildcard like invoice.*
+func matchEventType(pattern string, eventType string) bool {
+	if pattern == eventType || pattern == "*" {
+		return true
+	}
+
+	if strings.HasSuffix(pattern, ".*") {
+		return strings.HasPrefix(eventType, strings.TrimSuffix(pattern, "*"))
+	}
+
+	return false
+}
+
+// matchSubscriptionsUsingFilter drops the subscriptions whose header or body
+// filters do not match the event.
+func matchSubscriptionsUsingFilter(event *datastore.Event, subscriptions []datastore.Subscription) []datastore.Subscription {
+	var matched []datastore.Subscription
+	for _, sub := range subscriptions {
+		if sub.FilterConfig == nil {
+			matched = append(matched, sub)
+			continue
+		}
+
+		headerFilter, bodyFilter, err := sub.FilterConfig.Filter.Filters()
+		if err != nil {
+			log.WithError(err).Errorf("subscription %s has an invalid filter", sub.UID)
+			continue
+		}
+
+		if !headerFilter.Match(headersToFilterable(event.Headers)) {
+			continue
+		}
+
+		ok, err := bodyFilter.MatchJSON(event.Data)
+		if err != nil {
+			log.WithError(err).Errorf("failed to match event %s against subscription %s filter", event.UID, sub.UID)
+			continue
+		}
+
+		if ok {
+			matched = append(matched, sub)
+		}
+	}
+
+	return matched
+}
+
+// headersToFilterable converts the event headers to a value header filters can be
+// matched against. Header names are canonicalized, and single valued headers are
+// matched as strings.
+func headersToFilterable(headers httpheader.HTTPHeader) map[string]interface{} {
+	m := make(map[string]interface{}, len(headers))
+	for k, v := range headers {
+		key := http.CanonicalHeaderKey(k)
+		if len(v) == 1 {
+			m[key] = v[0]
+			continue
+		}
+
+		values := make([]interface{}, len(v))
+		for i := range v {
+			values[i] = v[i]
+		}
+		m[key] = values
+	}
+
+	return m
+}
+
 func getEventDeliveryStatus(ctx context.Context, subscription *datastore.Subscription, app *datastore.Application, deviceRepo datastore.DeviceRepository) datastore.EventDeliveryStatus {
 	if app.IsDisabled {
 		return datastore.DiscardedEventStatus
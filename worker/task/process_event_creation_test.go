@@ -3,6 +3,8 @@ package task
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -142,6 +144,88 @@ func TestProcessEventCreated(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should_fan_out_event_to_apps_with_tags",
+			event: &datastore.Event{
+				UID:       uuid.NewString(),
+				EventType: "invoice.paid",
+				GroupID:   "group-id-1",
+				AppTags:   []string{"enterprise"},
+				Data:      []byte(`{}`),
+				CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+				UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
+			},
+			dbFn: func(args *args) {
+				mockCache, _ := args.cache.(*mocks.MockCache)
+				var gr *datastore.Group
+				mockCache.EXPECT().Get(gomock.Any(), "groups:group-id-1", &gr).Times(1).Return(nil)
+
+				group := &datastore.Group{
+					UID:  "group-id-1",
+					Type: datastore.OutgoingGroup,
+					Config: &datastore.GroupConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       datastore.LinearStrategyProvider,
+							Duration:   10,
+							RetryCount: 3,
+						},
+					},
+				}
+
+				g, _ := args.groupRepo.(*mocks.MockGroupRepository)
+				g.EXPECT().FetchGroupByID(gomock.Any(), "group-id-1").Times(1).Return(group, nil)
+				mockCache.EXPECT().Set(gomock.Any(), "groups:group-id-1", group, 10*time.Minute).Times(1).Return(nil)
+
+				app1 := &datastore.Application{UID: "app-id-1", Tags: []string{"enterprise"}}
+				app2 := &datastore.Application{UID: "app-id-2", Tags: []string{"enterprise", "eu"}}
+
+				a, _ := args.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationsByTags(gomock.Any(), "group-id-1", []string{"enterprise"}).
+					Times(1).Return([]datastore.Application{*app1, *app2}, nil)
+
+				s, _ := args.subRepo.(*mocks.MockSubscriptionRepository)
+				s.EXPECT().FindSubscriptionsByAppID(gomock.Any(), "group-id-1", "app-id-1").Times(1).Return([]datastore.Subscription{
+					{
+						UID:          "456",
+						AppID:        "app-id-1",
+						EndpointID:   "098",
+						Type:         datastore.SubscriptionTypeAPI,
+						Status:       datastore.ActiveSubscriptionStatus,
+						FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"invoice.*"}},
+					},
+				}, nil)
+				s.EXPECT().FindSubscriptionsByAppID(gomock.Any(), "group-id-1", "app-id-2").Times(1).Return([]datastore.Subscription{
+					{
+						UID:          "789",
+						AppID:        "app-id-2",
+						EndpointID:   "099",
+						Type:         datastore.SubscriptionTypeAPI,
+						Status:       datastore.ActiveSubscriptionStatus,
+						FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"invoice.paid"}},
+					},
+				}, nil)
+
+				e, _ := args.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-id-1").Times(1).Return(app1, nil)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-id-2").Times(1).Return(app2, nil)
+
+				a.EXPECT().FindApplicationEndpointByID(gomock.Any(), "app-id-1", "098").
+					Times(1).Return(&datastore.Endpoint{UID: "098", TargetURL: "https://google.com"}, nil)
+				a.EXPECT().FindApplicationEndpointByID(gomock.Any(), "app-id-2", "099").
+					Times(1).Return(&datastore.Endpoint{UID: "099", TargetURL: "https://bing.com"}, nil)
+
+				ed, _ := args.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().CreateEventDelivery(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+				q, _ := args.eventQueue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(2).Return(nil)
+
+				q.EXPECT().Write(convoy.IndexDocument, convoy.PriorityQueue, gomock.Any()).Times(1).Return(nil)
+			},
+			wantErr: false,
+		},
 		{
 			name: "should_process_event_for_incoming_group",
 			event: &datastore.Event{
@@ -216,6 +300,102 @@ func TestProcessEventCreated(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "should_use_subscription_retry_config_over_group_strategy",
+			event: &datastore.Event{
+				UID:       uuid.NewString(),
+				EventType: "*",
+				GroupID:   "group-id-1",
+				AppID:     "app-id-1",
+				Data:      []byte(`{}`),
+				CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+				UpdatedAt: primitive.NewDateTimeFromTime(time.Now()),
+			},
+			dbFn: func(args *args) {
+				group := &datastore.Group{
+					UID:  "group-id-1",
+					Type: datastore.OutgoingGroup,
+					Config: &datastore.GroupConfig{
+						Strategy: &datastore.StrategyConfiguration{
+							Type:       datastore.LinearStrategyProvider,
+							Duration:   10,
+							RetryCount: 3,
+						},
+					},
+				}
+
+				mockCache, _ := args.cache.(*mocks.MockCache)
+				mockCache.EXPECT().Get(gomock.Any(), "groups:group-id-1", gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**datastore.Group) = group
+						return nil
+					})
+
+				app := &datastore.Application{UID: "app-id-1"}
+				mockCache.EXPECT().Get(gomock.Any(), "applications:app-id-1", gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, _ string, data interface{}) error {
+						*data.(**datastore.Application) = app
+						return nil
+					})
+
+				s, _ := args.subRepo.(*mocks.MockSubscriptionRepository)
+				subscriptions := []datastore.Subscription{
+					{
+						UID:        "456",
+						AppID:      "app-id-1",
+						EndpointID: "098",
+						Type:       datastore.SubscriptionTypeAPI,
+						Status:     datastore.ActiveSubscriptionStatus,
+						FilterConfig: &datastore.FilterConfiguration{
+							EventTypes: []string{"*"},
+						},
+						RetryConfig: &datastore.RetryConfiguration{
+							Type:        "exponential",
+							Duration:    "30s",
+							RetryCount:  8,
+							Multiplier:  3,
+							MaxInterval: "1h",
+							Jitter:      datastore.FullJitter,
+						},
+					},
+				}
+				s.EXPECT().FindSubscriptionsByAppID(gomock.Any(), "group-id-1", "app-id-1").Times(1).Return(subscriptions, nil)
+
+				e, _ := args.eventRepo.(*mocks.MockEventRepository)
+				e.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+
+				a, _ := args.appRepo.(*mocks.MockApplicationRepository)
+				a.EXPECT().FindApplicationByID(gomock.Any(), "app-id-1").Times(1).Return(app, nil)
+				a.EXPECT().FindApplicationEndpointByID(gomock.Any(), "app-id-1", "098").
+					Times(1).Return(&datastore.Endpoint{UID: "098", TargetURL: "https://google.com"}, nil)
+
+				want := datastore.Metadata{
+					Strategy:           datastore.ExponentialStrategyProvider,
+					IntervalSeconds:    30,
+					RetryLimit:         8,
+					Multiplier:         3,
+					MaxIntervalSeconds: 3600,
+					Jitter:             datastore.FullJitter,
+				}
+
+				ed, _ := args.eventDeliveryRepo.(*mocks.MockEventDeliveryRepository)
+				ed.EXPECT().CreateEventDelivery(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, delivery *datastore.EventDelivery) error {
+						got := *delivery.Metadata
+						got.Data, got.NextSendTime = nil, 0
+						if !reflect.DeepEqual(want, got) {
+							return fmt.Errorf("unexpected delivery metadata %+v", got)
+						}
+						return nil
+					})
+
+				q, _ := args.eventQueue.(*mocks.MockQueuer)
+				q.EXPECT().Write(convoy.EventProcessor, convoy.EventQueue, gomock.Any()).Times(1).Return(nil)
+
+				q.EXPECT().Write(convoy.IndexDocument, convoy.PriorityQueue, gomock.Any()).Times(1).Return(nil)
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -250,3 +430,180 @@ func TestProcessEventCreated(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchSubscriptions(t *testing.T) {
+	subscriptions := []datastore.Subscription{
+		{UID: "1", FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"*"}}},
+		{UID: "2", FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"invoice.paid"}}},
+		{UID: "3", FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"invoice.*"}}},
+		{UID: "4", FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"charge.*", "invoice.created"}}},
+		{UID: "5", FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"invoice.paid", "invoice.*"}}},
+	}
+
+	tests := []struct {
+		name      string
+		eventType string
+		want      []string
+	}{
+		{
+			name:      "should_match_exact_and_wildcard_event_types",
+			eventType: "invoice.paid",
+			want:      []string{"1", "2", "3", "5"},
+		},
+		{
+			name:      "should_match_prefix_wildcard",
+			eventType: "charge.refunded",
+			want:      []string{"1", "4"},
+		},
+		{
+			name:      "should_not_match_prefix_without_separator",
+			eventType: "invoices",
+			want:      []string{"1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range matchSubscriptions(tt.eventType, subscriptions) {
+				got = append(got, s.UID)
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRetryMetadata(t *testing.T) {
+	group := &datastore.Group{
+		Config: &datastore.GroupConfig{
+			Strategy: &datastore.StrategyConfiguration{
+				Type:       datastore.LinearStrategyProvider,
+				Duration:   10,
+				RetryCount: 3,
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		retryConfig *datastore.RetryConfiguration
+		want        *datastore.Metadata
+	}{
+		{
+			name: "should_use_the_group_strategy",
+			want: &datastore.Metadata{Strategy: datastore.LinearStrategyProvider, IntervalSeconds: 10, RetryLimit: 3},
+		},
+		{
+			name:        "should_use_the_subscription_retry_config",
+			retryConfig: &datastore.RetryConfiguration{Type: "exponential", Duration: "30s", RetryCount: 8, MaxInterval: "1h"},
+			want:        &datastore.Metadata{Strategy: datastore.ExponentialStrategyProvider, IntervalSeconds: 30, RetryLimit: 8, MaxIntervalSeconds: 3600},
+		},
+		{
+			name:        "should_keep_the_group_retry_count_when_the_config_has_none",
+			retryConfig: &datastore.RetryConfiguration{Type: "exponential", Duration: "30s"},
+			want:        &datastore.Metadata{Strategy: datastore.ExponentialStrategyProvider, IntervalSeconds: 30, RetryLimit: 3},
+		},
+		{
+			name:        "should_keep_the_group_interval_when_the_config_has_none",
+			retryConfig: &datastore.RetryConfiguration{Type: "exponential"},
+			want:        &datastore.Metadata{Strategy: datastore.ExponentialStrategyProvider, IntervalSeconds: 10, RetryLimit: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := retryMetadata(group, &datastore.Subscription{RetryConfig: tt.retryConfig})
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchSubscriptionsUsingFilter(t *testing.T) {
+	subscriptions := []datastore.Subscription{
+		{UID: "1", FilterConfig: &datastore.FilterConfiguration{EventTypes: []string{"*"}}},
+		{
+			UID: "2",
+			FilterConfig: &datastore.FilterConfiguration{
+				EventTypes: []string{"*"},
+				Filter: datastore.FilterSchema{
+					Body: map[string]interface{}{"data.amount": map[string]interface{}{"$gt": 1000}},
+				},
+			},
+		},
+		{
+			UID: "3",
+			FilterConfig: &datastore.FilterConfiguration{
+				EventTypes: []string{"*"},
+				Filter: datastore.FilterSchema{
+					Headers: map[string]interface{}{"x-tenant": "acme"},
+				},
+			},
+		},
+		{
+			UID: "4",
+			FilterConfig: &datastore.FilterConfiguration{
+				EventTypes: []string{"*"},
+				Filter: datastore.FilterSchema{
+					Body: map[string]interface{}{"data.amount": map[string]interface{}{"$unknown": 1}},
+				},
+			},
+		},
+		{
+			UID: "5",
+			FilterConfig: &datastore.FilterConfiguration{
+				EventTypes: []string{"*"},
+				Filter: datastore.FilterSchema{
+					Headers: map[string]interface{}{
+						"$or": []interface{}{
+							map[string]interface{}{"x-tenant": "acme"},
+							map[string]interface{}{"x-region": "eu"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		event *datastore.Event
+		want  []string
+	}{
+		{
+			name: "should_match_body_and_header_filters",
+			event: &datastore.Event{
+				Data:    []byte(`{"data": {"amount": 2000}}`),
+				Headers: map[string][]string{"X-Tenant": {"acme"}},
+			},
+			want: []string{"1", "2", "3", "5"},
+		},
+		{
+			name: "should_match_nested_header_filters",
+			event: &datastore.Event{
+				Data:    []byte(`{"data": {"amount": 500}}`),
+				Headers: map[string][]string{"x-region": {"eu"}},
+			},
+			want: []string{"1", "5"},
+		},
+		{
+			name: "should_drop_subscriptions_with_unmatched_filters",
+			event: &datastore.Event{
+				Data:    []byte(`{"data": {"amount": 500}}`),
+				Headers: map[string][]string{"X-Tenant": {"globex"}},
+			},
+			want: []string{"1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range matchSubscriptionsUsingFilter(tt.event, subscriptions) {
+				got = append(got, s.UID)
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
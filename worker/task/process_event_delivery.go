@@ -2,11 +2,16 @@ package task
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/frain-dev/convoy"
+	"github.com/frain-dev/convoy/cache"
+	"github.com/frain-dev/convoy/circuitbreaker"
 	"github.com/frain-dev/convoy/config"
 	"github.com/frain-dev/convoy/datastore"
 	"github.com/frain-dev/convoy/internal/notifications"
@@ -23,6 +28,9 @@ import (
 
 var ErrDeliveryAttemptFailed = errors.New("error sending event")
 var ErrRateLimit = errors.New("rate limit error")
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+var ErrHeadOfLineBlocked = errors.New("event delivery is blocked by the head of its ordering queue")
+var defaultBlockedDelay = 5 * time.Second
 var defaultDelay time.Duration = 30
 
 type SignatureValues struct {
@@ -30,7 +38,7 @@ type SignatureValues struct {
 	Timestamp string
 }
 
-func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, groupRepo datastore.GroupRepository, rateLimiter limiter.RateLimiter, subRepo datastore.SubscriptionRepository, notificationQueue queue.Queuer) func(context.Context, *asynq.Task) error {
+func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDeliveryRepo datastore.EventDeliveryRepository, groupRepo datastore.GroupRepository, rateLimiter limiter.RateLimiter, circuitBreaker circuitbreaker.CircuitBreaker, subRepo datastore.SubscriptionRepository, notificationQueue queue.Queuer, cache cache.Cache, dispatchers *net.DispatcherPool) func(context.Context, *asynq.Task) error {
 	return func(ctx context.Context, t *asynq.Task) error {
 		Id := string(t.Payload())
 
@@ -59,9 +67,65 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 		delayDuration := retrystrategies.NewRetryStrategyFromMetadata(*ed.Metadata).NextDuration(ed.Metadata.NumTrials)
 
 		switch ed.Status {
-		case datastore.ProcessingEventStatus,
-			datastore.SuccessEventStatus:
+		case datastore.SuccessEventStatus:
 			return nil
+		case datastore.ProcessingEventStatus:
+			// a delivery left in processing by a worker that crashed while
+			// sending it is sent again once it is stale.
+			if !ed.IsStaleProcessing(time.Now()) {
+				return nil
+			}
+		}
+
+		// the delivery is discarded, so it doesn't hold back the deliveries
+		// that share its ordering key.
+		if subscription.Status == datastore.InactiveSubscriptionStatus {
+			log.Debugf("subscription %s is inactive, discarding %s.", subscription.UID, ed.UID)
+
+			err = eventDeliveryRepo.UpdateStatusOfEventDelivery(context.Background(), *ed, datastore.DiscardedEventStatus)
+			if err != nil {
+				log.WithError(err).Error("failed to update status of event delivery")
+				return &EndpointError{Err: err, delay: delayDuration}
+			}
+
+			return nil
+		}
+
+		if !util.IsStringEmpty(ed.OrderingKey) {
+			head, err := eventDeliveryRepo.FindOrderingHead(context.Background(), ed.OrderingKey)
+			if err != nil && !errors.Is(err, datastore.ErrEventDeliveryNotFound) {
+				return &EndpointError{Err: err, delay: 10 * time.Second}
+			}
+
+			if err == nil && head.UID != ed.UID {
+				log.Infof("event delivery %s is blocked by %s", ed.UID, head.UID)
+
+				err = eventDeliveryRepo.BlockEventDelivery(context.Background(), *ed, head.UID)
+				if err != nil {
+					log.WithError(err).Error("failed to block event delivery")
+				}
+
+				return &BlockedError{Err: ErrHeadOfLineBlocked, delay: blockedDelay(head, time.Now())}
+			}
+		}
+
+		breaker, err := circuitBreaker.Allow(context.Background(), endpoint.UID)
+		if err != nil {
+			log.WithError(err).Errorf("failed to load circuit breaker for endpoint %s", endpoint.UID)
+		} else if breaker.IsOpen() {
+			log.Infof("circuit breaker for endpoint %s is open, holding back %s", endpoint.UID, ed.UID)
+
+			err = eventDeliveryRepo.UpdateStatusOfEventDelivery(context.Background(), *ed, datastore.CircuitOpenEventStatus)
+			if err != nil {
+				log.WithError(err).Error("failed to update status of event delivery")
+			}
+
+			delay := breaker.RetryAfter(time.Now())
+			if delay < time.Second {
+				delay = time.Second
+			}
+
+			return &CircuitBreakerError{Err: ErrCircuitOpen, delay: delay}
 		}
 
 		var rateLimitDuration time.Duration
@@ -111,7 +175,7 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 		}
 
 		var attempt datastore.DeliveryAttempt
-		var secret = endpoint.Secret
+		var secrets = endpoint.Secrets(time.Now())
 
 		cfg, err := config.Get()
 		if err != nil {
@@ -133,8 +197,6 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			}
 		}
 
-		dispatch := net.NewDispatcher(httpDuration)
-
 		var done = true
 
 		e := endpoint
@@ -143,27 +205,67 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			return nil
 		}
 
-		if subscription.Status == datastore.InactiveSubscriptionStatus {
-			log.Debugf("subscription %s is inactive, failing to send.", e.TargetURL)
-			return nil
-		}
-
 		g, err := groupRepo.FetchGroupByID(context.Background(), app.GroupID)
 		if err != nil {
 			log.WithError(err).Error("could not find error")
 			return &EndpointError{Err: err, delay: delayDuration}
 		}
 
-		sig, err := util.GenerateSignatureHeader(g.Config.ReplayAttacks, g.Config.Signature.Hash, secret, ed.Metadata.Data)
+		proxyURL, err := deliveryProxyURL(g, cfg)
+		if err != nil {
+			log.WithError(err).Errorf("invalid proxy config of group %s", g.UID)
+			return &EndpointError{Err: err, delay: delayDuration}
+		}
+
+		dispatch, err := dispatchers.Dispatcher(httpDuration, endpoint, cache, proxyURL)
+		if err != nil {
+			log.WithError(err).Errorf("invalid tls or authentication config of endpoint %s", endpoint.UID)
+			return &EndpointError{Err: err, delay: delayDuration}
+		}
+
+		payload := ed.Metadata.Data
+		if subscription.TransformConfig != nil {
+			payload, err = transformPayload(subscription.TransformConfig, ed.Metadata.Data)
+			if err != nil {
+				log.WithError(err).Errorf("failed to transform payload for event delivery %s", ed.UID)
+
+				ed.Status = datastore.FailureEventStatus
+				ed.Description = "Payload transformation failed"
+
+				attempt = datastore.DeliveryAttempt{
+					ID:         primitive.NewObjectID(),
+					UID:        uuid.New().String(),
+					URL:        e.TargetURL,
+					Method:     string(convoy.HttpPost),
+					MsgID:      ed.UID,
+					EndpointID: e.UID,
+					APIVersion: "2021-08-27",
+					Error:      err.Error(),
+					CreatedAt:  primitive.NewDateTimeFromTime(time.Now()),
+					UpdatedAt:  primitive.NewDateTimeFromTime(time.Now()),
+				}
+
+				err = eventDeliveryRepo.UpdateEventDeliveryWithAttempt(context.Background(), *ed, attempt)
+				if err != nil {
+					log.WithError(err).Error("failed to update message ", ed.UID)
+				}
+
+				sendToDeadLetterQueue(ed, notificationQueue)
+				return nil
+			}
+		}
+
+		sig, err := util.GenerateSignatureHeader(g.Config, ed.UID, secrets, payload)
 		if err != nil {
 			log.Errorf("error occurred while generating hmac - %+v\n", err)
 			return &EndpointError{Err: err, delay: delayDuration}
 		}
 
 		attemptStatus := false
+		var decision datastore.RetryDecision
 		start := time.Now()
 
-		resp, err := dispatch.SendRequest(e.TargetURL, string(convoy.HttpPost), sig.EncodedData, g, sig.Hmac, sig.Timestamp, int64(cfg.MaxResponseSize), ed.Headers)
+		resp, err := dispatch.SendRequest(e.TargetURL, string(convoy.HttpPost), sig.EncodedData, g, sig, int64(cfg.MaxResponseSize), ed.Headers)
 		status := "-"
 		statusCode := 0
 		if resp != nil {
@@ -195,11 +297,14 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 
 			ed.Status = datastore.RetryEventStatus
 
-			nextTime := time.Now().Add(delayDuration)
-			ed.Metadata.NextSendTime = primitive.NewDateTimeFromTime(nextTime)
-			attempts := ed.Metadata.NumTrials + 1
+			decision, delayDuration = retryPolicy(resp, subscription, delayDuration, retrystrategies.MaxInterval(*ed.Metadata))
+			if !decision.IsTerminal() {
+				nextTime := time.Now().Add(delayDuration)
+				ed.Metadata.NextSendTime = primitive.NewDateTimeFromTime(nextTime)
+				attempts := ed.Metadata.NumTrials + 1
 
-			log.Errorf("%s next retry time is %s (strategy = %s, delay = %d, attempts = %d/%d)\n", ed.UID, nextTime.Format(time.ANSIC), ed.Metadata.Strategy, ed.Metadata.IntervalSeconds, attempts, ed.Metadata.RetryLimit)
+				log.Errorf("%s next retry time is %s (strategy = %s, delay = %d, attempts = %d/%d)\n", ed.UID, nextTime.Format(time.ANSIC), ed.Metadata.Strategy, ed.Metadata.IntervalSeconds, attempts, ed.Metadata.RetryLimit)
+			}
 		}
 
 		// Request failed but statusCode is 200 <= x <= 299
@@ -207,6 +312,10 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			log.Errorf("%s failed. Reason: %s", ed.UID, err)
 		}
 
+		if breaker != nil {
+			recordDeliveryOutcome(circuitBreaker, breaker, app, endpoint, g, notificationQueue, done)
+		}
+
 		if done && subscription.Status == datastore.PendingSubscriptionStatus && g.Config.DisableEndpoint {
 			subscriptionStatus := datastore.ActiveSubscriptionStatus
 			err := subRepo.UpdateSubscriptionStatus(context.Background(), g.UID, subscription.UID, subscriptionStatus)
@@ -233,7 +342,31 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 
 		ed.Metadata.NumTrials++
 
-		if ed.Metadata.NumTrials >= ed.Metadata.RetryLimit {
+		if decision.IsTerminal() {
+			ed.Status = datastore.FailureEventStatus
+			if decision == datastore.GoneDecision {
+				log.Errorf("%s endpoint is gone", ed.UID)
+				ed.Description = "Endpoint is gone"
+			} else {
+				log.Errorf("%s received non-retryable status code %d", ed.UID, statusCode)
+				ed.Description = fmt.Sprintf("Non-retryable status code %d", statusCode)
+			}
+
+			if decision == datastore.GoneDecision && subscription.Status == datastore.ActiveSubscriptionStatus {
+				subscriptionStatus := datastore.InactiveSubscriptionStatus
+
+				err := subRepo.UpdateSubscriptionStatus(context.Background(), g.UID, subscription.UID, subscriptionStatus)
+				if err != nil {
+					log.WithError(err).Error("Failed to deactivate endpoint after it responded with 410 Gone")
+				}
+
+				// send endpoint deactivation notification
+				err = notifications.SendEndpointNotification(context.Background(), app, endpoint, g, subscriptionStatus, notificationQueue, true)
+				if err != nil {
+					log.WithError(err).Error("failed to send notification")
+				}
+			}
+		} else if ed.Metadata.NumTrials >= ed.Metadata.RetryLimit {
 			if done {
 				if ed.Status != datastore.SuccessEventStatus {
 					log.Errorln("an anomaly has occurred. retry limit exceeded, fan out is done but event status is not successful")
@@ -243,6 +376,7 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 				log.Errorf("%s retry limit exceeded ", ed.UID)
 				ed.Description = "Retry limit exceeded"
 				ed.Status = datastore.FailureEventStatus
+				decision = datastore.RetryLimitExceededDecision
 			}
 
 			
@@ -262,18 +396,116 @@ func ProcessEventDelivery(appRepo datastore.ApplicationRepository, eventDelivery
 			}
 		}
 
+		attempt.RetryDecision = decision
+
 		err = eventDeliveryRepo.UpdateEventDeliveryWithAttempt(context.Background(), *ed, attempt)
 		if err != nil {
 			log.WithError(err).Error("failed to update message ", ed.UID)
 		}
 
-		if !done && ed.Metadata.NumTrials < ed.Metadata.RetryLimit {
+		if ed.Status == datastore.FailureEventStatus {
+			sendToDeadLetterQueue(ed, notificationQueue)
+		}
+
+		if !done && !decision.IsTerminal() && ed.Metadata.NumTrials < ed.Metadata.RetryLimit {
 			return &EndpointError{Err: ErrDeliveryAttemptFailed, delay: delayDuration}
 		}
 
 		return nil
 	}
 }
+
+// retryPolicy decides how a failed delivery is handled based on the endpoint's
+// response, it returns the decision and the delay before the next retry. The
+// delay an endpoint asks for is capped to maxDelay.
+func retryPolicy(resp *net.Response, subscription *datastore.Subscription, delay, maxDelay time.Duration) (datastore.RetryDecision, time.Duration) {
+	if resp == nil {
+		return datastore.RetryScheduledDecision, delay
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusGone:
+		return datastore.GoneDecision, delay
+	case subscription.RetryConfig.IsNonRetryable(resp.StatusCode):
+		return datastore.NonRetryableDecision, delay
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusServiceUnavailable:
+		retryAfter := retrystrategies.WithRetryAfter(delay, resp.ResponseHeader, time.Now(), maxDelay)
+		if retryAfter > delay {
+			return datastore.RetryAfterDecision, retryAfter
+		}
+	}
+
+	return datastore.RetryScheduledDecision, delay
+}
+
+// blockedDelay is how long a delivery waits before checking if the head
+// of its ordering queue has been delivered or dead-lettered.
+func blockedDelay(head *datastore.EventDelivery, now time.Time) time.Duration {
+	delay := defaultBlockedDelay
+	if head.Status == datastore.RetryEventStatus && head.Metadata != nil {
+		delay = head.Metadata.NextSendTime.Time().Sub(now)
+	}
+
+	if delay < time.Second {
+		delay = time.Second
+	}
+
+	return delay
+}
+
+// recordDeliveryOutcome updates the endpoint's circuit breaker and sends
+// a notification when the delivery opens or closes the circuit.
+func recordDeliveryOutcome(cb circuitbreaker.CircuitBreaker, before *circuitbreaker.Breaker, app *datastore.Application, endpoint *datastore.Endpoint, g *datastore.Group, q queue.Queuer, success bool) {
+	after, err := cb.Record(context.Background(), endpoint.UID, success)
+	if err != nil {
+		log.WithError(err).Errorf("failed to update circuit breaker for endpoint %s", endpoint.UID)
+		return
+	}
+
+	opened := before.State == circuitbreaker.StateClosed && after.State == circuitbreaker.StateOpen
+	closed := before.State == circuitbreaker.StateHalfOpen && after.State == circuitbreaker.StateClosed
+	if !opened && !closed {
+		return
+	}
+
+	err = notifications.SendCircuitBreakerNotification(context.Background(), app, endpoint, g, after.State, q)
+	if err != nil {
+		log.WithError(err).Error("failed to send notification")
+	}
+}
+
+// sendToDeadLetterQueue schedules a failed event delivery to be moved to the dead letter queue.
+func sendToDeadLetterQueue(ed *datastore.EventDelivery, q queue.Queuer) {
+	job := &queue.Job{
+		Payload: json.RawMessage(ed.UID),
+		Delay:   0,
+	}
+
+	err := q.Write(convoy.DeadLetterProcessor, convoy.DefaultQueue, job)
+	if err != nil {
+		log.WithError(err).Errorf("failed to send event delivery %s to the dead letter queue", ed.UID)
+	}
+}
+
+func transformPayload(cfg *datastore.TransformConfiguration, data json.RawMessage) (json.RawMessage, error) {
+	transformer, err := cfg.Transformer()
+	if err != nil {
+		return nil, err
+	}
+
+	return transformer.Transform(data)
+}
+
+// deliveryProxyURL returns the proxy deliveries of the group are sent
+// through, the group's proxy takes precedence over the global proxy.
+func deliveryProxyURL(g *datastore.Group, cfg config.Configuration) (*url.URL, error) {
+	if g.Config != nil && g.Config.Proxy != nil {
+		return net.NewProxyURL(g.Config.Proxy.URL, g.Config.Proxy.Username, g.Config.Proxy.Password)
+	}
+
+	return net.NewProxyURL(cfg.Proxy.URL, cfg.Proxy.Username, cfg.Proxy.Password)
+}
+
 func parseAttemptFromResponse(m *datastore.EventDelivery, e *datastore.Endpoint, resp *net.Response, attemptStatus bool) datastore.DeliveryAttempt {
 
 	responseHeader := util.ConvertDefaultHeaderToCustomHeader(&resp.ResponseHeader)
@@ -289,6 +521,7 @@ func parseAttemptFromResponse(m *datastore.EventDelivery, e *datastore.Endpoint,
 		APIVersion: "2021-08-27",
 
 		IPAddress:        resp.IP,
+		Proxy:            resp.Proxy,
 		ResponseHeader:   *responseHeader,
 		RequestHeader:    *requestHeader,
 		HttpResponseCode: resp.Status,
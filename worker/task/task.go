@@ -35,6 +35,32 @@ func (e *RateLimitError) Delay() time.Duration {
 func (e *RateLimitError) RateLimit() {
 }
 
+type CircuitBreakerError struct {
+	delay time.Duration
+	Err   error
+}
+
+func (e *CircuitBreakerError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CircuitBreakerError) Delay() time.Duration {
+	return e.delay
+}
+
+type BlockedError struct {
+	delay time.Duration
+	Err   error
+}
+
+func (e *BlockedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *BlockedError) Delay() time.Duration {
+	return e.delay
+}
+
 func GetRetryDelay(n int, err error, t *asynq.Task) time.Duration {
 	if endpointError, ok := err.(*EndpointError); ok {
 		return endpointError.Delay()
@@ -42,5 +68,11 @@ func GetRetryDelay(n int, err error, t *asynq.Task) time.Duration {
 	if rateLimitError, ok := err.(*RateLimitError); ok {
 		return rateLimitError.Delay()
 	}
+	if circuitBreakerError, ok := err.(*CircuitBreakerError); ok {
+		return circuitBreakerError.Delay()
+	}
+	if blockedError, ok := err.(*BlockedError); ok {
+		return blockedError.Delay()
+	}
 	return defaultDelay
 }